@@ -0,0 +1,245 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// BulkOperation performs an API operation on a single target. The request
+// options passed by RunBulk must be passed on to the service method, for
+// example:
+//
+//	op := func(pid int64, options ...gitlab.RequestOptionFunc) (*gitlab.Project, *gitlab.Response, error) {
+//		return client.Projects.EditProject(pid, &gitlab.EditProjectOptions{
+//			OnlyAllowMergeIfPipelineSucceeds: gitlab.Ptr(true),
+//		}, options...)
+//	}
+type BulkOperation[T, R any] func(target T, options ...RequestOptionFunc) (R, *Response, error)
+
+// BulkOptions represents the available options for RunBulk.
+type BulkOptions struct {
+	// Concurrency is the maximum number of operations running at the same
+	// time. Defaults to 4.
+	Concurrency int
+
+	// DryRun builds the first request of every operation without sending
+	// it. The request is reported in BulkResult.Request.
+	DryRun bool
+
+	// Limiter optionally limits the rate at which operations are started.
+	// The requests are always limited by the rate limiter of the client
+	// they are sent with.
+	Limiter RateLimiter
+}
+
+// BulkRequest describes a request built by an operation during a dry run.
+type BulkRequest struct {
+	Method string
+	URL    string
+}
+
+// BulkResult is the result of a BulkOperation for a single target.
+type BulkResult[T, R any] struct {
+	Target   T
+	Value    R
+	Response *Response
+	Err      error
+
+	// DryRun reports whether the operation was not executed, because of
+	// BulkOptions.DryRun. Request then describes the request that would
+	// have been sent.
+	DryRun  bool
+	Request *BulkRequest
+}
+
+// Class classifies the error of the result.
+func (r *BulkResult[T, R]) Class() BulkErrorClass {
+	return ClassifyError(r.Err)
+}
+
+// BulkResults are the results of RunBulk, in the order of the targets.
+type BulkResults[T, R any] []*BulkResult[T, R]
+
+// Succeeded returns the results without an error.
+func (rs BulkResults[T, R]) Succeeded() BulkResults[T, R] {
+	var succeeded BulkResults[T, R]
+	for _, r := range rs {
+		if r.Err == nil {
+			succeeded = append(succeeded, r)
+		}
+	}
+	return succeeded
+}
+
+// Failed returns the results with an error.
+func (rs BulkResults[T, R]) Failed() BulkResults[T, R] {
+	var failed BulkResults[T, R]
+	for _, r := range rs {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// ByClass groups the failed results by the class of their error.
+func (rs BulkResults[T, R]) ByClass() map[BulkErrorClass]BulkResults[T, R] {
+	classes := make(map[BulkErrorClass]BulkResults[T, R])
+	for _, r := range rs.Failed() {
+		c := r.Class()
+		classes[c] = append(classes[c], r)
+	}
+	return classes
+}
+
+// Err returns an error combining the errors of all failed results, or nil
+// if all operations succeeded.
+func (rs BulkResults[T, R]) Err() error {
+	var errs []error
+	for _, r := range rs.Failed() {
+		errs = append(errs, fmt.Errorf("%v: %w", r.Target, r.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// BulkErrorClass classifies the errors of bulk operations, so failures can
+// be handled by category, for example to retry only rate limited targets.
+type BulkErrorClass string
+
+// The available error classes.
+const (
+	BulkErrorNone         BulkErrorClass = ""
+	BulkErrorNotFound     BulkErrorClass = "not_found"
+	BulkErrorUnauthorized BulkErrorClass = "unauthorized"
+	BulkErrorForbidden    BulkErrorClass = "forbidden"
+	BulkErrorConflict     BulkErrorClass = "conflict"
+	BulkErrorInvalid      BulkErrorClass = "invalid"
+	BulkErrorRateLimited  BulkErrorClass = "rate_limited"
+	BulkErrorServer       BulkErrorClass = "server"
+	BulkErrorCanceled     BulkErrorClass = "canceled"
+	BulkErrorOther        BulkErrorClass = "other"
+)
+
+// ClassifyError returns the BulkErrorClass of an error returned by the
+// client.
+func ClassifyError(err error) BulkErrorClass {
+	if err == nil {
+		return BulkErrorNone
+	}
+	if errors.Is(err, ErrNotFound) {
+		return BulkErrorNotFound
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return BulkErrorCanceled
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return BulkErrorOther
+	}
+
+	switch code := errResp.Response.StatusCode; {
+	case code == http.StatusNotFound:
+		return BulkErrorNotFound
+	case code == http.StatusUnauthorized:
+		return BulkErrorUnauthorized
+	case code == http.StatusForbidden:
+		return BulkErrorForbidden
+	case code == http.StatusConflict:
+		return BulkErrorConflict
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		return BulkErrorInvalid
+	case code == http.StatusTooManyRequests:
+		return BulkErrorRateLimited
+	case code >= 500:
+		return BulkErrorServer
+	}
+
+	return BulkErrorOther
+}
+
+// errBulkDryRun aborts the requests of an operation during a dry run.
+var errBulkDryRun = errors.New("dry run")
+
+// RunBulk runs op for every target, with at most BulkOptions.Concurrency
+// operations running at the same time. A failing operation doesn't stop the
+// others; the error is reported in its result instead. Once ctx is done, no
+// more operations are started and the remaining targets fail with the error
+// of the context.
+//
+// The options can be nil to use the defaults.
+func RunBulk[T, R any](ctx context.Context, targets []T, op BulkOperation[T, R], opt *BulkOptions) BulkResults[T, R] {
+	concurrency := 4
+	if opt == nil {
+		opt = new(BulkOptions)
+	}
+	if opt.Concurrency > 0 {
+		concurrency = opt.Concurrency
+	}
+
+	results := make(BulkResults[T, R], len(targets))
+	for i, target := range targets {
+		results[i] = &BulkResult[T, R]{Target: target}
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for _, r := range results {
+		select {
+		case <-ctx.Done():
+			r.Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		// Both cases above might have been ready at the same time.
+		err := ctx.Err()
+		if err == nil && opt.Limiter != nil {
+			err = opt.Limiter.Wait(ctx)
+		}
+		if err != nil {
+			r.Err = err
+			<-sem
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			runBulkOperation(ctx, r, op, opt.DryRun)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func runBulkOperation[T, R any](ctx context.Context, r *BulkResult[T, R], op BulkOperation[T, R], dryRun bool) {
+	options := []RequestOptionFunc{WithContext(ctx)}
+	if dryRun {
+		options = append(options, func(req *retryablehttp.Request) error {
+			if r.Request == nil {
+				r.Request = &BulkRequest{Method: req.Method, URL: req.URL.String()}
+			}
+			return errBulkDryRun
+		})
+	}
+
+	r.Value, r.Response, r.Err = op(r.Target, options...)
+
+	if dryRun && (r.Request != nil || errors.Is(r.Err, errBulkDryRun)) {
+		var zero R
+		r.Value, r.Response, r.Err = zero, nil, nil
+		r.DryRun = true
+	}
+}
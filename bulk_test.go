@@ -0,0 +1,161 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBulk(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	var inFlight, maxInFlight atomic.Int32
+	mux.HandleFunc("/api/v4/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		switch id := r.PathValue("id"); id {
+		case "3":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "404 Project Not Found"}`)
+		case "4":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "403 Forbidden"}`)
+		case "5":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message": {"name": ["is invalid"]}}`)
+		default:
+			fmt.Fprintf(w, `{"id": %s}`, id)
+		}
+	})
+
+	op := func(pid int64, options ...RequestOptionFunc) (*Project, *Response, error) {
+		return client.Projects.EditProject(pid, &EditProjectOptions{Description: Ptr("managed")}, options...)
+	}
+
+	results := RunBulk(context.Background(), []int64{1, 2, 3, 4, 5, 6, 7, 8}, op, &BulkOptions{Concurrency: 3})
+
+	require.Len(t, results, 8)
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+
+	for i, r := range results {
+		assert.Equal(t, int64(i+1), r.Target)
+	}
+	assert.Equal(t, int64(1), results[0].Value.ID)
+	assert.Equal(t, http.StatusOK, results[0].Response.StatusCode)
+
+	assert.Len(t, results.Succeeded(), 5)
+	assert.Len(t, results.Failed(), 3)
+
+	classes := results.ByClass()
+	require.Len(t, classes[BulkErrorNotFound], 1)
+	assert.Equal(t, int64(3), classes[BulkErrorNotFound][0].Target)
+	require.Len(t, classes[BulkErrorForbidden], 1)
+	assert.Equal(t, int64(4), classes[BulkErrorForbidden][0].Target)
+	require.Len(t, classes[BulkErrorInvalid], 1)
+	assert.Equal(t, int64(5), classes[BulkErrorInvalid][0].Target)
+
+	err := results.Err()
+	require.Error(t, err)
+	assert.True(t, HasStatusCode(err, http.StatusForbidden))
+	assert.True(t, strings.HasPrefix(err.Error(), "3: "))
+}
+
+func TestRunBulk_DryRun(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	op := func(pid any, options ...RequestOptionFunc) (*ProjectVariable, *Response, error) {
+		return client.ProjectVariables.CreateVariable(pid, &CreateProjectVariableOptions{
+			Key:   Ptr("DEPLOY_ENV"),
+			Value: Ptr("production"),
+		}, options...)
+	}
+
+	results := RunBulk(context.Background(), []any{"group/project", 2, 1.5}, op, &BulkOptions{DryRun: true})
+
+	require.Len(t, results, 3)
+
+	assert.True(t, results[0].DryRun)
+	assert.NoError(t, results[0].Err)
+	require.NotNil(t, results[0].Request)
+	assert.Equal(t, http.MethodPost, results[0].Request.Method)
+	assert.True(t, strings.HasSuffix(results[0].Request.URL, "/api/v4/projects/group%2Fproject/variables"))
+
+	assert.True(t, results[1].DryRun)
+	assert.True(t, strings.HasSuffix(results[1].Request.URL, "/api/v4/projects/2/variables"))
+
+	// Invalid targets still fail during a dry run.
+	assert.False(t, results[2].DryRun)
+	assert.ErrorIs(t, results[2].Err, ErrInvalidIDType)
+	assert.Equal(t, BulkErrorOther, results[2].Class())
+}
+
+func TestRunBulk_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls atomic.Int32
+	op := func(target int, options ...RequestOptionFunc) (int, *Response, error) {
+		calls.Add(1)
+		cancel()
+		return target, nil, nil
+	}
+
+	results := RunBulk(ctx, []int{1, 2, 3, 4}, op, &BulkOptions{Concurrency: 1})
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.NoError(t, results[0].Err)
+	for _, r := range results[1:] {
+		assert.ErrorIs(t, r.Err, context.Canceled)
+		assert.Equal(t, BulkErrorCanceled, r.Class())
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	errWithStatus := func(code int) error {
+		return &ErrorResponse{Response: &http.Response{StatusCode: code}}
+	}
+
+	tests := map[BulkErrorClass]error{
+		BulkErrorNone:         nil,
+		BulkErrorNotFound:     ErrNotFound,
+		BulkErrorUnauthorized: errWithStatus(http.StatusUnauthorized),
+		BulkErrorConflict:     fmt.Errorf("wrapped: %w", errWithStatus(http.StatusConflict)),
+		BulkErrorInvalid:      errWithStatus(http.StatusUnprocessableEntity),
+		BulkErrorRateLimited:  errWithStatus(http.StatusTooManyRequests),
+		BulkErrorServer:       errWithStatus(http.StatusBadGateway),
+		BulkErrorCanceled:     context.DeadlineExceeded,
+		BulkErrorOther:        errors.New("unexpected"),
+	}
+
+	for want, err := range tests {
+		assert.Equal(t, want, ClassifyError(err), "%v", err)
+	}
+}
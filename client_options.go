@@ -111,6 +111,44 @@ func WithHTTPClient(httpClient *http.Client) ClientOptionFunc {
 	}
 }
 
+// WithInstrumentation can be used to observe every API call made by the
+// client, for example to create tracing spans and record metrics. See
+// Instrumentation for details.
+func WithInstrumentation(instrumentation Instrumentation) ClientOptionFunc {
+	return func(c *Client) error {
+		if instrumentation == nil {
+			return errors.New("instrumentation cannot be nil")
+		}
+		c.instrumentation = instrumentation
+		return nil
+	}
+}
+
+// WithResponseCache can be used to make conditional GET requests using the
+// ETags of the responses stored in cache. See ResponseCache for details.
+func WithResponseCache(cache ResponseCache) ClientOptionFunc {
+	return func(c *Client) error {
+		if cache == nil {
+			return errors.New("response cache cannot be nil")
+		}
+		c.responseCache = cache
+		return nil
+	}
+}
+
+// WithResponseCacheMaxBodySize sets the size of the largest response body in
+// bytes that is stored in the ResponseCache. Larger responses are passed
+// through without being cached. Defaults to DefaultResponseCacheMaxBodySize.
+func WithResponseCacheMaxBodySize(size int64) ClientOptionFunc {
+	return func(c *Client) error {
+		if size <= 0 {
+			return errors.New("response cache max body size must be positive")
+		}
+		c.responseCacheMaxBodySize = size
+		return nil
+	}
+}
+
 // WithRequestLogHook can be used to configure a custom request log hook.
 func WithRequestLogHook(hook retryablehttp.RequestLogHook) ClientOptionFunc {
 	return func(c *Client) error {
@@ -127,6 +165,16 @@ func WithResponseLogHook(hook retryablehttp.ResponseLogHook) ClientOptionFunc {
 	}
 }
 
+// WithRetryPolicies can be used to configure declarative retry policies per
+// HTTP method or endpoint class, and a retry budget. See RetryPolicies for
+// details.
+func WithRetryPolicies(policies RetryPolicies) ClientOptionFunc {
+	return func(c *Client) error {
+		c.retryPolicies = &policies
+		return nil
+	}
+}
+
 // WithoutRetries disables the default retry logic.
 func WithoutRetries() ClientOptionFunc {
 	return func(c *Client) error {
@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"errors"
+	"io/fs"
 	"net/http"
 	"time"
 )
@@ -53,6 +54,18 @@ type (
 		// https://docs.gitlab.com/api/commits/#create-a-commit-with-multiple-files-and-actions
 		CreateCommit(pid any, opt *CreateCommitOptions, options ...RequestOptionFunc) (*Commit, *Response, error)
 
+		// SyncFiles commits the files of fsys to a branch, so the directory
+		// PathPrefix of the branch matches them. Unchanged files are skipped by
+		// comparing their git blob SHAs with the repository tree, binary files
+		// are committed base64 encoded, and large change sets are split into
+		// multiple commits. Optionally, a merge request is opened for the
+		// changes. The executable bits of the files are only synced if
+		// SyncFileModes is set.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/commits/#create-a-commit-with-multiple-files-and-actions
+		SyncFiles(pid any, fsys fs.FS, opt *SyncFilesOptions, options ...RequestOptionFunc) (*SyncFilesResult, error)
+
 		// GetCommitDiff gets the diff of a commit in a project.
 		//
 		// GitLab API docs:
@@ -0,0 +1,369 @@
+package gitlab
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultSyncMaxActionsPerCommit = 100
+	defaultSyncMaxBytesPerCommit   = 10 << 20
+)
+
+// SyncFilesOptions represents the available SyncFiles() options.
+type SyncFilesOptions struct {
+	// Branch is the branch the changes are committed to. Required.
+	Branch string
+
+	// StartBranch is the branch Branch is created from, if it doesn't exist
+	// yet.
+	StartBranch string
+
+	// PathPrefix is the directory of the repository the files are synced
+	// to. Defaults to the root of the repository.
+	PathPrefix string
+
+	// CommitMessage is the message of the commits. Required. If the changes
+	// are split into multiple commits, the messages are suffixed with the
+	// number of the commit, like " (1/3)".
+	CommitMessage string
+	AuthorName    string
+	AuthorEmail   string
+
+	// DeleteMissing deletes the files below PathPrefix which don't exist
+	// in the synced file system. Deleted files with the same content as a
+	// new file are moved instead.
+	DeleteMissing bool
+
+	// SyncFileModes makes the executable bit of the committed files match
+	// the mode bits of the synced files. Leave it unset for file systems
+	// without mode bits, like embed.FS, so existing files keep their mode
+	// in the repository and new files are created without the executable
+	// bit.
+	SyncFileModes bool
+
+	// MaxActionsPerCommit and MaxBytesPerCommit split large change sets
+	// into multiple commits. They default to 100 actions and 10 MiB of
+	// encoded content.
+	MaxActionsPerCommit int
+	MaxBytesPerCommit   int
+
+	// MergeRequest optionally opens a merge request from Branch after the
+	// changes are committed. The source branch is set to Branch, the target
+	// branch defaults to StartBranch. If an open merge request from Branch
+	// already exists, it is returned instead.
+	MergeRequest *CreateMergeRequestOptions
+}
+
+// SyncFilesResult is the result of SyncFiles.
+type SyncFilesResult struct {
+	// Actions are the actions needed to sync the files, in the order they
+	// were committed.
+	Actions []*CommitActionOptions
+
+	// Commits are the created commits.
+	Commits []*Commit
+
+	// MergeRequest is the merge request of the changes, if requested.
+	MergeRequest *BasicMergeRequest
+}
+
+// syncFile is a file of a tree to sync.
+type syncFile struct {
+	sha        string
+	executable bool
+}
+
+func (s *CommitsService) SyncFiles(pid any, fsys fs.FS, opt *SyncFilesOptions, options ...RequestOptionFunc) (*SyncFilesResult, error) {
+	if opt == nil || opt.Branch == "" {
+		return nil, errors.New("missing required option: Branch")
+	}
+	if opt.CommitMessage == "" {
+		return nil, errors.New("missing required option: CommitMessage")
+	}
+	prefix := strings.Trim(opt.PathPrefix, "/")
+
+	// Compare with Branch if it exists, otherwise with the branch it is
+	// created from.
+	ref, startBranch := opt.Branch, ""
+	if _, _, err := s.client.Branches.GetBranch(pid, opt.Branch, options...); err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		ref, startBranch = opt.StartBranch, opt.StartBranch
+	}
+
+	remote := make(map[string]syncFile)
+	if ref != "" {
+		nodes, err := ScanAndCollect(func(p PaginationOptionFunc) ([]*TreeNode, *Response, error) {
+			return s.client.Repositories.ListTree(pid, &ListTreeOptions{
+				Path:      Ptr(prefix),
+				Ref:       Ptr(ref),
+				Recursive: Ptr(true),
+			}, append(options[:len(options):len(options)], p)...)
+		})
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		for _, n := range nodes {
+			// Symbolic links and submodules are not synced.
+			if n.Type == "blob" && n.Mode != "120000" {
+				remote[n.Path] = syncFile{sha: n.ID, executable: n.Mode == "100755"}
+			}
+		}
+	}
+
+	actions, err := syncActions(fsys, prefix, remote, opt.DeleteMissing, opt.SyncFileModes)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncFilesResult{Actions: actions}
+	batches := splitSyncActions(actions, opt.MaxActionsPerCommit, opt.MaxBytesPerCommit)
+	for i, batch := range batches {
+		message := opt.CommitMessage
+		if len(batches) > 1 {
+			message = fmt.Sprintf("%s (%d/%d)", message, i+1, len(batches))
+		}
+
+		commitOpt := &CreateCommitOptions{
+			Branch:        Ptr(opt.Branch),
+			CommitMessage: Ptr(message),
+			Actions:       batch,
+		}
+		if i == 0 && startBranch != "" {
+			commitOpt.StartBranch = Ptr(startBranch)
+		}
+		if opt.AuthorName != "" {
+			commitOpt.AuthorName = Ptr(opt.AuthorName)
+		}
+		if opt.AuthorEmail != "" {
+			commitOpt.AuthorEmail = Ptr(opt.AuthorEmail)
+		}
+
+		commit, _, err := s.CreateCommit(pid, commitOpt, options...)
+		if err != nil {
+			return result, fmt.Errorf("creating commit %d of %d: %w", i+1, len(batches), err)
+		}
+		result.Commits = append(result.Commits, commit)
+	}
+
+	if opt.MergeRequest == nil || len(result.Commits) == 0 {
+		return result, nil
+	}
+
+	mr, err := s.syncMergeRequest(pid, opt, options)
+	if err != nil {
+		return result, fmt.Errorf("opening merge request: %w", err)
+	}
+	result.MergeRequest = mr
+
+	return result, nil
+}
+
+// syncMergeRequest returns the open merge request from the synced branch,
+// creating it if it doesn't exist yet.
+func (s *CommitsService) syncMergeRequest(pid any, opt *SyncFilesOptions, options []RequestOptionFunc) (*BasicMergeRequest, error) {
+	existing, _, err := s.client.MergeRequests.ListProjectMergeRequests(pid, &ListProjectMergeRequestsOptions{
+		SourceBranch: Ptr(opt.Branch),
+		State:        Ptr("opened"),
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return existing[0], nil
+	}
+
+	mrOpt := *opt.MergeRequest
+	mrOpt.SourceBranch = Ptr(opt.Branch)
+	if mrOpt.TargetBranch == nil {
+		if opt.StartBranch == "" {
+			return nil, errors.New("missing target branch")
+		}
+		mrOpt.TargetBranch = Ptr(opt.StartBranch)
+	}
+	if mrOpt.Title == nil {
+		mrOpt.Title = Ptr(opt.CommitMessage)
+	}
+
+	mr, _, err := s.client.MergeRequests.CreateMergeRequest(pid, &mrOpt, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &mr.BasicMergeRequest, nil
+}
+
+// syncActions returns the actions needed to turn the remote files below
+// prefix into the files of fsys, sorted by path. The executable bits are only
+// compared if syncModes is set.
+func syncActions(fsys fs.FS, prefix string, remote map[string]syncFile, deleteMissing, syncModes bool) ([]*CommitActionOptions, error) {
+	type localFile struct {
+		syncFile
+		path    string
+		content []byte
+	}
+
+	var (
+		local   []*localFile
+		present = make(map[string]bool)
+	)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		f := &localFile{
+			syncFile: syncFile{sha: gitBlobSHA(content), executable: info.Mode()&0o111 != 0},
+			path:     path.Join(prefix, p),
+			content:  content,
+		}
+		present[f.path] = true
+		local = append(local, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Files which are deleted can be moved to new files with the same
+	// content instead.
+	deleted := make(map[string][]string)
+	if deleteMissing {
+		for p, f := range remote {
+			if !present[p] {
+				deleted[f.sha] = append(deleted[f.sha], p)
+			}
+		}
+		for _, paths := range deleted {
+			sort.Strings(paths)
+		}
+	}
+
+	var actions []*CommitActionOptions
+	moved := make(map[string]bool)
+	for _, f := range local {
+		r, exists := remote[f.path]
+		switch {
+		case !exists && len(deleted[f.sha]) > 0:
+			from := deleted[f.sha][0]
+			deleted[f.sha] = deleted[f.sha][1:]
+			moved[from] = true
+
+			actions = append(actions, &CommitActionOptions{
+				Action:       Ptr(FileMove),
+				FilePath:     Ptr(f.path),
+				PreviousPath: Ptr(from),
+			})
+			r.executable = remote[from].executable
+		case !exists:
+			actions = append(actions, syncContentAction(FileCreate, f.path, f.content))
+		case r.sha != f.sha:
+			actions = append(actions, syncContentAction(FileUpdate, f.path, f.content))
+		}
+
+		if syncModes && f.executable != r.executable {
+			actions = append(actions, &CommitActionOptions{
+				Action:          Ptr(FileChmod),
+				FilePath:        Ptr(f.path),
+				ExecuteFilemode: Ptr(f.executable),
+			})
+		}
+	}
+
+	if deleteMissing {
+		for p := range remote {
+			if !present[p] && !moved[p] {
+				actions = append(actions, &CommitActionOptions{
+					Action:   Ptr(FileDelete),
+					FilePath: Ptr(p),
+				})
+			}
+		}
+	}
+
+	// Keep the order of the actions of a file, a chmod follows its create.
+	sort.SliceStable(actions, func(i, j int) bool {
+		return *actions[i].FilePath < *actions[j].FilePath
+	})
+
+	return actions, nil
+}
+
+// syncContentAction returns a create or update action, encoding binary
+// content as base64.
+func syncContentAction(action FileActionValue, filePath string, content []byte) *CommitActionOptions {
+	a := &CommitActionOptions{
+		Action:   Ptr(action),
+		FilePath: Ptr(filePath),
+	}
+	if utf8.Valid(content) && !strings.ContainsRune(string(content), 0) {
+		a.Content = Ptr(string(content))
+	} else {
+		a.Content = Ptr(base64.StdEncoding.EncodeToString(content))
+		a.Encoding = Ptr("base64")
+	}
+	return a
+}
+
+// splitSyncActions splits actions into batches of at most maxActions actions
+// and maxBytes of content. A single action larger than maxBytes gets its own
+// batch.
+func splitSyncActions(actions []*CommitActionOptions, maxActions, maxBytes int) [][]*CommitActionOptions {
+	if maxActions <= 0 {
+		maxActions = defaultSyncMaxActionsPerCommit
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultSyncMaxBytesPerCommit
+	}
+
+	var (
+		batches [][]*CommitActionOptions
+		batch   []*CommitActionOptions
+		size    int
+	)
+	for _, a := range actions {
+		n := 0
+		if a.Content != nil {
+			n = len(*a.Content)
+		}
+		if len(batch) > 0 && (len(batch) >= maxActions || size+n > maxBytes) {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+		batch = append(batch, a)
+		size += n
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// gitBlobSHA returns the object ID git assigns to a blob with the given
+// content.
+func gitBlobSHA(content []byte) string {
+	h := sha1.New()
+	h.Write([]byte("blob " + strconv.Itoa(len(content)) + "\x00"))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
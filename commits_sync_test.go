@@ -0,0 +1,228 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncFiles(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("GET /api/v4/projects/1/repository/branches/sync", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "sync"}`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/1/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		testParam(t, r, "path", "gen")
+		testParam(t, r, "ref", "sync")
+		testParam(t, r, "recursive", "true")
+		fmt.Fprintf(w, `[
+			{"id": "%s", "type": "blob", "path": "gen/same.txt", "mode": "100644"},
+			{"id": "%s", "type": "blob", "path": "gen/changed.txt", "mode": "100644"},
+			{"id": "%s", "type": "blob", "path": "gen/old-name.txt", "mode": "100644"},
+			{"id": "%s", "type": "blob", "path": "gen/stale.txt", "mode": "100644"},
+			{"id": "%s", "type": "blob", "path": "gen/run.sh", "mode": "100644"},
+			{"id": "0000000000000000000000000000000000000000", "type": "blob", "path": "gen/link", "mode": "120000"},
+			{"id": "0000000000000000000000000000000000000000", "type": "tree", "path": "gen/dir", "mode": "040000"}
+		]`,
+			gitBlobSHA([]byte("same")),
+			gitBlobSHA([]byte("old")),
+			gitBlobSHA([]byte("moved content")),
+			gitBlobSHA([]byte("stale")),
+			gitBlobSHA([]byte("#!/bin/sh\n")),
+		)
+	})
+
+	var (
+		mu      sync.Mutex
+		commits []*CreateCommitOptions
+	)
+	mux.HandleFunc("POST /api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		opt := new(CreateCommitOptions)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(opt))
+
+		mu.Lock()
+		commits = append(commits, opt)
+		n := len(commits)
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"id": "commit%d"}`, n)
+	})
+	mux.HandleFunc("GET /api/v4/projects/1/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		testParam(t, r, "source_branch", "sync")
+		testParam(t, r, "state", "opened")
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("POST /api/v4/projects/1/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		testBodyJSON(t, r, map[string]string{
+			"title":         "Sync generated code",
+			"source_branch": "sync",
+			"target_branch": "main",
+		})
+		fmt.Fprint(w, `{"iid": 5}`)
+	})
+
+	fsys := fstest.MapFS{
+		"same.txt":     {Data: []byte("same")},
+		"changed.txt":  {Data: []byte("new")},
+		"new-name.txt": {Data: []byte("moved content")},
+		"run.sh":       {Data: []byte("#!/bin/sh\n"), Mode: 0o755},
+		"bin.dat":      {Data: []byte{0, 1, 2}},
+	}
+
+	result, err := client.Commits.SyncFiles(1, fsys, &SyncFilesOptions{
+		Branch:              "sync",
+		StartBranch:         "main",
+		PathPrefix:          "/gen/",
+		CommitMessage:       "Sync generated code",
+		DeleteMissing:       true,
+		SyncFileModes:       true,
+		MaxActionsPerCommit: 3,
+		MergeRequest:        &CreateMergeRequestOptions{},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*CommitActionOptions{
+		{Action: Ptr(FileCreate), FilePath: Ptr("gen/bin.dat"), Content: Ptr("AAEC"), Encoding: Ptr("base64")},
+		{Action: Ptr(FileUpdate), FilePath: Ptr("gen/changed.txt"), Content: Ptr("new")},
+		{Action: Ptr(FileMove), FilePath: Ptr("gen/new-name.txt"), PreviousPath: Ptr("gen/old-name.txt")},
+		{Action: Ptr(FileChmod), FilePath: Ptr("gen/run.sh"), ExecuteFilemode: Ptr(true)},
+		{Action: Ptr(FileDelete), FilePath: Ptr("gen/stale.txt")},
+	}, result.Actions)
+
+	require.Len(t, commits, 2)
+	assert.Equal(t, "Sync generated code (1/2)", *commits[0].CommitMessage)
+	assert.Len(t, commits[0].Actions, 3)
+	assert.Nil(t, commits[0].StartBranch)
+	assert.Equal(t, "Sync generated code (2/2)", *commits[1].CommitMessage)
+	assert.Len(t, commits[1].Actions, 2)
+
+	require.Len(t, result.Commits, 2)
+	assert.Equal(t, "commit2", result.Commits[1].ID)
+	require.NotNil(t, result.MergeRequest)
+	assert.Equal(t, int64(5), result.MergeRequest.IID)
+}
+
+func TestSyncFiles_NewBranch(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("GET /api/v4/projects/1/repository/branches/sync", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "404 Branch Not Found"}`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/1/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		testParam(t, r, "ref", "main")
+		fmt.Fprintf(w, `[{"id": "%s", "type": "blob", "path": "README.md", "mode": "100644"}]`, gitBlobSHA([]byte("readme")))
+	})
+	mux.HandleFunc("POST /api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		opt := new(CreateCommitOptions)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(opt))
+
+		assert.Equal(t, "main", *opt.StartBranch)
+		assert.Equal(t, []*CommitActionOptions{
+			{Action: Ptr(FileCreate), FilePath: Ptr("docs/guide.md"), Content: Ptr("guide")},
+		}, opt.Actions)
+
+		fmt.Fprint(w, `{"id": "commit"}`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/1/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"iid": 3, "source_branch": "sync"}]`)
+	})
+
+	result, err := client.Commits.SyncFiles(1, fstest.MapFS{
+		"README.md":     {Data: []byte("readme")},
+		"docs/guide.md": {Data: []byte("guide")},
+	}, &SyncFilesOptions{
+		Branch:        "sync",
+		StartBranch:   "main",
+		CommitMessage: "Add guide",
+		MergeRequest:  &CreateMergeRequestOptions{},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Commits, 1)
+	assert.Equal(t, int64(3), result.MergeRequest.IID)
+}
+
+func TestSyncFiles_Unchanged(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("GET /api/v4/projects/1/repository/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "main"}`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/1/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id": "%s", "type": "blob", "path": "a.txt", "mode": "100644"}]`, gitBlobSHA([]byte("a")))
+	})
+
+	result, err := client.Commits.SyncFiles(1, fstest.MapFS{"a.txt": {Data: []byte("a")}}, &SyncFilesOptions{
+		Branch:        "main",
+		CommitMessage: "Sync",
+		MergeRequest:  &CreateMergeRequestOptions{},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Actions)
+	assert.Empty(t, result.Commits)
+	assert.Nil(t, result.MergeRequest)
+}
+
+func TestSyncFiles_KeepsRemoteModes(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("GET /api/v4/projects/1/repository/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name": "main"}`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/1/repository/tree", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id": "%s", "type": "blob", "path": "run.sh", "mode": "100755"}]`, gitBlobSHA([]byte("old")))
+	})
+	mux.HandleFunc("POST /api/v4/projects/1/repository/commits", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "commit"}`)
+	})
+
+	// Like embed.FS, the file system reports all files as read-only.
+	result, err := client.Commits.SyncFiles(1, fstest.MapFS{
+		"run.sh":  {Data: []byte("new"), Mode: 0o444},
+		"tool.sh": {Data: []byte("tool"), Mode: 0o444},
+	}, &SyncFilesOptions{
+		Branch:        "main",
+		CommitMessage: "Sync",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*CommitActionOptions{
+		{Action: Ptr(FileUpdate), FilePath: Ptr("run.sh"), Content: Ptr("new")},
+		{Action: Ptr(FileCreate), FilePath: Ptr("tool.sh"), Content: Ptr("tool")},
+	}, result.Actions)
+}
+
+func TestGitBlobSHA(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", gitBlobSHA(nil))
+	assert.Equal(t, "ce013625030ba8dba906f756967f9e9ca394464a", gitBlobSHA([]byte("hello\n")))
+}
+
+func TestSplitSyncActions(t *testing.T) {
+	t.Parallel()
+
+	action := func(size int) *CommitActionOptions {
+		return &CommitActionOptions{Content: Ptr(string(make([]byte, size)))}
+	}
+	actions := []*CommitActionOptions{action(4), action(4), action(20), action(1), action(1), action(1)}
+
+	batches := splitSyncActions(actions, 2, 10)
+	assert.Equal(t, [][]*CommitActionOptions{
+		actions[0:2],
+		actions[2:3],
+		actions[3:5],
+		actions[5:6],
+	}, batches)
+}
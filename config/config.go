@@ -103,10 +103,14 @@ type OAuth2Settings struct {
 	AuthorizationFlowEnabled bool
 	CallbackServerListenAddr string
 	Browser                  gitlaboauth2.BrowserFunc
-	ClientID                 string
-	ClientSecret             string
-	RedirectURL              string
-	Scopes                   []string
+	// DeviceCodePrompt presents the user code of the OAuth2 device
+	// authorization grant. If set and Browser is nil, the device flow is used
+	// instead of the callback server, e.g. on hosts without a browser.
+	DeviceCodePrompt gitlaboauth2.DeviceCodePromptFunc
+	ClientID         string
+	ClientSecret     string
+	RedirectURL      string
+	Scopes           []string
 }
 
 // ConfigOption is a function that modifies a Config during initialization.
@@ -711,9 +715,13 @@ func (c *Config) resolveOAuth2(o *v1beta1.OAuth2, baseURL *url.URL) (gitlab.Auth
 			return nil, fmt.Errorf("unable to read OAuth2 token: %w", err)
 		}
 		if token.RefreshToken == "" {
-			server := gitlaboauth2.NewCallbackServer(oauth2Config, c.oauth2Settings.CallbackServerListenAddr, c.oauth2Settings.Browser)
-
-			token, err = server.GetToken(c.ctx)
+			if c.oauth2Settings.Browser == nil && c.oauth2Settings.DeviceCodePrompt != nil {
+				flow := gitlaboauth2.NewDeviceFlow(oauth2Config, c.oauth2Settings.DeviceCodePrompt)
+				token, err = flow.GetToken(c.ctx)
+			} else {
+				server := gitlaboauth2.NewCallbackServer(oauth2Config, c.oauth2Settings.CallbackServerListenAddr, c.oauth2Settings.Browser)
+				token, err = server.GetToken(c.ctx)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,187 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrDownloadChanged is returned when reading a resumable download if the
+// resource changed on the server after the download was interrupted.
+var ErrDownloadChanged = errors.New("download changed on the server while resuming")
+
+// doDownload performs a GET request for a (potentially large) file and returns
+// the response body as an io.ReadCloser, without buffering it in memory.
+//
+// If reading the body fails before it is complete, the download is resumed
+// transparently using an HTTP Range request, starting at the first byte that
+// was not yet read and ending at the end of the range requested by the
+// caller, if any. The number of resumptions is bounded by the maximum number
+// of retries of the client.
+func doDownload(c *Client, path doOption, apiOpts any, options []RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	open := func(offset, end int64, etag string) (io.ReadCloser, *Response, error) {
+		opts := options
+		if offset > 0 {
+			rng := fmt.Sprintf("bytes=%d-", offset)
+			if end >= 0 {
+				rng += strconv.FormatInt(end, 10)
+			}
+			opts = append(slices.Clone(options), WithHeader("Range", rng))
+			if etag != "" {
+				opts = append(opts, WithHeader("If-Range", etag))
+			}
+		}
+
+		r, resp, err := do[bodyReader](c,
+			path,
+			withAPIOpts(apiOpts),
+			withRequestOpts(opts...),
+		)
+		if err != nil {
+			return nil, resp, err
+		}
+		return r.ReadCloser, resp, nil
+	}
+
+	body, resp, err := open(0, -1, "")
+	if err != nil {
+		return nil, resp, err
+	}
+
+	r := &resumableReader{
+		open: open,
+		body: body,
+		size: resp.ContentLength,
+		end:  -1,
+		etag: resp.Header.Get("ETag"),
+	}
+	if !c.disableRetries {
+		r.maxResumes = c.client.RetryMax
+	}
+
+	// The caller may have requested a range on its own, in which case we
+	// continue within that range when resuming.
+	if resp.StatusCode == http.StatusPartialContent {
+		if start, end, ok := parseContentRange(resp.Header.Get("Content-Range")); ok {
+			r.offset = start
+			r.size = end + 1
+			r.end = end
+		}
+	}
+
+	// Weak validators can't be used with If-Range.
+	if strings.HasPrefix(r.etag, "W/") {
+		r.etag = ""
+	}
+
+	return r, resp, nil
+}
+
+// resumableReader is an io.ReadCloser which resumes reading a download using
+// HTTP Range requests when the underlying response body fails.
+type resumableReader struct {
+	open       func(offset, end int64, etag string) (io.ReadCloser, *Response, error)
+	body       io.ReadCloser
+	offset     int64
+	size       int64
+	end        int64
+	etag       string
+	resumes    int
+	maxResumes int
+	err        error
+}
+
+func (r *resumableReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+
+		switch {
+		case err == nil:
+			return n, nil
+		case errors.Is(err, io.EOF) && (r.size < 0 || r.offset >= r.size):
+			return n, io.EOF
+		}
+
+		// The body ended prematurely or failed, try to resume the download.
+		if rerr := r.resume(); rerr != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			r.err = errors.Join(err, rerr)
+			return n, r.err
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (r *resumableReader) Close() error {
+	return r.body.Close()
+}
+
+func (r *resumableReader) resume() error {
+	if r.resumes >= r.maxResumes {
+		return fmt.Errorf("download interrupted at byte %d after %d resumptions", r.offset, r.resumes)
+	}
+	r.resumes++
+
+	r.body.Close()
+	r.body = http.NoBody
+
+	body, resp, err := r.open(r.offset, r.end, r.etag)
+	if err != nil {
+		return fmt.Errorf("resuming download at byte %d: %w", r.offset, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		start, _, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != r.offset {
+			body.Close()
+			return fmt.Errorf("resuming download at byte %d: unexpected Content-Range %q", r.offset, resp.Header.Get("Content-Range"))
+		}
+	default:
+		// The server ignored the range, so the complete file is sent again.
+		// If it changed in the meantime, we can't continue the download.
+		if etag := resp.Header.Get("ETag"); r.etag != "" && etag != r.etag {
+			body.Close()
+			return ErrDownloadChanged
+		}
+		if _, err := io.CopyN(io.Discard, body, r.offset); err != nil {
+			body.Close()
+			return fmt.Errorf("resuming download at byte %d: %w", r.offset, err)
+		}
+		if r.end >= 0 {
+			body = limitedReadCloser{Reader: io.LimitReader(body, r.end+1-r.offset), Closer: body}
+		}
+	}
+
+	r.body = body
+	return nil
+}
+
+// limitedReadCloser limits the bytes read from a body, but closes all of it.
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// parseContentRange parses the start and end of a Content-Range header in the
+// form "bytes <start>-<end>/<size>".
+func parseContentRange(s string) (start, end int64, ok bool) {
+	var size string
+	if _, err := fmt.Sscanf(s, "bytes %d-%d/%s", &start, &end, &size); err != nil {
+		return 0, 0, false
+	}
+	return start, end, start <= end
+}
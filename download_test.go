@@ -0,0 +1,200 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interruptedDownloadHandler serves content, but aborts the first response
+// after writing half of it. Subsequent requests honor the Range header unless
+// ignoreRange is set.
+func interruptedDownloadHandler(t *testing.T, content, etag string, ignoreRange bool, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Header().Set("ETag", etag)
+
+		if n == 1 {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+
+		rng := r.Header.Get("Range")
+		if ignoreRange || rng == "" {
+			fmt.Fprint(w, content)
+			return
+		}
+
+		assert.Equal(t, etag, r.Header.Get("If-Range"))
+
+		start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		require.NoError(t, err)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, content[start:])
+	}
+}
+
+func TestDoDownload_ResumesWithRange(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	content := strings.Repeat("0123456789", 1000)
+	var requests atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/jobs/1/artifacts", interruptedDownloadHandler(t, content, `"abc"`, false, &requests))
+
+	reader, resp, err := client.Jobs.StreamJobArtifacts(1, 1)
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestDoDownload_ResumesWhenRangeIsIgnored(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	content := strings.Repeat("0123456789", 1000)
+	var requests atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/jobs/1/artifacts", interruptedDownloadHandler(t, content, `"abc"`, true, &requests))
+
+	reader, _, err := client.Jobs.StreamJobArtifacts(1, 1)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(got))
+}
+
+func TestDoDownload_ContentChanged(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	content := strings.Repeat("0123456789", 1000)
+	var requests atomic.Int32
+	handler := interruptedDownloadHandler(t, content, `"abc"`, true, &requests)
+	mux.HandleFunc("/api/v4/projects/1/jobs/1/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		if requests.Load() > 0 {
+			w.Header().Set("ETag", `"def"`)
+			fmt.Fprint(w, content)
+			return
+		}
+		handler(w, r)
+	})
+
+	reader, _, err := client.Jobs.StreamJobArtifacts(1, 1)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	_, err = io.ReadAll(reader)
+	assert.ErrorIs(t, err, ErrDownloadChanged)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestDoDownload_ResumesWithinRequestedRange(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	content := strings.Repeat("0123456789", 1000)
+	var ranges []string
+	mux.HandleFunc("/api/v4/projects/1/jobs/1/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+
+		var start, end int
+		_, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end)
+		require.NoError(t, err)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+		w.Header().Set("Content-Length", strconv.Itoa(end-start+1))
+		w.WriteHeader(http.StatusPartialContent)
+
+		if len(ranges) == 1 {
+			fmt.Fprint(w, content[start:start+500])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		fmt.Fprint(w, content[start:end+1])
+	})
+
+	reader, resp, err := client.Jobs.StreamJobArtifacts(1, 1, WithHeader("Range", "bytes=100-1099"))
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content[100:1100], string(got))
+	assert.Equal(t, []string{"bytes=100-1099", "bytes=600-1099"}, ranges)
+}
+
+func TestDo_PartialContentWithoutRange(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	_, resp, err := client.Projects.GetProject(1, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+}
+
+func TestDoDownload_RetriesDisabled(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+	client.disableRetries = true
+
+	content := strings.Repeat("0123456789", 1000)
+	var requests atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/jobs/1/trace", interruptedDownloadHandler(t, content, "", false, &requests))
+
+	reader, _, err := client.Jobs.StreamTraceFile(1, 1)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	_, err = io.ReadAll(reader)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestParseContentRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header     string
+		start, end int64
+		ok         bool
+	}{
+		{header: "bytes 0-99/100", start: 0, end: 99, ok: true},
+		{header: "bytes 50-99/*", start: 50, end: 99, ok: true},
+		{header: "bytes */100"},
+		{header: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			start, end, ok := parseContentRange(tt.header)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.end, end)
+		})
+	}
+}
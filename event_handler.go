@@ -0,0 +1,535 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	eventUUIDHeader      = "X-Gitlab-Event-UUID"
+	idempotencyKeyHeader = "Idempotency-Key"
+	instanceHeader       = "X-Gitlab-Instance"
+	webhookUUIDHeader    = "X-Gitlab-Webhook-UUID"
+)
+
+const (
+	// DefaultWebhookMaxBodySize is the default maximum size of a webhook
+	// payload accepted by a WebhookHandler.
+	DefaultWebhookMaxBodySize = 25 << 20
+
+	defaultWebhookDeduplicationTTL = 24 * time.Hour
+)
+
+// WebhookDelivery contains the metadata of a single webhook delivery, as sent
+// by GitLab in the request headers.
+type WebhookDelivery struct {
+	EventType      EventType
+	EventUUID      string
+	IdempotencyKey string
+	Instance       string
+	WebhookUUID    string
+}
+
+// deduplicationKey returns the key used to detect repeated deliveries of the
+// same event. GitLab sends the same Idempotency-Key when retrying a delivery.
+// Without it, the event UUID is combined with the webhook UUID, as GitLab
+// sends the same event UUID to every webhook triggered by an event.
+func (d *WebhookDelivery) deduplicationKey() string {
+	if d.IdempotencyKey != "" {
+		return d.IdempotencyKey
+	}
+	if d.EventUUID == "" {
+		return ""
+	}
+	return d.WebhookUUID + "/" + d.EventUUID
+}
+
+func newWebhookDelivery(r *http.Request) *WebhookDelivery {
+	return &WebhookDelivery{
+		EventType:      HookEventType(r),
+		EventUUID:      r.Header.Get(eventUUIDHeader),
+		IdempotencyKey: r.Header.Get(idempotencyKeyHeader),
+		Instance:       r.Header.Get(instanceHeader),
+		WebhookUUID:    r.Header.Get(webhookUUIDHeader),
+	}
+}
+
+type webhookDeliveryContextKey struct{}
+
+// WebhookDeliveryFromContext returns the WebhookDelivery of the event that is
+// being handled by a WebhookHandler.
+func WebhookDeliveryFromContext(ctx context.Context) (*WebhookDelivery, bool) {
+	d, ok := ctx.Value(webhookDeliveryContextKey{}).(*WebhookDelivery)
+	return d, ok
+}
+
+// WebhookDeduplicator keeps track of the webhook deliveries that were already
+// handled, so retried deliveries of the same event are only handled once.
+type WebhookDeduplicator interface {
+	// Claim reports whether the delivery with the given key should be
+	// handled. It returns false if the delivery was already claimed before.
+	Claim(ctx context.Context, key string) (bool, error)
+	// Release releases a claimed delivery after handling it failed, so it
+	// can be handled again when GitLab retries it.
+	Release(ctx context.Context, key string) error
+}
+
+// InMemoryWebhookDeduplicator is a WebhookDeduplicator that keeps the claimed
+// deliveries in memory for a limited amount of time.
+type InMemoryWebhookDeduplicator struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	expires map[string]time.Time
+	claims  []claimedDelivery
+	now     func() time.Time
+}
+
+// claimedDelivery is an entry of the claims of an InMemoryWebhookDeduplicator,
+// which are ordered by their expiry as all of them have the same ttl.
+type claimedDelivery struct {
+	key     string
+	expires time.Time
+}
+
+var _ WebhookDeduplicator = (*InMemoryWebhookDeduplicator)(nil)
+
+// NewInMemoryWebhookDeduplicator returns a new InMemoryWebhookDeduplicator,
+// which remembers claimed deliveries for the given ttl.
+func NewInMemoryWebhookDeduplicator(ttl time.Duration) *InMemoryWebhookDeduplicator {
+	return &InMemoryWebhookDeduplicator{
+		ttl:     ttl,
+		expires: make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+func (d *InMemoryWebhookDeduplicator) Claim(_ context.Context, key string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.expire(now)
+
+	if exp, ok := d.expires[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	exp := now.Add(d.ttl)
+	d.expires[key] = exp
+	d.claims = append(d.claims, claimedDelivery{key: key, expires: exp})
+	return true, nil
+}
+
+// expire removes the claims that expired before now, stopping at the first
+// one that didn't. Claims that were released or claimed again in the
+// meantime are skipped.
+func (d *InMemoryWebhookDeduplicator) expire(now time.Time) {
+	n := 0
+	for _, c := range d.claims {
+		if now.Before(c.expires) {
+			break
+		}
+		if exp, ok := d.expires[c.key]; ok && exp.Equal(c.expires) {
+			delete(d.expires, c.key)
+		}
+		n++
+	}
+	d.claims = d.claims[n:]
+}
+
+func (d *InMemoryWebhookDeduplicator) Release(_ context.Context, key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.expires, key)
+	return nil
+}
+
+// WebhookHandlerOptionFunc can be used to customize a WebhookHandler.
+type WebhookHandlerOptionFunc func(*WebhookHandler)
+
+// WithWebhookMaxBodySize sets the maximum size of the payloads accepted by
+// the handler. Larger payloads are rejected with 413 Request Entity Too Large.
+// Defaults to DefaultWebhookMaxBodySize.
+func WithWebhookMaxBodySize(size int64) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.maxBodySize = size
+	}
+}
+
+// WithWebhookDeduplicator sets the WebhookDeduplicator used to skip retried
+// deliveries. Pass nil to disable deduplication. Defaults to an
+// InMemoryWebhookDeduplicator which remembers deliveries for 24 hours.
+func WithWebhookDeduplicator(d WebhookDeduplicator) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.deduplicator = d
+	}
+}
+
+// WithWebhookEventStore makes the handler append every received event to the
+// given EventStore before dispatching it, so it can be replayed later with
+// Replay. Retried deliveries of stored events are not stored again.
+func WithWebhookEventStore(store EventStore) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.store = store
+	}
+}
+
+// WithWebhookParseOptions sets the options used to parse the received and
+// replayed events, e.g. WithLenientParsing to dispatch events this package
+// doesn't know as *UnknownEvent instead of rejecting them.
+func WithWebhookParseOptions(options ...ParseHookOptionFunc) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.parseOptions = options
+	}
+}
+
+// WithWebhookErrorHandler registers a function that is called with all errors
+// that occur while handling a request, e.g. to log them. The response sent to
+// GitLab is not affected by it.
+func WithWebhookErrorHandler(fn func(r *http.Request, err error)) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.errorHandler = fn
+	}
+}
+
+// WebhookHandler is an http.Handler that receives GitLab web- and system
+// hooks, and dispatches the parsed events to the registered callbacks.
+//
+// The handler verifies the secret token sent in the X-Gitlab-Token header,
+// limits the size of the payloads, and skips retried deliveries of events it
+// already handled, based on the Idempotency-Key header, or the
+// X-Gitlab-Event-UUID and X-Gitlab-Webhook-UUID headers.
+//
+// If a callback returns an error, the handler responds with 500 Internal
+// Server Error, so GitLab retries the delivery. Events without callbacks are
+// acknowledged, but otherwise ignored.
+//
+// Example usage:
+//
+//	h := gitlab.NewWebhookHandler(os.Getenv("WEBHOOK_SECRET"))
+//	h.OnMergeRequest(func(ctx context.Context, e *gitlab.MergeEvent) error {
+//	    return processMergeEvent(ctx, e)
+//	})
+//	h.OnPipeline(func(ctx context.Context, e *gitlab.PipelineEvent) error {
+//	    return processPipelineEvent(ctx, e)
+//	})
+//	http.Handle("/webhook", h)
+type WebhookHandler struct {
+	secretToken  string
+	maxBodySize  int64
+	deduplicator WebhookDeduplicator
+	store        EventStore
+	parseOptions []ParseHookOptionFunc
+	errorHandler func(r *http.Request, err error)
+
+	mu        sync.RWMutex
+	callbacks map[reflect.Type][]func(context.Context, any) error
+	fallback  func(context.Context, any) error
+}
+
+var _ http.Handler = (*WebhookHandler)(nil)
+
+// NewWebhookHandler returns a new WebhookHandler, which only accepts
+// requests with the given secret token. If secretToken is empty, the token
+// is not verified.
+func NewWebhookHandler(secretToken string, options ...WebhookHandlerOptionFunc) *WebhookHandler {
+	h := &WebhookHandler{
+		secretToken:  secretToken,
+		maxBodySize:  DefaultWebhookMaxBodySize,
+		deduplicator: NewInMemoryWebhookDeduplicator(defaultWebhookDeduplicationTTL),
+		callbacks:    make(map[reflect.Type][]func(context.Context, any) error),
+	}
+	for _, fn := range options {
+		if fn != nil {
+			fn(h)
+		}
+	}
+	return h
+}
+
+// OnEvent registers a callback for events of type T, which must be one of
+// the event types returned by ParseWebhook or ParseSystemhook, for example
+// *MergeEvent or *ProjectSystemEvent. Multiple callbacks can be registered
+// for the same event type, which are called in the order of registration.
+func OnEvent[T any](h *WebhookHandler, fn func(context.Context, T) error) {
+	t := reflect.TypeFor[T]()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.callbacks[t] = append(h.callbacks[t], func(ctx context.Context, event any) error {
+		return fn(ctx, event.(T))
+	})
+}
+
+// OnUnhandled registers a callback for all events without a callback for
+// their specific type.
+func (h *WebhookHandler) OnUnhandled(fn func(ctx context.Context, event any) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fallback = fn
+}
+
+// OnBuild registers a callback for build events.
+func (h *WebhookHandler) OnBuild(fn func(context.Context, *BuildEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnCommitComment registers a callback for comments on commits.
+func (h *WebhookHandler) OnCommitComment(fn func(context.Context, *CommitCommentEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnDeployment registers a callback for deployment events.
+func (h *WebhookHandler) OnDeployment(fn func(context.Context, *DeploymentEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnEmoji registers a callback for emoji events.
+func (h *WebhookHandler) OnEmoji(fn func(context.Context, *EmojiEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnFeatureFlag registers a callback for feature flag events.
+func (h *WebhookHandler) OnFeatureFlag(fn func(context.Context, *FeatureFlagEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnIssue registers a callback for issue events, including confidential
+// issues.
+func (h *WebhookHandler) OnIssue(fn func(context.Context, *IssueEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnIssueComment registers a callback for comments on issues.
+func (h *WebhookHandler) OnIssueComment(fn func(context.Context, *IssueCommentEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnJob registers a callback for job events.
+func (h *WebhookHandler) OnJob(fn func(context.Context, *JobEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnMember registers a callback for member events.
+func (h *WebhookHandler) OnMember(fn func(context.Context, *MemberEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnMergeRequest registers a callback for merge request events.
+func (h *WebhookHandler) OnMergeRequest(fn func(context.Context, *MergeEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnMergeRequestComment registers a callback for comments on merge requests.
+func (h *WebhookHandler) OnMergeRequestComment(fn func(context.Context, *MergeCommentEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnMilestone registers a callback for milestone events.
+func (h *WebhookHandler) OnMilestone(fn func(context.Context, *MilestoneWebhookEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnPipeline registers a callback for pipeline events.
+func (h *WebhookHandler) OnPipeline(fn func(context.Context, *PipelineEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnProject registers a callback for project events.
+func (h *WebhookHandler) OnProject(fn func(context.Context, *ProjectWebhookEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnPush registers a callback for push events.
+func (h *WebhookHandler) OnPush(fn func(context.Context, *PushEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnRelease registers a callback for release events.
+func (h *WebhookHandler) OnRelease(fn func(context.Context, *ReleaseEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnSnippetComment registers a callback for comments on snippets.
+func (h *WebhookHandler) OnSnippetComment(fn func(context.Context, *SnippetCommentEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnSubGroup registers a callback for subgroup events.
+func (h *WebhookHandler) OnSubGroup(fn func(context.Context, *SubGroupEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnTagPush registers a callback for tag push events.
+func (h *WebhookHandler) OnTagPush(fn func(context.Context, *TagEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnVulnerability registers a callback for vulnerability events.
+func (h *WebhookHandler) OnVulnerability(fn func(context.Context, *VulnerabilityEvent) error) {
+	OnEvent(h, fn)
+}
+
+// OnWikiPage registers a callback for wiki page events.
+func (h *WebhookHandler) OnWikiPage(fn func(context.Context, *WikiPageEvent) error) {
+	OnEvent(h, fn)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.secretToken != "" && subtle.ConstantTimeCompare([]byte(HookEventToken(r)), []byte(h.secretToken)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+			return
+		}
+		h.handleError(r, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	delivery := newWebhookDelivery(r)
+	event, err := ParseHook(delivery.EventType, payload, h.parseOptions...)
+	if err != nil {
+		h.handleError(r, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), webhookDeliveryContextKey{}, delivery)
+
+	key := delivery.deduplicationKey()
+	if h.deduplicator != nil && key != "" {
+		ok, err := h.deduplicator.Claim(ctx, key)
+		if err != nil {
+			h.handleError(r, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			// The event was already handled, acknowledge the retry.
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if err := h.storeEvent(ctx, delivery, payload); err != nil {
+		h.handleError(r, err)
+		h.release(ctx, r, key)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.dispatch(ctx, event); err != nil {
+		h.handleError(r, err)
+		h.release(ctx, r, key)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Replay dispatches the stored events matching the filter to the registered
+// callbacks, in the order they were stored. The WebhookDelivery the events
+// were originally received with is available from the context.
+//
+// Replayed events are not deduplicated. Replay stops at the first error and
+// returns the number of events replayed before it; the filter's
+// AfterSequence can be used to resume after the last replayed event.
+func (h *WebhookHandler) Replay(ctx context.Context, store EventStore, filter *EventFilter) (int, error) {
+	n := 0
+	for stored, err := range store.Events(ctx, filter) {
+		if err != nil {
+			return n, err
+		}
+
+		event, err := stored.Parse(h.parseOptions...)
+		if err != nil {
+			return n, fmt.Errorf("parsing event %d: %w", stored.Sequence, err)
+		}
+
+		eventCtx := context.WithValue(ctx, webhookDeliveryContextKey{}, stored.Delivery())
+		if err := h.dispatch(eventCtx, event); err != nil {
+			return n, fmt.Errorf("replaying event %d: %w", stored.Sequence, err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// storeEvent appends the payload to the event store of the handler, if any.
+func (h *WebhookHandler) storeEvent(ctx context.Context, delivery *WebhookDelivery, payload []byte) error {
+	if h.store == nil {
+		return nil
+	}
+
+	stored, err := NewStoredEvent(delivery, payload)
+	if err != nil {
+		return err
+	}
+	if err := h.store.Append(ctx, stored); err != nil && !errors.Is(err, ErrEventExists) {
+		return err
+	}
+	return nil
+}
+
+// release releases a claimed delivery after handling it failed.
+func (h *WebhookHandler) release(ctx context.Context, r *http.Request, key string) {
+	if h.deduplicator == nil || key == "" {
+		return
+	}
+	if err := h.deduplicator.Release(ctx, key); err != nil {
+		h.handleError(r, err)
+	}
+}
+
+// dispatch calls the callbacks registered for the type of event.
+func (h *WebhookHandler) dispatch(ctx context.Context, event any) error {
+	h.mu.RLock()
+	callbacks := h.callbacks[reflect.TypeOf(event)]
+	fallback := h.fallback
+	h.mu.RUnlock()
+
+	if len(callbacks) == 0 {
+		if fallback == nil {
+			return nil
+		}
+		return fallback(ctx, event)
+	}
+
+	for _, fn := range callbacks {
+		if err := fn(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *WebhookHandler) handleError(r *http.Request, err error) {
+	if h.errorHandler != nil {
+		h.errorHandler(r, err)
+	}
+}
@@ -0,0 +1,294 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookRequest(t *testing.T, eventType EventType, fixture string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(loadFixture(t, fixture)))
+	req.Header.Set("X-Gitlab-Event", string(eventType))
+	req.Header.Set("X-Gitlab-Token", "secret")
+	return req
+}
+
+func TestWebhookHandler_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	h := NewWebhookHandler("secret")
+
+	var (
+		merge    *MergeEvent
+		pipeline *PipelineEvent
+		delivery *WebhookDelivery
+	)
+	h.OnMergeRequest(func(ctx context.Context, e *MergeEvent) error {
+		merge = e
+		delivery, _ = WebhookDeliveryFromContext(ctx)
+		return nil
+	})
+	h.OnPipeline(func(_ context.Context, e *PipelineEvent) error {
+		pipeline = e
+		return nil
+	})
+
+	req := newWebhookRequest(t, EventTypeMergeRequest, "testdata/webhooks/merge_request.json")
+	req.Header.Set("X-Gitlab-Event-UUID", "13792a34-cac6-4fda-95a8-c58e00a3954e")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, merge)
+	assert.Equal(t, "merge_request", merge.ObjectKind)
+	assert.Nil(t, pipeline)
+	require.NotNil(t, delivery)
+	assert.Equal(t, EventTypeMergeRequest, delivery.EventType)
+	assert.Equal(t, "13792a34-cac6-4fda-95a8-c58e00a3954e", delivery.EventUUID)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, newWebhookRequest(t, EventTypePipeline, "testdata/webhooks/pipeline.json"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, pipeline)
+	assert.Equal(t, "pipeline", pipeline.ObjectKind)
+}
+
+func TestWebhookHandler_SystemHooksAndFallback(t *testing.T) {
+	t.Parallel()
+
+	h := NewWebhookHandler("secret")
+
+	var project *ProjectSystemEvent
+	OnEvent(h, func(_ context.Context, e *ProjectSystemEvent) error {
+		project = e
+		return nil
+	})
+	var unhandled any
+	h.OnUnhandled(func(_ context.Context, e any) error {
+		unhandled = e
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newWebhookRequest(t, EventTypeSystemHook, "testdata/systemhooks/project_create.json"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, project)
+	assert.Equal(t, "project_create", project.EventName)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, newWebhookRequest(t, EventTypePush, "testdata/webhooks/push.json"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.IsType(t, &PushEvent{}, unhandled)
+}
+
+func TestWebhookHandler_LenientParsing(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"object_kind": "work_item"}`))
+		req.Header.Set("X-Gitlab-Event", "Work Item Hook")
+		req.Header.Set("X-Gitlab-Token", "secret")
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	NewWebhookHandler("secret").ServeHTTP(rec, newRequest())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	h := NewWebhookHandler("secret", WithWebhookParseOptions(WithLenientParsing()))
+	var unknown *UnknownEvent
+	OnEvent(h, func(_ context.Context, e *UnknownEvent) error {
+		unknown = e
+		return nil
+	})
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, newRequest())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, unknown)
+	assert.Equal(t, EventType("Work Item Hook"), unknown.Type)
+	assert.Equal(t, "work_item", unknown.ObjectKind)
+}
+
+func TestWebhookHandler_RejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	var errs []error
+	h := NewWebhookHandler("secret",
+		WithWebhookMaxBodySize(16),
+		WithWebhookErrorHandler(func(_ *http.Request, err error) {
+			errs = append(errs, err)
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		req    func() *http.Request
+		status int
+	}{
+		{
+			name: "wrong method",
+			req: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/webhook", nil)
+			},
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "missing token",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+				req.Header.Set("X-Gitlab-Event", string(EventTypePush))
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "wrong token",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+				req.Header.Set("X-Gitlab-Event", string(EventTypePush))
+				req.Header.Set("X-Gitlab-Token", "secret2")
+				return req
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "body too large",
+			req: func() *http.Request {
+				return newWebhookRequest(t, EventTypePush, "testdata/webhooks/push.json")
+			},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "unknown event type",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("{}"))
+				req.Header.Set("X-Gitlab-Event", "Unknown Hook")
+				req.Header.Set("X-Gitlab-Token", "secret")
+				return req
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, tt.req())
+		assert.Equal(t, tt.status, rec.Code, tt.name)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "unexpected event type")
+}
+
+func TestWebhookHandler_Deduplication(t *testing.T) {
+	t.Parallel()
+
+	h := NewWebhookHandler("secret")
+
+	calls := 0
+	fail := true
+	h.OnPush(func(context.Context, *PushEvent) error {
+		calls++
+		if fail {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	deliver := func(idempotencyKey string) int {
+		req := newWebhookRequest(t, EventTypePush, "testdata/webhooks/push.json")
+		req.Header.Set("X-Gitlab-Event-UUID", "13792a34-cac6-4fda-95a8-c58e00a3954e")
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A failed delivery is retried by GitLab, so it must not be deduplicated.
+	assert.Equal(t, http.StatusInternalServerError, deliver("key-1"))
+	fail = false
+	assert.Equal(t, http.StatusOK, deliver("key-1"))
+	assert.Equal(t, http.StatusOK, deliver("key-1"))
+	assert.Equal(t, 2, calls)
+
+	assert.Equal(t, http.StatusOK, deliver("key-2"))
+	assert.Equal(t, 3, calls)
+}
+
+func TestWebhookHandler_DeduplicationPerWebhook(t *testing.T) {
+	t.Parallel()
+
+	h := NewWebhookHandler("secret")
+
+	calls := 0
+	h.OnPush(func(context.Context, *PushEvent) error {
+		calls++
+		return nil
+	})
+
+	deliver := func(webhookUUID string) int {
+		req := newWebhookRequest(t, EventTypePush, "testdata/webhooks/push.json")
+		req.Header.Set("X-Gitlab-Event-UUID", "13792a34-cac6-4fda-95a8-c58e00a3954e")
+		req.Header.Set("X-Gitlab-Webhook-UUID", webhookUUID)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// A project and a group hook receive the same event.
+	assert.Equal(t, http.StatusOK, deliver("project-hook"))
+	assert.Equal(t, http.StatusOK, deliver("group-hook"))
+	assert.Equal(t, http.StatusOK, deliver("group-hook"))
+	assert.Equal(t, 2, calls)
+}
+
+func TestInMemoryWebhookDeduplicator_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewInMemoryWebhookDeduplicator(time.Minute)
+	d.now = func() time.Time { return now }
+
+	ok, err := d.Claim(context.Background(), "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = d.Claim(context.Background(), "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	now = now.Add(30 * time.Second)
+	ok, err = d.Claim(context.Background(), "other")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	now = now.Add(30 * time.Second)
+	ok, err = d.Claim(context.Background(), "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Len(t, d.claims, 2)
+
+	// A released and claimed again delivery expires with its new claim.
+	require.NoError(t, d.Release(context.Background(), "other"))
+	ok, err = d.Claim(context.Background(), "other")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	now = now.Add(30 * time.Second)
+	ok, err = d.Claim(context.Background(), "other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Len(t, d.expires, 2)
+}
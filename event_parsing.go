@@ -17,10 +17,15 @@
 package gitlab
 
 import (
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"reflect"
+	"slices"
+	"strings"
 )
 
 // EventType represents a GitLab event type.
@@ -57,6 +62,7 @@ const (
 	eventObjectKindPush         = "push"
 	eventObjectKindTagPush      = "tag_push"
 	eventObjectKindMergeRequest = "merge_request"
+	eventObjectKindAccessToken  = "access_token"
 )
 
 const (
@@ -93,7 +99,8 @@ func HookEventType(r *http.Request) EventType {
 	return EventType(r.Header.Get(eventTypeHeader))
 }
 
-// ParseHook tries to parse both web- and system hooks.
+// ParseHook tries to parse both web- and system hooks. The options are
+// passed on to ParseWebhook or ParseSystemhook.
 //
 // Example usage:
 //
@@ -110,18 +117,40 @@ func HookEventType(r *http.Request) EventType {
 //	    ...
 //	    }
 //	}
-func ParseHook(eventType EventType, payload []byte) (event any, err error) {
+func ParseHook(eventType EventType, payload []byte, options ...ParseHookOptionFunc) (event any, err error) {
 	switch eventType {
 	case EventTypeSystemHook:
-		return ParseSystemhook(payload)
+		return ParseSystemhook(payload, options...)
 	default:
-		return ParseWebhook(eventType, payload)
+		return ParseWebhook(eventType, payload, options...)
 	}
 }
 
+// ParsedEvent is a web- or system hook event returned by ParseHookRaw
+// together with the payload it was parsed from.
+type ParsedEvent struct {
+	// Event is the parsed event, as returned by ParseHook.
+	Event any
+	// Raw is the complete payload, including the fields without a
+	// corresponding struct field. It must not be modified.
+	Raw json.RawMessage
+}
+
+// ParseHookRaw parses the payload like ParseHook, and returns the parsed
+// event together with the raw payload, e.g. to forward or store the fields
+// the event types of this package don't cover. In strict mode, the event is
+// returned together with an *UnknownFieldsError.
+func ParseHookRaw(eventType EventType, payload []byte, options ...ParseHookOptionFunc) (*ParsedEvent, error) {
+	event, err := ParseHook(eventType, payload, options...)
+	if event == nil {
+		return nil, err
+	}
+	return &ParsedEvent{Event: event, Raw: payload}, err
+}
+
 // ParseSystemhook parses the event payload. For recognized event types, a
-// value of the corresponding struct type will be returned. An error will be
-// returned for unrecognized event types.
+// value of the corresponding struct type will be returned. Unrecognized
+// events are returned as an *UnknownSystemEvent holding the raw payload.
 //
 // Example usage:
 //
@@ -133,12 +162,16 @@ func ParseHook(eventType EventType, payload []byte) (event any, err error) {
 //	    switch event := event.(type) {
 //	    case *gitlab.PushSystemEvent:
 //	        processPushSystemEvent(event)
-//	    case *gitlab.MergeSystemEvent:
-//	        processMergeSystemEvent(event)
+//	    case *gitlab.MergeEvent:
+//	        processMergeEvent(event)
+//	    case *gitlab.UnknownSystemEvent:
+//	        log.Printf("ignoring system hook %s", event.EventName)
 //	    ...
 //	    }
 //	}
-func ParseSystemhook(payload []byte) (event any, err error) {
+func ParseSystemhook(payload []byte, options ...ParseHookOptionFunc) (event any, err error) {
+	p := newHookParser(options)
+
 	e := &systemHookEvent{}
 	err = json.Unmarshal(payload, e)
 	if err != nil {
@@ -175,27 +208,45 @@ func ParseSystemhook(payload []byte) (event any, err error) {
 	case
 		"user_add_to_group",
 		"user_remove_from_group",
-		"user_update_for_group":
+		"user_update_for_group",
+		"user_access_request_to_group",
+		"user_access_request_revoked_for_group":
 		event = &UserGroupSystemEvent{}
 	case
 		"user_add_to_team",
 		"user_remove_from_team",
-		"user_update_for_team":
+		"user_update_for_team",
+		"user_access_request_to_project",
+		"user_access_request_revoked_for_project":
 		event = &UserTeamSystemEvent{}
 	default:
+		// Events without a known event name are sent with the payload of
+		// the corresponding webhook.
 		switch e.ObjectKind {
 		case string(MergeRequestEventTargetType):
 			event = &MergeEvent{}
+		case eventObjectKindAccessToken:
+			event, err = resourceAccessTokenEvent(payload)
+			if err != nil {
+				return nil, err
+			}
+			if event == nil {
+				return &UnknownSystemEvent{
+					BaseSystemEvent: e.BaseSystemEvent,
+					ObjectKind:      e.ObjectKind,
+					Raw:             payload,
+				}, nil
+			}
 		default:
-			return nil, fmt.Errorf("unexpected system hook type %s", e.EventName)
+			return &UnknownSystemEvent{
+				BaseSystemEvent: e.BaseSystemEvent,
+				ObjectKind:      e.ObjectKind,
+				Raw:             payload,
+			}, nil
 		}
 	}
 
-	if err := json.Unmarshal(payload, event); err != nil {
-		return nil, err
-	}
-
-	return event, nil
+	return p.decode(payload, event)
 }
 
 // WebhookEventType returns the event type for the given request.
@@ -205,7 +256,8 @@ func WebhookEventType(r *http.Request) EventType {
 
 // ParseWebhook parses the event payload. For recognized event types, a
 // value of the corresponding struct type will be returned. An error will
-// be returned for unrecognized event types.
+// be returned for unrecognized event types, unless WithLenientParsing is
+// passed.
 //
 // Example usage:
 //
@@ -222,7 +274,9 @@ func WebhookEventType(r *http.Request) EventType {
 //	    ...
 //	    }
 //	}
-func ParseWebhook(eventType EventType, payload []byte) (event any, err error) {
+func ParseWebhook(eventType EventType, payload []byte, options ...ParseHookOptionFunc) (event any, err error) {
+	p := newHookParser(options)
+
 	switch eventType {
 	case EventTypeBuild:
 		event = &BuildEvent{}
@@ -250,7 +304,7 @@ func ParseWebhook(eventType EventType, payload []byte) (event any, err error) {
 		}
 
 		if note.ObjectKind != string(NoteEventTargetType) {
-			return nil, fmt.Errorf("unexpected object kind %s", note.ObjectKind)
+			return p.unknown(eventType, payload, fmt.Errorf("unexpected object kind %s", note.ObjectKind))
 		}
 
 		switch note.ObjectAttributes.NoteableType {
@@ -263,7 +317,7 @@ func ParseWebhook(eventType EventType, payload []byte) (event any, err error) {
 		case noteableTypeSnippet:
 			event = &SnippetCommentEvent{}
 		default:
-			return nil, fmt.Errorf("unexpected noteable type %s", note.ObjectAttributes.NoteableType)
+			return p.unknown(eventType, payload, fmt.Errorf("unexpected noteable type %s", note.ObjectAttributes.NoteableType))
 		}
 	case EventTypePipeline:
 		event = &PipelineEvent{}
@@ -274,22 +328,12 @@ func ParseWebhook(eventType EventType, payload []byte) (event any, err error) {
 	case EventTypeRelease:
 		event = &ReleaseEvent{}
 	case EventTypeResourceAccessToken:
-		data := map[string]any{}
-		err := json.Unmarshal(payload, &data)
+		event, err = resourceAccessTokenEvent(payload)
 		if err != nil {
 			return nil, err
 		}
-
-		_, groupEvent := data["group"]
-		_, projectEvent := data["project"]
-
-		switch {
-		case groupEvent:
-			event = &GroupResourceAccessTokenEvent{}
-		case projectEvent:
-			event = &ProjectResourceAccessTokenEvent{}
-		default:
-			return nil, errors.New("unexpected resource access token payload")
+		if event == nil {
+			return p.unknown(eventType, payload, errors.New("unexpected resource access token payload"))
 		}
 	case EventTypeServiceHook:
 		service := &serviceEvent{}
@@ -305,7 +349,7 @@ func ParseWebhook(eventType EventType, payload []byte) (event any, err error) {
 		case eventObjectKindMergeRequest:
 			event = &MergeEvent{}
 		default:
-			return nil, fmt.Errorf("unexpected service type %s", service.ObjectKind)
+			return p.unknown(eventType, payload, fmt.Errorf("unexpected service type %s", service.ObjectKind))
 		}
 	case EventTypeSubGroup:
 		event = &SubGroupEvent{}
@@ -316,12 +360,233 @@ func ParseWebhook(eventType EventType, payload []byte) (event any, err error) {
 	case EventTypeWikiPage:
 		event = &WikiPageEvent{}
 	default:
-		return nil, fmt.Errorf("unexpected event type: %s", eventType)
+		return p.unknown(eventType, payload, fmt.Errorf("unexpected event type: %s", eventType))
+	}
+
+	return p.decode(payload, event)
+}
+
+// resourceAccessTokenEvent returns the event to decode a resource access
+// token payload into, or nil if it belongs to neither a group nor a project.
+func resourceAccessTokenEvent(payload []byte) (any, error) {
+	data := map[string]any{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+
+	_, groupEvent := data["group"]
+	_, projectEvent := data["project"]
+
+	switch {
+	case groupEvent:
+		return &GroupResourceAccessTokenEvent{}, nil
+	case projectEvent:
+		return &ProjectResourceAccessTokenEvent{}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// ParseHookOptionFunc can be passed to ParseHook, ParseWebhook and
+// ParseSystemhook to change how payloads are parsed.
+type ParseHookOptionFunc func(*hookParser)
+
+// WithLenientParsing makes the parse functions return an *UnknownEvent for
+// webhook events they don't recognize, instead of an error. System hook
+// events are always returned as an *UnknownSystemEvent in that case.
+func WithLenientParsing() ParseHookOptionFunc {
+	return func(p *hookParser) {
+		p.lenient = true
+	}
+}
+
+// WithStrictParsing makes the parse functions report the fields of a payload
+// that the event type has no struct field for, which are otherwise ignored.
+// The parsed event is returned together with an *UnknownFieldsError, e.g. to
+// detect changes of the payloads in tests.
+func WithStrictParsing() ParseHookOptionFunc {
+	return func(p *hookParser) {
+		p.strict = true
 	}
+}
 
+type hookParser struct {
+	lenient bool
+	strict  bool
+}
+
+func newHookParser(options []ParseHookOptionFunc) *hookParser {
+	p := &hookParser{}
+	for _, fn := range options {
+		if fn != nil {
+			fn(p)
+		}
+	}
+	return p
+}
+
+// decode decodes the payload into the event.
+func (p *hookParser) decode(payload []byte, event any) (any, error) {
 	if err := json.Unmarshal(payload, event); err != nil {
 		return nil, err
 	}
 
+	if p.strict {
+		if fields := unknownFields(payload, reflect.TypeOf(event)); len(fields) > 0 {
+			return event, &UnknownFieldsError{Event: event, Fields: fields}
+		}
+	}
+
 	return event, nil
 }
+
+// unknown returns an UnknownEvent for the payload in lenient mode, and the
+// given error otherwise.
+func (p *hookParser) unknown(eventType EventType, payload []byte, err error) (any, error) {
+	if !p.lenient {
+		return nil, err
+	}
+
+	e := &serviceEvent{}
+	if err := json.Unmarshal(payload, e); err != nil {
+		return nil, err
+	}
+
+	return &UnknownEvent{Type: eventType, ObjectKind: e.ObjectKind, Raw: payload}, nil
+}
+
+// UnknownEvent represents a webhook event without a corresponding type in
+// this package, e.g. an event added in a newer GitLab version. It is only
+// returned in lenient mode, see WithLenientParsing.
+type UnknownEvent struct {
+	Type       EventType
+	ObjectKind string
+	Raw        json.RawMessage
+}
+
+// MarshalJSON returns the raw payload of the event, so it is sent and stored
+// unchanged.
+func (e *UnknownEvent) MarshalJSON() ([]byte, error) {
+	return e.Raw, nil
+}
+
+// UnknownFieldsError is returned in strict mode if a payload contains fields
+// the event type has no struct field for, see WithStrictParsing.
+type UnknownFieldsError struct {
+	// Event is the parsed event.
+	Event any
+	// Fields are the paths of the unknown fields, e.g. "project.topics" or
+	// "commits[].author.username", in lexical order.
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("unknown fields in %T payload: %s", e.Event, strings.Join(e.Fields, ", "))
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// unknownFields returns the paths of the fields of the payload that are not
+// decoded into a value of type t.
+func unknownFields(payload []byte, t reflect.Type) []string {
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return nil
+	}
+
+	var fields []string
+	collectUnknownFields(v, t, "", &fields)
+	slices.Sort(fields)
+	return slices.Compact(fields)
+}
+
+func collectUnknownFields(v any, t reflect.Type, path string, fields *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Types with custom decoding may use any field of the payload.
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		known := jsonFields(t)
+		for key, value := range obj {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			ft, ok := known[strings.ToLower(key)]
+			if !ok {
+				*fields = append(*fields, fieldPath)
+				continue
+			}
+			collectUnknownFields(value, ft, fieldPath, fields)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		for key, value := range obj {
+			collectUnknownFields(value, t.Elem(), path+"."+key, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]any)
+		if !ok {
+			return
+		}
+		for _, value := range arr {
+			collectUnknownFields(value, t.Elem(), path+"[]", fields)
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of struct type t by their
+// lowercased JSON name. Like encoding/json, fields of embedded structs are
+// included, unless t has a field with the same name.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	embedded := make(map[string]reflect.Type)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			maps.Copy(embedded, jsonFields(ft))
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+
+	for name, ft := range embedded {
+		if _, ok := fields[name]; !ok {
+			fields[name] = ft
+		}
+	}
+	return fields
+}
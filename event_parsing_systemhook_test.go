@@ -17,9 +17,13 @@
 package gitlab
 
 import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseSystemhookPush(t *testing.T) {
@@ -179,6 +183,8 @@ func TestParseSystemhookUserGroup(t *testing.T) {
 		{"user_add_to_group", loadFixture(t, "testdata/systemhooks/user_add_to_group.json")},
 		{"user_remove_from_group", loadFixture(t, "testdata/systemhooks/user_remove_from_group.json")},
 		{"user_update_for_group", loadFixture(t, "testdata/systemhooks/user_update_for_group.json")},
+		{"user_access_request_to_group", loadFixture(t, "testdata/systemhooks/user_access_request_to_group.json")},
+		{"user_access_request_revoked_for_group", loadFixture(t, "testdata/systemhooks/user_access_request_revoked_for_group.json")},
 	}
 	for _, tc := range tests {
 		t.Run(tc.event, func(t *testing.T) {
@@ -206,6 +212,8 @@ func TestParseSystemhookUserTeam(t *testing.T) {
 		{"user_add_to_team", loadFixture(t, "testdata/systemhooks/user_add_to_team.json")},
 		{"user_remove_from_team", loadFixture(t, "testdata/systemhooks/user_remove_from_team.json")},
 		{"user_update_for_team", loadFixture(t, "testdata/systemhooks/user_update_for_team.json")},
+		{"user_access_request_to_project", loadFixture(t, "testdata/systemhooks/user_access_request_to_project.json")},
+		{"user_access_request_revoked_for_project", loadFixture(t, "testdata/systemhooks/user_access_request_revoked_for_project.json")},
 	}
 	for _, tc := range tests {
 		t.Run(tc.event, func(t *testing.T) {
@@ -224,6 +232,121 @@ func TestParseSystemhookUserTeam(t *testing.T) {
 	}
 }
 
+func TestParseSystemhookAccessToken(t *testing.T) {
+	t.Parallel()
+
+	parsedEvent, err := ParseSystemhook(loadFixture(t, "testdata/systemhooks/access_token_project.json"))
+	require.NoError(t, err)
+	projectEvent, ok := parsedEvent.(*ProjectResourceAccessTokenEvent)
+	require.True(t, ok, "Expected ProjectResourceAccessTokenEvent, but parsing produced %T", parsedEvent)
+	assert.Equal(t, "expiring_access_token", projectEvent.EventName)
+	assert.Equal(t, int64(7), projectEvent.Project.ID)
+
+	parsedEvent, err = ParseSystemhook(loadFixture(t, "testdata/systemhooks/access_token_group.json"))
+	require.NoError(t, err)
+	groupEvent, ok := parsedEvent.(*GroupResourceAccessTokenEvent)
+	require.True(t, ok, "Expected GroupResourceAccessTokenEvent, but parsing produced %T", parsedEvent)
+	assert.Equal(t, int64(35), groupEvent.Group.GroupID)
+}
+
+func TestParseSystemhookUnknown(t *testing.T) {
+	t.Parallel()
+	payload := loadFixture(t, "testdata/systemhooks/unknown.json")
+
+	parsedEvent, err := ParseHook(EventTypeSystemHook, payload)
+	require.NoError(t, err)
+
+	event, ok := parsedEvent.(*UnknownSystemEvent)
+	require.True(t, ok, "Expected UnknownSystemEvent, but parsing produced %T", parsedEvent)
+	assert.Equal(t, "user_example_event", event.EventName)
+	assert.Equal(t, "2012-07-21T07:30:56Z", event.CreatedAt)
+	assert.JSONEq(t, string(payload), string(event.Raw))
+
+	// The raw payload is marshaled unchanged.
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(b))
+
+	var user struct {
+		UserID int64 `json:"user_id"`
+	}
+	require.NoError(t, json.Unmarshal(event.Raw, &user))
+	assert.Equal(t, int64(41), user.UserID)
+}
+
+func TestParseSystemhookAccessTokenWithoutResource(t *testing.T) {
+	t.Parallel()
+	payload := []byte(`{"object_kind": "access_token", "event_name": "expiring_access_token", "user": {"id": 1}}`)
+
+	parsedEvent, err := ParseSystemhook(payload)
+	require.NoError(t, err)
+
+	event, ok := parsedEvent.(*UnknownSystemEvent)
+	require.True(t, ok, "Expected UnknownSystemEvent, but parsing produced %T", parsedEvent)
+	assert.Equal(t, "access_token", event.ObjectKind)
+	assert.Equal(t, "expiring_access_token", event.EventName)
+	assert.JSONEq(t, string(payload), string(event.Raw))
+}
+
+// TestParseSystemhookDocumentedEvents parses a fixture of every system hook
+// event documented at https://docs.gitlab.com/administration/system_hooks/.
+func TestParseSystemhookDocumentedEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]any{
+		"project_create":                          &ProjectSystemEvent{},
+		"project_destroy":                         &ProjectSystemEvent{},
+		"project_rename":                          &ProjectSystemEvent{},
+		"project_transfer":                        &ProjectSystemEvent{},
+		"project_update":                          &ProjectSystemEvent{},
+		"user_add_to_team":                        &UserTeamSystemEvent{},
+		"user_remove_from_team":                   &UserTeamSystemEvent{},
+		"user_update_for_team":                    &UserTeamSystemEvent{},
+		"user_access_request_to_project":          &UserTeamSystemEvent{},
+		"user_access_request_revoked_for_project": &UserTeamSystemEvent{},
+		"user_create":                             &UserSystemEvent{},
+		"user_destroy":                            &UserSystemEvent{},
+		"user_failed_login":                       &UserSystemEvent{},
+		"user_rename":                             &UserSystemEvent{},
+		"key_create":                              &KeySystemEvent{},
+		"key_destroy":                             &KeySystemEvent{},
+		"group_create":                            &GroupSystemEvent{},
+		"group_destroy":                           &GroupSystemEvent{},
+		"group_rename":                            &GroupSystemEvent{},
+		"user_add_to_group":                       &UserGroupSystemEvent{},
+		"user_remove_from_group":                  &UserGroupSystemEvent{},
+		"user_update_for_group":                   &UserGroupSystemEvent{},
+		"user_access_request_to_group":            &UserGroupSystemEvent{},
+		"user_access_request_revoked_for_group":   &UserGroupSystemEvent{},
+		"push":                                    &PushSystemEvent{},
+		"tag_push":                                &TagPushSystemEvent{},
+		"repository_update":                       &RepositoryUpdateSystemEvent{},
+		"merge_request":                           &MergeEvent{},
+		"access_token_project":                    &ProjectResourceAccessTokenEvent{},
+		"access_token_group":                      &GroupResourceAccessTokenEvent{},
+	}
+
+	// Every fixture, except the one of an unknown event, is covered.
+	fixtures, err := filepath.Glob("testdata/systemhooks/*.json")
+	require.NoError(t, err)
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		if name != "unknown" {
+			assert.Contains(t, tests, name, "fixture %s is not covered", fixture)
+		}
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			parsedEvent, err := ParseSystemhook(loadFixture(t, "testdata/systemhooks/"+name+".json"))
+			require.NoError(t, err)
+			assert.IsType(t, want, parsedEvent)
+		})
+	}
+}
+
 func TestParseHookSystemHook(t *testing.T) {
 	t.Parallel()
 	parsedEvent1, err := ParseHook("System Hook", loadFixture(t, "testdata/systemhooks/merge_request.json"))
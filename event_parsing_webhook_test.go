@@ -17,10 +17,12 @@
 package gitlab
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWebhookEventType(t *testing.T) {
@@ -444,3 +446,98 @@ func TestParseVulnerabilityHook(t *testing.T) {
 	assert.Len(t, event.ObjectAttributes.Identifiers, 1)
 	assert.Equal(t, "CVE-2024-1234", event.ObjectAttributes.Identifiers[0].Name)
 }
+
+func TestParseHookRaw(t *testing.T) {
+	t.Parallel()
+
+	raw := loadFixture(t, "testdata/webhooks/push.json")
+	parsed, err := ParseHookRaw(EventTypePush, raw)
+	require.NoError(t, err)
+	assert.IsType(t, &PushEvent{}, parsed.Event)
+	assert.Equal(t, json.RawMessage(raw), parsed.Raw)
+
+	raw = loadFixture(t, "testdata/systemhooks/project_create.json")
+	parsed, err = ParseHookRaw(EventTypeSystemHook, raw)
+	require.NoError(t, err)
+	assert.IsType(t, &ProjectSystemEvent{}, parsed.Event)
+	assert.Equal(t, json.RawMessage(raw), parsed.Raw)
+
+	// The parsed events equal events decoded without the parse functions.
+	raw = loadFixture(t, "testdata/webhooks/merge_request.json")
+	var want MergeEvent
+	require.NoError(t, json.Unmarshal(raw, &want))
+	parsed, err = ParseHookRaw(EventTypeMergeRequest, raw)
+	require.NoError(t, err)
+	assert.Equal(t, &want, parsed.Event)
+
+	// In strict mode, the event is returned with the unknown fields.
+	parsed, err = ParseHookRaw(EventTypePush, []byte(`{"object_kind": "push", "ref": "refs/heads/main", "new_field": true}`), WithStrictParsing())
+	var fieldsErr *UnknownFieldsError
+	require.ErrorAs(t, err, &fieldsErr)
+	assert.Equal(t, []string{"new_field"}, fieldsErr.Fields)
+	assert.Equal(t, "refs/heads/main", parsed.Event.(*PushEvent).Ref)
+
+	_, err = ParseHookRaw("Work Item Hook", []byte(`{}`))
+	assert.EqualError(t, err, "unexpected event type: Work Item Hook")
+}
+
+func TestParseWebhook_Lenient(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{"object_kind": "work_item", "object_attributes": {"id": 1}}`)
+
+	_, err := ParseWebhook("Work Item Hook", raw)
+	assert.EqualError(t, err, "unexpected event type: Work Item Hook")
+
+	parsedEvent, err := ParseWebhook("Work Item Hook", raw, WithLenientParsing())
+	require.NoError(t, err)
+	assert.Equal(t, &UnknownEvent{Type: "Work Item Hook", ObjectKind: "work_item", Raw: raw}, parsedEvent)
+
+	// Unknown kinds of known event types are returned as UnknownEvent too.
+	raw = []byte(`{"object_kind": "note", "object_attributes": {"noteable_type": "WorkItem"}}`)
+	parsedEvent, err = ParseWebhook(EventTypeNote, raw, WithLenientParsing())
+	require.NoError(t, err)
+	assert.Equal(t, &UnknownEvent{Type: EventTypeNote, ObjectKind: "note", Raw: raw}, parsedEvent)
+
+	b, err := json.Marshal(parsedEvent)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(raw), string(b))
+
+	_, err = ParseWebhook("Work Item Hook", []byte(`{`), WithLenientParsing())
+	assert.Error(t, err)
+}
+
+func TestParseWebhook_Strict(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte(`{
+		"object_kind": "push",
+		"ref": "refs/heads/main",
+		"ref_type": "branch",
+		"project": {"id": 15, "topics": ["go"]},
+		"commits": [
+			{"id": "a", "timestamp": "2011-12-12T14:27:31+02:00", "author": {"name": "Jordi", "username": "jordi"}},
+			{"id": "b", "author": {"name": "Jordi", "username": "jordi"}}
+		]
+	}`)
+
+	parsedEvent, err := ParseWebhook(EventTypePush, raw)
+	require.NoError(t, err)
+	assert.Equal(t, "refs/heads/main", parsedEvent.(*PushEvent).Ref)
+
+	parsedEvent, err = ParseWebhook(EventTypePush, raw, WithStrictParsing())
+	var fieldsErr *UnknownFieldsError
+	require.ErrorAs(t, err, &fieldsErr)
+	assert.Equal(t, []string{"commits[].author.username", "project.topics", "ref_type"}, fieldsErr.Fields)
+	assert.EqualError(t, err, "unknown fields in *gitlab.PushEvent payload: commits[].author.username, project.topics, ref_type")
+
+	// The event is still parsed.
+	event, ok := parsedEvent.(*PushEvent)
+	require.True(t, ok, "Expected PushEvent, but parsing produced %T", parsedEvent)
+	assert.Same(t, event, fieldsErr.Event)
+	assert.Equal(t, int64(15), event.Project.ID)
+
+	// Fields of embedded structs are known.
+	_, err = ParseSystemhook(loadFixture(t, "testdata/systemhooks/project_create.json"), WithStrictParsing())
+	assert.NoError(t, err)
+}
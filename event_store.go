@@ -0,0 +1,423 @@
+package gitlab
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrEventExists is returned by an EventStore when appending an event that
+// was already delivered by the same webhook.
+var ErrEventExists = errors.New("event already exists in the store")
+
+// StoredEvent is a web- or system hook event captured by an EventStore. The
+// raw payload is stored, so the event can be parsed again with the types of
+// later versions of this package.
+type StoredEvent struct {
+	// Sequence is the position of the event in the store, starting at 1.
+	// It is assigned by EventStore.Append.
+	Sequence int64 `json:"sequence"`
+
+	EventType      EventType `json:"event_type"`
+	EventUUID      string    `json:"event_uuid,omitempty"`
+	IdempotencyKey string    `json:"idempotency_key,omitempty"`
+	WebhookUUID    string    `json:"webhook_uuid,omitempty"`
+	Instance       string    `json:"instance,omitempty"`
+
+	// ProjectID is the ID of the project the event belongs to, if any.
+	ProjectID int64 `json:"project_id,omitempty"`
+
+	ReceivedAt time.Time       `json:"received_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// NewStoredEvent returns a StoredEvent for the payload of a delivery, which
+// can be appended to an EventStore. The payload must be valid JSON.
+func NewStoredEvent(delivery *WebhookDelivery, payload []byte) (*StoredEvent, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, payload); err != nil {
+		return nil, err
+	}
+
+	return &StoredEvent{
+		EventType:      delivery.EventType,
+		EventUUID:      delivery.EventUUID,
+		IdempotencyKey: delivery.IdempotencyKey,
+		WebhookUUID:    delivery.WebhookUUID,
+		Instance:       delivery.Instance,
+		ProjectID:      hookProjectID(payload),
+		ReceivedAt:     time.Now(),
+		Payload:        compact.Bytes(),
+	}, nil
+}
+
+// Parse parses the stored payload like ParseHook.
+func (e *StoredEvent) Parse(options ...ParseHookOptionFunc) (any, error) {
+	return ParseHook(e.EventType, e.Payload, options...)
+}
+
+// Delivery returns the WebhookDelivery the event was received with.
+func (e *StoredEvent) Delivery() *WebhookDelivery {
+	return &WebhookDelivery{
+		EventType:      e.EventType,
+		EventUUID:      e.EventUUID,
+		IdempotencyKey: e.IdempotencyKey,
+		Instance:       e.Instance,
+		WebhookUUID:    e.WebhookUUID,
+	}
+}
+
+// storedEventKey identifies a delivered event. GitLab assigns the same event
+// UUID to the deliveries of an event to different webhooks, so the UUID
+// alone is not unique.
+type storedEventKey struct {
+	webhookUUID string
+	eventUUID   string
+}
+
+// key returns the key of the event, and false if it has no event UUID.
+func (e *StoredEvent) key() (storedEventKey, bool) {
+	return storedEventKey{webhookUUID: e.WebhookUUID, eventUUID: e.EventUUID}, e.EventUUID != ""
+}
+
+// hookProjectID returns the ID of the project of a hook payload. Webhooks
+// contain a project object, system hooks and some webhooks only its ID.
+func hookProjectID(payload []byte) int64 {
+	var p struct {
+		ProjectID *int64 `json:"project_id"`
+		Project   *struct {
+			ID int64 `json:"id"`
+		} `json:"project"`
+		ObjectAttributes *struct {
+			ProjectID       int64 `json:"project_id"`
+			TargetProjectID int64 `json:"target_project_id"`
+		} `json:"object_attributes"`
+	}
+	// Fields of unexpected types are skipped, the others are still decoded.
+	_ = json.Unmarshal(payload, &p)
+
+	switch {
+	case p.Project != nil && p.Project.ID != 0:
+		return p.Project.ID
+	case p.ProjectID != nil:
+		return *p.ProjectID
+	case p.ObjectAttributes != nil && p.ObjectAttributes.TargetProjectID != 0:
+		return p.ObjectAttributes.TargetProjectID
+	case p.ObjectAttributes != nil:
+		return p.ObjectAttributes.ProjectID
+	default:
+		return 0
+	}
+}
+
+// EventFilter selects stored events. The zero value matches all events.
+type EventFilter struct {
+	EventUUID  string
+	ProjectID  int64
+	EventTypes []EventType
+
+	// AfterSequence only matches events stored after the event with the
+	// given sequence number, e.g. to resume an interrupted replay.
+	AfterSequence int64
+
+	// Since and Until limit the time the events were received at.
+	Since time.Time
+	Until time.Time
+}
+
+func (f *EventFilter) matches(e *StoredEvent) bool {
+	if f == nil {
+		return true
+	}
+
+	switch {
+	case f.EventUUID != "" && e.EventUUID != f.EventUUID:
+		return false
+	case f.ProjectID != 0 && e.ProjectID != f.ProjectID:
+		return false
+	case len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, e.EventType):
+		return false
+	case e.Sequence <= f.AfterSequence:
+		return false
+	case !f.Since.IsZero() && e.ReceivedAt.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.ReceivedAt.Before(f.Until):
+		return false
+	default:
+		return true
+	}
+}
+
+// EventStore is an append-only store of web- and system hook events.
+type EventStore interface {
+	// Append assigns the next sequence number to the event and stores it.
+	// It returns ErrEventExists if an event with the same webhook and event
+	// UUID was already stored. Events without a UUID are always stored.
+	Append(ctx context.Context, event *StoredEvent) error
+	// Events returns the stored events matching the filter in the order
+	// they were appended. A nil filter matches all events.
+	Events(ctx context.Context, filter *EventFilter) iter.Seq2[*StoredEvent, error]
+}
+
+// StoreHook parses a web- or system hook payload like ParseHook, and appends
+// it to the store together with the metadata of its delivery. If the event
+// was already stored, the parsed event is returned with ErrEventExists.
+func StoreHook(ctx context.Context, store EventStore, delivery *WebhookDelivery, payload []byte, options ...ParseHookOptionFunc) (any, error) {
+	event, err := ParseHook(delivery.EventType, payload, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := NewStoredEvent(delivery, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Append(ctx, stored); err != nil {
+		if errors.Is(err, ErrEventExists) {
+			return event, err
+		}
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// InMemoryEventStore is an EventStore that keeps the events in memory.
+type InMemoryEventStore struct {
+	mu     sync.Mutex
+	events []*StoredEvent
+	keys   map[storedEventKey]bool
+}
+
+var _ EventStore = (*InMemoryEventStore)(nil)
+
+// NewInMemoryEventStore returns a new, empty InMemoryEventStore.
+func NewInMemoryEventStore() *InMemoryEventStore {
+	return &InMemoryEventStore{keys: make(map[storedEventKey]bool)}
+}
+
+func (s *InMemoryEventStore) Append(_ context.Context, event *StoredEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, hasKey := event.key()
+	if hasKey && s.keys[key] {
+		return ErrEventExists
+	}
+
+	event.Sequence = int64(len(s.events)) + 1
+	stored := *event
+	s.events = append(s.events, &stored)
+	if hasKey {
+		s.keys[key] = true
+	}
+
+	return nil
+}
+
+func (s *InMemoryEventStore) Events(ctx context.Context, filter *EventFilter) iter.Seq2[*StoredEvent, error] {
+	return func(yield func(*StoredEvent, error) bool) {
+		s.mu.Lock()
+		events := slices.Clone(s.events)
+		s.mu.Unlock()
+
+		for _, e := range events {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !filter.matches(e) {
+				continue
+			}
+			stored := *e
+			if !yield(&stored, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FileEventStore is an EventStore that appends the events to a file with
+// one JSON document per line (JSON Lines). Every event is synced to disk
+// before Append returns. A failed append is truncated from the file again. If
+// that fails as well, all further appends return an error.
+type FileEventStore struct {
+	path string
+
+	mu       sync.Mutex
+	file     eventFile
+	size     int64
+	sequence int64
+	keys     map[storedEventKey]bool
+
+	// err is set if a failed append could not be rolled back. The store
+	// refuses further appends then, so the file isn't corrupted.
+	err error
+}
+
+// eventFile is the part of *os.File used by a FileEventStore.
+type eventFile interface {
+	io.WriteCloser
+	Sync() error
+	Truncate(size int64) error
+}
+
+var _ EventStore = (*FileEventStore)(nil)
+
+// OpenFileEventStore opens the event store at the given path, creating the
+// file if it doesn't exist. An incomplete last line, left behind by a crash
+// while appending, is removed.
+func OpenFileEventStore(path string) (*FileEventStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileEventStore{path: path, file: file, keys: make(map[storedEventKey]bool)}
+
+	var (
+		r     = bufio.NewReader(file)
+		valid int64
+	)
+	for line := 1; ; line++ {
+		data, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		e := new(StoredEvent)
+		if err := json.Unmarshal(data, e); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("decoding event at %s:%d: %w", path, line, err)
+		}
+		s.sequence = e.Sequence
+		if key, ok := e.key(); ok {
+			s.keys[key] = true
+		}
+		valid += int64(len(data))
+	}
+
+	if err := file.Truncate(valid); err != nil {
+		file.Close()
+		return nil, err
+	}
+	s.size = valid
+
+	return s, nil
+}
+
+// Close closes the file of the store.
+func (s *FileEventStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *FileEventStore) Append(_ context.Context, event *StoredEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	key, hasKey := event.key()
+	if hasKey && s.keys[key] {
+		return ErrEventExists
+	}
+
+	stored := *event
+	stored.Sequence = s.sequence + 1
+	data, err := json.Marshal(&stored)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := s.write(data); err != nil {
+		return err
+	}
+
+	s.size += int64(len(data))
+	s.sequence = stored.Sequence
+	event.Sequence = stored.Sequence
+	if hasKey {
+		s.keys[key] = true
+	}
+
+	return nil
+}
+
+// write appends data to the file and syncs it. If that fails, the file is
+// truncated to its previous size, so no partial line is left behind for the
+// next append to write after.
+func (s *FileEventStore) write(data []byte) error {
+	_, err := s.file.Write(data)
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if err == nil {
+		return nil
+	}
+
+	if terr := s.file.Truncate(s.size); terr != nil {
+		s.err = fmt.Errorf("event store %s is broken after a failed append: %w", s.path, errors.Join(err, terr))
+		return s.err
+	}
+	return err
+}
+
+func (s *FileEventStore) Events(ctx context.Context, filter *EventFilter) iter.Seq2[*StoredEvent, error] {
+	return func(yield func(*StoredEvent, error) bool) {
+		file, err := os.Open(s.path)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer file.Close()
+
+		r := bufio.NewReader(file)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			// A line without a newline is still being appended.
+			data, err := r.ReadBytes('\n')
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			e := new(StoredEvent)
+			if err := json.Unmarshal(data, e); err != nil {
+				yield(nil, fmt.Errorf("decoding event: %w", err))
+				return
+			}
+			if !filter.matches(e) {
+				continue
+			}
+			if !yield(e, nil) {
+				return
+			}
+		}
+	}
+}
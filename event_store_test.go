@@ -0,0 +1,287 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collectStoredEvents(t *testing.T, store EventStore, filter *EventFilter) []*StoredEvent {
+	t.Helper()
+
+	var events []*StoredEvent
+	for e, err := range store.Events(context.Background(), filter) {
+		require.NoError(t, err)
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestFileEventStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	store, err := OpenFileEventStore(path)
+	require.NoError(t, err)
+
+	event, err := StoreHook(ctx, store, &WebhookDelivery{
+		EventType:      EventTypeMergeRequest,
+		EventUUID:      "merge-uuid",
+		IdempotencyKey: "merge-key",
+	}, loadFixture(t, "testdata/webhooks/merge_request.json"))
+	require.NoError(t, err)
+	assert.IsType(t, &MergeEvent{}, event)
+
+	_, err = StoreHook(ctx, store, &WebhookDelivery{
+		EventType: EventTypePush,
+		EventUUID: "push-uuid",
+	}, loadFixture(t, "testdata/webhooks/push.json"))
+	require.NoError(t, err)
+
+	event, err = StoreHook(ctx, store, &WebhookDelivery{
+		EventType: EventTypeMergeRequest,
+		EventUUID: "merge-uuid",
+	}, loadFixture(t, "testdata/webhooks/merge_request.json"))
+	require.ErrorIs(t, err, ErrEventExists)
+	assert.IsType(t, &MergeEvent{}, event)
+	require.NoError(t, store.Close())
+
+	// Reopening the store keeps the sequence and the known UUIDs.
+	store, err = OpenFileEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	_, err = StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypeMergeRequest, EventUUID: "merge-uuid"}, loadFixture(t, "testdata/webhooks/merge_request.json"))
+	require.ErrorIs(t, err, ErrEventExists)
+
+	_, err = StoreHook(ctx, store, &WebhookDelivery{
+		EventType: EventTypeSystemHook,
+	}, loadFixture(t, "testdata/systemhooks/project_create.json"))
+	require.NoError(t, err)
+
+	events := collectStoredEvents(t, store, nil)
+	require.Len(t, events, 3)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{events[0].Sequence, events[1].Sequence, events[2].Sequence})
+	assert.Equal(t, []int64{1, 15, 74}, []int64{events[0].ProjectID, events[1].ProjectID, events[2].ProjectID})
+	assert.Equal(t, "merge-uuid", events[0].EventUUID)
+	assert.Equal(t, "merge-key", events[0].Delivery().IdempotencyKey)
+	assert.False(t, events[0].ReceivedAt.IsZero())
+
+	system, err := events[2].Parse()
+	require.NoError(t, err)
+	assert.IsType(t, &ProjectSystemEvent{}, system)
+
+	events = collectStoredEvents(t, store, &EventFilter{ProjectID: 15})
+	require.Len(t, events, 1)
+	assert.Equal(t, "push-uuid", events[0].EventUUID)
+
+	events = collectStoredEvents(t, store, &EventFilter{EventUUID: "merge-uuid"})
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeMergeRequest, events[0].EventType)
+
+	events = collectStoredEvents(t, store, &EventFilter{EventTypes: []EventType{EventTypePush, EventTypeSystemHook}, AfterSequence: 2})
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(3), events[0].Sequence)
+}
+
+func TestEventStore_SameEventForDifferentWebhooks(t *testing.T) {
+	t.Parallel()
+
+	stores := map[string]func(t *testing.T) EventStore{
+		"memory": func(t *testing.T) EventStore {
+			return NewInMemoryEventStore()
+		},
+		"file": func(t *testing.T) EventStore {
+			store, err := OpenFileEventStore(filepath.Join(t.TempDir(), "events.jsonl"))
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Close() })
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			store := newStore(t)
+			payload := loadFixture(t, "testdata/webhooks/push.json")
+
+			for _, webhook := range []string{"hook-a", "hook-b"} {
+				_, err := StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "push-uuid", WebhookUUID: webhook}, payload)
+				require.NoError(t, err)
+			}
+
+			_, err := StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "push-uuid", WebhookUUID: "hook-b"}, payload)
+			require.ErrorIs(t, err, ErrEventExists)
+
+			events := collectStoredEvents(t, store, &EventFilter{EventUUID: "push-uuid"})
+			require.Len(t, events, 2)
+			assert.Equal(t, "hook-a", events[0].WebhookUUID)
+			assert.Equal(t, "hook-b", events[1].WebhookUUID)
+		})
+	}
+}
+
+func TestStoreHook_ParseOptions(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryEventStore()
+	delivery := &WebhookDelivery{EventType: "Work Item Hook", EventUUID: "work-item-uuid"}
+	payload := []byte(`{"object_kind": "work_item"}`)
+
+	_, err := StoreHook(context.Background(), store, delivery, payload)
+	require.EqualError(t, err, "unexpected event type: Work Item Hook")
+
+	event, err := StoreHook(context.Background(), store, delivery, payload, WithLenientParsing())
+	require.NoError(t, err)
+	assert.Equal(t, "work_item", event.(*UnknownEvent).ObjectKind)
+	assert.Len(t, collectStoredEvents(t, store, nil), 1)
+}
+
+func TestOpenFileEventStore_IncompleteLine(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	store, err := OpenFileEventStore(path)
+	require.NoError(t, err)
+	_, err = StoreHook(context.Background(), store, &WebhookDelivery{EventType: EventTypePush}, loadFixture(t, "testdata/webhooks/push.json"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"sequence": 2, "event_type": "Push Hook", "payl`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	store, err = OpenFileEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	_, err = StoreHook(context.Background(), store, &WebhookDelivery{EventType: EventTypePush}, loadFixture(t, "testdata/webhooks/push.json"))
+	require.NoError(t, err)
+
+	events := collectStoredEvents(t, store, nil)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(2), events[1].Sequence)
+}
+
+// failingEventFile writes only half of the data of a write and fails, like
+// a full disk.
+type failingEventFile struct {
+	eventFile
+	truncateErr error
+}
+
+func (f *failingEventFile) Write(p []byte) (int, error) {
+	n, _ := f.eventFile.Write(p[:len(p)/2])
+	return n, errors.New("no space left on device")
+}
+
+func (f *failingEventFile) Truncate(size int64) error {
+	if f.truncateErr != nil {
+		return f.truncateErr
+	}
+	return f.eventFile.Truncate(size)
+}
+
+func TestFileEventStore_FailedAppend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	store, err := OpenFileEventStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	payload := loadFixture(t, "testdata/webhooks/push.json")
+	_, err = StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "first"}, payload)
+	require.NoError(t, err)
+
+	// A failed append is rolled back, so the next one starts on a new line.
+	file := store.file
+	store.file = &failingEventFile{eventFile: file}
+	_, err = StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "second"}, payload)
+	require.ErrorContains(t, err, "no space left on device")
+
+	store.file = file
+	_, err = StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "second"}, payload)
+	require.NoError(t, err)
+
+	events := collectStoredEvents(t, store, nil)
+	require.Len(t, events, 2)
+	assert.Equal(t, []int64{1, 2}, []int64{events[0].Sequence, events[1].Sequence})
+	assert.Equal(t, "second", events[1].EventUUID)
+
+	// If the rollback fails too, the store refuses further appends.
+	store.file = &failingEventFile{eventFile: file, truncateErr: errors.New("read-only file system")}
+	_, err = StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "third"}, payload)
+	require.ErrorContains(t, err, "is broken after a failed append")
+
+	store.file = file
+	_, err = StoreHook(ctx, store, &WebhookDelivery{EventType: EventTypePush, EventUUID: "fourth"}, payload)
+	require.ErrorContains(t, err, "is broken after a failed append")
+}
+
+func TestWebhookHandler_Replay(t *testing.T) {
+	t.Parallel()
+
+	store := NewInMemoryEventStore()
+	h := NewWebhookHandler("secret", WithWebhookEventStore(store))
+
+	for _, req := range []*http.Request{
+		newWebhookRequest(t, EventTypeMergeRequest, "testdata/webhooks/merge_request.json"),
+		newWebhookRequest(t, EventTypePipeline, "testdata/webhooks/pipeline.json"),
+		newWebhookRequest(t, EventTypeSystemHook, "testdata/systemhooks/project_create.json"),
+	} {
+		req.Header.Set("X-Gitlab-Event-UUID", string(HookEventType(req)))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	require.Len(t, collectStoredEvents(t, store, nil), 3)
+
+	// Replay the stored events into a new consumer.
+	replay := NewWebhookHandler("secret")
+	var (
+		kinds      []string
+		deliveries []string
+	)
+	replay.OnMergeRequest(func(ctx context.Context, e *MergeEvent) error {
+		kinds = append(kinds, e.ObjectKind)
+		d, _ := WebhookDeliveryFromContext(ctx)
+		deliveries = append(deliveries, d.EventUUID)
+		return nil
+	})
+	replay.OnPipeline(func(_ context.Context, e *PipelineEvent) error {
+		kinds = append(kinds, e.ObjectKind)
+		return errors.New("downstream unavailable")
+	})
+	OnEvent(replay, func(_ context.Context, e *ProjectSystemEvent) error {
+		kinds = append(kinds, e.EventName)
+		return nil
+	})
+
+	n, err := replay.Replay(context.Background(), store, nil)
+	assert.ErrorContains(t, err, "replaying event 2: downstream unavailable")
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"merge_request", "pipeline"}, kinds)
+	assert.Equal(t, []string{string(EventTypeMergeRequest)}, deliveries)
+
+	// Resume after the failed event.
+	kinds = nil
+	n, err = replay.Replay(context.Background(), store, &EventFilter{AfterSequence: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"project_create"}, kinds)
+}
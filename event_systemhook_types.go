@@ -16,7 +16,10 @@
 
 package gitlab
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // systemHookEvent is used to pre-process events to determine the
 // system hook event type.
@@ -35,6 +38,26 @@ type BaseSystemEvent struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+// UnknownSystemEvent represents a system hook event without a corresponding
+// type in this package, e.g. an event added in a newer GitLab version. Raw
+// holds the complete payload, so it can still be decoded by the caller.
+type UnknownSystemEvent struct {
+	BaseSystemEvent
+	ObjectKind string          `json:"object_kind"`
+	Raw        json.RawMessage `json:"-"`
+}
+
+// MarshalJSON returns the raw payload of the event, so it is sent and stored
+// unchanged.
+func (e *UnknownSystemEvent) MarshalJSON() ([]byte, error) {
+	if e.Raw != nil {
+		return e.Raw, nil
+	}
+
+	type alias UnknownSystemEvent
+	return json.Marshal((*alias)(e))
+}
+
 // ProjectSystemEvent represents a project system event.
 //
 // GitLab API docs:
@@ -93,7 +116,8 @@ type UserSystemEvent struct {
 	State       string `json:"state,omitempty"`
 }
 
-// UserGroupSystemEvent represents a user group system event.
+// UserGroupSystemEvent represents a user group system event. It is also sent
+// for requests to access a group.
 //
 // GitLab API docs:
 // https://docs.gitlab.com/administration/system_hooks/
@@ -109,7 +133,8 @@ type UserGroupSystemEvent struct {
 	GroupAccess string `json:"group_access"`
 }
 
-// UserTeamSystemEvent represents a user team system event.
+// UserTeamSystemEvent represents a user team system event. It is also sent
+// for requests to access a project.
 //
 // GitLab API docs:
 // https://docs.gitlab.com/administration/system_hooks/
@@ -56,6 +56,7 @@ const (
 
 	AccessTokenHeaderName = "Private-Token"
 	JobTokenHeaderName    = "Job-Token"
+	DeployTokenHeaderName = "Deploy-Token"
 )
 
 // AuthType represents an authentication type within GitLab.
@@ -139,6 +140,20 @@ type Client struct {
 	// Default request options applied to every request.
 	defaultRequestOptions []RequestOptionFunc
 
+	// responseCache stores responses for conditional requests.
+	responseCache ResponseCache
+
+	// responseCacheMaxBodySize is the size of the largest response body
+	// stored in the responseCache. Defaults to
+	// DefaultResponseCacheMaxBodySize if 0.
+	responseCacheMaxBodySize int64
+
+	// retryPolicies declares how failed requests are retried.
+	retryPolicies *RetryPolicies
+
+	// instrumentation receives observability events for every API call.
+	instrumentation Instrumentation
+
 	// interceptors contain the stack of *http.Client round tripper builder func
 	// which are used to decorate the http.Client#Transport value.
 	interceptors []Interceptor
@@ -224,6 +239,7 @@ type Client struct {
 	GroupVariables                   GroupVariablesServiceInterface
 	GroupWikis                       GroupWikisServiceInterface
 	Groups                           GroupsServiceInterface
+	HelmCharts                       HelmChartsServiceInterface
 	Import                           ImportServiceInterface
 	InstanceCluster                  InstanceClustersServiceInterface
 	InstanceVariables                InstanceVariablesServiceInterface
@@ -238,6 +254,7 @@ type Client struct {
 	License                          LicenseServiceInterface
 	LicenseTemplates                 LicenseTemplatesServiceInterface
 	Markdown                         MarkdownServiceInterface
+	MavenPackages                    MavenPackagesServiceInterface
 	MemberRolesService               MemberRolesServiceInterface
 	MergeRequestApprovals            MergeRequestApprovalsServiceInterface
 	MergeRequestApprovalSettings     MergeRequestApprovalSettingsServiceInterface
@@ -250,6 +267,7 @@ type Client struct {
 	Namespaces                       NamespacesServiceInterface
 	Notes                            NotesServiceInterface
 	NotificationSettings             NotificationSettingsServiceInterface
+	NPMPackages                      NPMPackagesServiceInterface
 	Packages                         PackagesServiceInterface
 	Pages                            PagesServiceInterface
 	PagesDomains                     PagesDomainsServiceInterface
@@ -280,6 +298,7 @@ type Client struct {
 	ProtectedEnvironments            ProtectedEnvironmentsServiceInterface
 	ProtectedPackages                ProtectedPackagesServiceInterface
 	ProtectedTags                    ProtectedTagsServiceInterface
+	PyPIPackages                     PyPIPackagesServiceInterface
 	ReleaseLinks                     ReleaseLinksServiceInterface
 	Releases                         ReleasesServiceInterface
 	Repositories                     RepositoriesServiceInterface
@@ -294,6 +313,7 @@ type Client struct {
 	RunnerControllers                RunnerControllersServiceInterface
 	RunnerControllerScopes           RunnerControllerScopesServiceInterface
 	RunnerControllerTokens           RunnerControllerTokensServiceInterface
+	RunnerJobs                       RunnerJobsServiceInterface
 	Runners                          RunnersServiceInterface
 	Search                           SearchServiceInterface
 	SecureFiles                      SecureFilesServiceInterface
@@ -304,6 +324,7 @@ type Client struct {
 	Snippets                         SnippetsServiceInterface
 	SystemHooks                      SystemHooksServiceInterface
 	Tags                             TagsServiceInterface
+	TerraformModules                 TerraformModulesServiceInterface
 	TerraformStates                  TerraformStatesServiceInterface
 	Todos                            TodosServiceInterface
 	Topics                           TopicsServiceInterface
@@ -447,6 +468,8 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	}
 
 	decorateHTTPClientTransportWithInterceptors(c)
+	decorateHTTPClientTransportWithInstrumentation(c)
+	decorateHTTPClientTransportWithRateLimitObserver(c)
 
 	// Wire up the cookie jar.
 	// The ClientOptionFunc can't do it directly,
@@ -546,6 +569,7 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	c.GroupVariables = &GroupVariablesService{client: c}
 	c.GroupWikis = &GroupWikisService{client: c}
 	c.Groups = &GroupsService{client: c}
+	c.HelmCharts = &HelmChartsService{client: c}
 	c.Import = &ImportService{client: c}
 	c.InstanceCluster = &InstanceClustersService{client: c}
 	c.InstanceVariables = &InstanceVariablesService{client: c}
@@ -560,6 +584,7 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	c.License = &LicenseService{client: c}
 	c.LicenseTemplates = &LicenseTemplatesService{client: c}
 	c.Markdown = &MarkdownService{client: c}
+	c.MavenPackages = &MavenPackagesService{client: c}
 	c.MemberRolesService = &MemberRolesService{client: c}
 	c.MergeRequestApprovals = &MergeRequestApprovalsService{client: c}
 	c.MergeRequestApprovalSettings = &MergeRequestApprovalSettingsService{client: c}
@@ -572,6 +597,7 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	c.Namespaces = &NamespacesService{client: c}
 	c.Notes = &NotesService{client: c}
 	c.NotificationSettings = &NotificationSettingsService{client: c}
+	c.NPMPackages = &NPMPackagesService{client: c}
 	c.Packages = &PackagesService{client: c}
 	c.Pages = &PagesService{client: c}
 	c.PagesDomains = &PagesDomainsService{client: c}
@@ -602,6 +628,7 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	c.ProtectedEnvironments = &ProtectedEnvironmentsService{client: c}
 	c.ProtectedPackages = &ProtectedPackagesService{client: c}
 	c.ProtectedTags = &ProtectedTagsService{client: c}
+	c.PyPIPackages = &PyPIPackagesService{client: c}
 	c.ReleaseLinks = &ReleaseLinksService{client: c}
 	c.Releases = &ReleasesService{client: c}
 	c.Repositories = &RepositoriesService{client: c}
@@ -616,6 +643,7 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	c.RunnerControllers = &RunnerControllersService{client: c}
 	c.RunnerControllerScopes = &RunnerControllerScopesService{client: c}
 	c.RunnerControllerTokens = &RunnerControllerTokensService{client: c}
+	c.RunnerJobs = &RunnerJobsService{client: c}
 	c.Runners = &RunnersService{client: c}
 	c.Search = &SearchService{client: c}
 	c.SecureFiles = &SecureFilesService{client: c}
@@ -626,6 +654,7 @@ func NewAuthSourceClient(as AuthSource, options ...ClientOptionFunc) (*Client, e
 	c.SnippetRepositoryStorageMove = &SnippetRepositoryStorageMoveService{client: c}
 	c.SystemHooks = &SystemHooksService{client: c}
 	c.Tags = &TagsService{client: c}
+	c.TerraformModules = &TerraformModulesService{client: c}
 	c.TerraformStates = &TerraformStatesService{client: c}
 	c.Todos = &TodosService{client: c}
 	c.Topics = &TopicsService{client: c}
@@ -1086,6 +1115,10 @@ type Response struct {
 
 	// GraphQL pagination.
 	PageInfo *PageInfo
+
+	// FromCache reports whether the response was replayed from the
+	// ResponseCache, because GitLab responded with 304 Not Modified.
+	FromCache bool
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -1167,8 +1200,23 @@ func (r *Response) populateLinkValues() {
 // first decode it. If v is a *bodyReader, the response body is preserved
 // without copying and the caller is responsible for closing it.
 func (c *Client) Do(req *retryablehttp.Request, v any) (*Response, error) {
+	if c.instrumentation == nil {
+		return c.sendRequest(req, v)
+	}
+
+	ctx, endCall := c.instrumentCall(req)
+	resp, err := c.sendRequest(req.WithContext(ctx), v)
+	endCall(resp, err)
+
+	return resp, err
+}
+
+// sendRequest implements Do, after the instrumentation of the call started.
+func (c *Client) sendRequest(req *retryablehttp.Request, v any) (*Response, error) {
 	// Wait will block until the limiter can obtain a new token.
+	start := time.Now()
 	err := c.limiter.Wait(req.Context())
+	recordRateLimitWait(req.Context(), time.Since(start))
 	if err != nil {
 		return nil, err
 	}
@@ -1194,18 +1242,59 @@ func (c *Client) Do(req *retryablehttp.Request, v any) (*Response, error) {
 
 	client := c.client
 
-	if cr := checkRetryFromContext(req.Context()); cr != nil {
+	var retries *retryState
+	if policy := c.retryPolicy(req); policy != nil {
+		check := c.client.CheckRetry
+		if cr := checkRetryFromContext(req.Context()); cr != nil {
+			check = cr
+		}
+		if c.retryPolicies != nil && c.retryPolicies.Budget != nil {
+			c.retryPolicies.Budget.deposit()
+		}
+
+		retries = &retryState{idempotent: isIdempotentRequest(req.Request)}
+		client = c.newRetryableHTTPClientWithRetryPolicy(policy, check, retries)
+	} else if cr := checkRetryFromContext(req.Context()); cr != nil {
 		// for avoid overwriting c.client. Use copy of c.client and apply checkRetry from request context
 		client = c.newRetryableHTTPClientWithRetryCheck(cr)
 	}
 
+	// Check if v is a bodyReader or writer, as streamed and raw downloads are
+	// never cached.
+	_, isReader := v.(*bodyReader)
+	_, isWriter := v.(io.Writer)
+
+	var (
+		cacheKey string
+		cached   *CachedResponse
+	)
+	if c.responseCache != nil && !isReader && !isWriter {
+		if cacheKey = responseCacheKey(req); cacheKey != "" {
+			if r, ok := c.responseCache.Get(cacheKey); ok && r.ETag != "" {
+				cached = r
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+		}
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
+		if retries != nil && retries.exhausted() {
+			err = retries.error(err)
+		}
 		return nil, err
 	}
 
-	// Check if v is a bodyReader before setting up the defer
-	_, isReader := v.(*bodyReader)
+	fromCache := false
+	if cacheKey != "" {
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			resp = replayCachedResponse(resp, cached)
+			fromCache = true
+		} else if resp, err = storeCachedResponse(c.responseCache, cacheKey, resp, c.responseCacheMaxBodySize); err != nil {
+			return nil, err
+		}
+	}
+
 	if !isReader {
 		defer func() {
 			io.Copy(io.Discard, resp.Body)
@@ -1219,8 +1308,13 @@ func (c *Client) Do(req *retryablehttp.Request, v any) (*Response, error) {
 	c.configureLimiterOnce.Do(func() { c.configureLimiter(req.Context(), resp.Header) })
 
 	response := newResponse(resp)
+	response.FromCache = fromCache
 
-	err = CheckResponse(resp)
+	// Partial content is only expected in response to Range requests, like
+	// the ones of downloads and job traces.
+	if resp.StatusCode != http.StatusPartialContent || req.Header.Get("Range") == "" {
+		err = CheckResponse(resp)
+	}
 	if err != nil {
 		// Even though there was an error, we still return the response
 		// in case the caller wants to inspect it further.
@@ -1229,6 +1323,9 @@ func (c *Client) Do(req *retryablehttp.Request, v any) (*Response, error) {
 			io.Copy(io.Discard, resp.Body)
 			resp.Body.Close()
 		}
+		if retries != nil && retries.exhausted() {
+			err = retries.error(err)
+		}
 		return response, err
 	}
 
@@ -1447,6 +1544,21 @@ func (s JobTokenAuthSource) Header(_ context.Context) (string, string, error) {
 	return JobTokenHeaderName, s.Token, nil
 }
 
+// DeployTokenAuthSource used as an AuthSource for deploy tokens. Deploy
+// tokens can only be used for the package registry, container registry and
+// repository endpoints which support them.
+type DeployTokenAuthSource struct {
+	Token string
+}
+
+func (DeployTokenAuthSource) Init(context.Context, *Client) error {
+	return nil
+}
+
+func (s DeployTokenAuthSource) Header(_ context.Context) (string, string, error) {
+	return DeployTokenHeaderName, s.Token, nil
+}
+
 // AccessTokenAuthSource used as an AuthSource for various access tokens, like Personal-, Project- and Group- Access Tokens.
 // Can be used for all tokens that authorize with the Private-Token header.
 type AccessTokenAuthSource struct {
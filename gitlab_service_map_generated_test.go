@@ -72,6 +72,7 @@ var serviceMap = map[any]any{
 	&GroupVariablesService{}:                   (*GroupVariablesServiceInterface)(nil),
 	&GroupWikisService{}:                       (*GroupWikisServiceInterface)(nil),
 	&GroupsService{}:                           (*GroupsServiceInterface)(nil),
+	&HelmChartsService{}:                       (*HelmChartsServiceInterface)(nil),
 	&ImportService{}:                           (*ImportServiceInterface)(nil),
 	&InstanceClustersService{}:                 (*InstanceClustersServiceInterface)(nil),
 	&InstanceVariablesService{}:                (*InstanceVariablesServiceInterface)(nil),
@@ -88,6 +89,7 @@ var serviceMap = map[any]any{
 	&LicenseService{}:                          (*LicenseServiceInterface)(nil),
 	&LicenseTemplatesService{}:                 (*LicenseTemplatesServiceInterface)(nil),
 	&MarkdownService{}:                         (*MarkdownServiceInterface)(nil),
+	&MavenPackagesService{}:                    (*MavenPackagesServiceInterface)(nil),
 	&MemberRolesService{}:                      (*MemberRolesServiceInterface)(nil),
 	&MergeRequestApprovalSettingsService{}:     (*MergeRequestApprovalSettingsServiceInterface)(nil),
 	&MergeRequestApprovalsService{}:            (*MergeRequestApprovalsServiceInterface)(nil),
@@ -97,6 +99,7 @@ var serviceMap = map[any]any{
 	&MetadataService{}:                         (*MetadataServiceInterface)(nil),
 	&MilestonesService{}:                       (*MilestonesServiceInterface)(nil),
 	&ModelRegistryService{}:                    (*ModelRegistryServiceInterface)(nil),
+	&NPMPackagesService{}:                      (*NPMPackagesServiceInterface)(nil),
 	&NamespacesService{}:                       (*NamespacesServiceInterface)(nil),
 	&NotesService{}:                            (*NotesServiceInterface)(nil),
 	&NotificationSettingsService{}:             (*NotificationSettingsServiceInterface)(nil),
@@ -130,6 +133,7 @@ var serviceMap = map[any]any{
 	&ProtectedEnvironmentsService{}:            (*ProtectedEnvironmentsServiceInterface)(nil),
 	&ProtectedPackagesService{}:                (*ProtectedPackagesServiceInterface)(nil),
 	&ProtectedTagsService{}:                    (*ProtectedTagsServiceInterface)(nil),
+	&PyPIPackagesService{}:                     (*PyPIPackagesServiceInterface)(nil),
 	&ReleaseLinksService{}:                     (*ReleaseLinksServiceInterface)(nil),
 	&ReleasesService{}:                         (*ReleasesServiceInterface)(nil),
 	&RepositoriesService{}:                     (*RepositoriesServiceInterface)(nil),
@@ -144,6 +148,7 @@ var serviceMap = map[any]any{
 	&RunnerControllerScopesService{}:           (*RunnerControllerScopesServiceInterface)(nil),
 	&RunnerControllerTokensService{}:           (*RunnerControllerTokensServiceInterface)(nil),
 	&RunnerControllersService{}:                (*RunnerControllersServiceInterface)(nil),
+	&RunnerJobsService{}:                       (*RunnerJobsServiceInterface)(nil),
 	&RunnersService{}:                          (*RunnersServiceInterface)(nil),
 	&SearchService{}:                           (*SearchServiceInterface)(nil),
 	&SecureFilesService{}:                      (*SecureFilesServiceInterface)(nil),
@@ -154,6 +159,7 @@ var serviceMap = map[any]any{
 	&SnippetsService{}:                         (*SnippetsServiceInterface)(nil),
 	&SystemHooksService{}:                      (*SystemHooksServiceInterface)(nil),
 	&TagsService{}:                             (*TagsServiceInterface)(nil),
+	&TerraformModulesService{}:                 (*TerraformModulesServiceInterface)(nil),
 	&TerraformStatesService{}:                  (*TerraformStatesServiceInterface)(nil),
 	&TodosService{}:                            (*TodosServiceInterface)(nil),
 	&TopicsService{}:                           (*TopicsServiceInterface)(nil),
@@ -50,6 +50,7 @@ var (
 	_ AuthSource = OAuthTokenSource{}
 	_ AuthSource = JobTokenAuthSource{}
 	_ AuthSource = AccessTokenAuthSource{}
+	_ AuthSource = DeployTokenAuthSource{}
 	_ AuthSource = (*PasswordCredentialsAuthSource)(nil)
 )
 
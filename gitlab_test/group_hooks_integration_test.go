@@ -5,7 +5,6 @@ package gitlab_test
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -51,7 +50,7 @@ func Test_GroupHooksAddGroupHook_Integration(t *testing.T) {
 
 	group := CreateTestGroup(t, client)
 
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	name := fmt.Sprintf("testhook%d", suffix)
 	description := fmt.Sprintf("Test Hook %d", suffix)
 	hookURL := fmt.Sprintf("https://example.com/%d", suffix)
@@ -121,7 +120,7 @@ func Test_GroupHooksEditGroupHook_Integration(t *testing.T) {
 
 	hook, err := CreateTestGroupHook(t, group.ID, client)
 	require.NoError(t, err, "Failed to create test hook")
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	name := fmt.Sprintf("testhook%d", suffix)
 	description := fmt.Sprintf("Test Hook %d", suffix)
 	hookURL := fmt.Sprintf("https://example.com/%d", suffix)
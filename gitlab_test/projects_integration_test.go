@@ -5,7 +5,6 @@ package gitlab_test
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,7 +86,7 @@ func Test_ProjectAddProjectHook(t *testing.T) {
 	client := SetupIntegrationClient(t)
 
 	project := CreateTestProject(t, client)
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	name := fmt.Sprintf("testhook%d", suffix)
 	description := fmt.Sprintf("Test Hook %d", suffix)
 	hookURL := fmt.Sprintf("https://example.com/%d", suffix)
@@ -117,7 +116,7 @@ func Test_ProjectEditProjectHook(t *testing.T) {
 	project := CreateTestProject(t, client)
 	hook, err := CreateTestProjectHook(t, project.ID, client)
 	require.NoError(t, err, "Failed to create test hook")
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	name := fmt.Sprintf("testhook%d", suffix)
 	description := fmt.Sprintf("Test Hook %d", suffix)
 	hookURL := fmt.Sprintf("https://example.com/%d", suffix)
@@ -167,7 +166,7 @@ func TestCreateProjectWithMergeRequestTitleRegex_Integration(t *testing.T) {
 	// GIVEN a GitLab client
 	client := SetupIntegrationClient(t)
 
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	projectName := fmt.Sprintf("test-project-%d", suffix)
 	mergeTitle := fmt.Sprintf("^ABC-.*%d", suffix)
 	mergeTitleDescription := fmt.Sprintf("Title must start with ABC- %d", suffix)
@@ -199,7 +198,7 @@ func TestEditMergeRequestTitleRegex_Integration(t *testing.T) {
 	client := SetupIntegrationClient(t)
 	project := CreateTestProject(t, client)
 
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	mergeTitle := fmt.Sprintf("^ABC-.*%d", suffix)
 	mergeTitleDescription := fmt.Sprintf("Title must start with ABC- %d", suffix)
 
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -238,7 +237,7 @@ func Test_UsersDeleteUser_Integration(t *testing.T) {
 	client := SetupIntegrationClient(t)
 
 	// Create a test user (without cleanup since we're testing deletion)
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	username := fmt.Sprintf("testuser%d", suffix)
 	email := fmt.Sprintf("testuser%d@example.com", suffix)
 	name := fmt.Sprintf("Test User %d", suffix)
@@ -362,7 +361,7 @@ func Test_UsersCreateServiceAccountUser_Integration(t *testing.T) {
 	SkipIfNotLicensed(t, client)
 
 	// WHEN the CreateServiceAccountUser function is called
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	name := fmt.Sprintf("TestSA%d", suffix)
 	username := fmt.Sprintf("serviceaccount%d", suffix)
 	email := fmt.Sprintf("serviceaccount%d@test.com", suffix)
@@ -397,7 +396,7 @@ func Test_UsersListServiceAccounts_Integration(t *testing.T) {
 	SkipIfNotLicensed(t, client)
 
 	// Create a service account first
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	name := fmt.Sprintf("Test Service Account %d", suffix)
 	username := fmt.Sprintf("serviceaccount%d", suffix)
 	email := fmt.Sprintf("serviceaccount%d@test.com", suffix)
@@ -4,14 +4,21 @@ package gitlab_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gitlabtesting "gitlab.com/gitlab-org/api/client-go/v2/testing"
 )
 
 // This file contains helper functions that are useful for
@@ -20,10 +27,21 @@ import (
 func SetupIntegrationClient(t *testing.T) *gitlab.Client {
 	t.Helper()
 
+	// Set up a cassette recorder if requested. In replay mode, no live
+	// instance or token is required.
+	var options []gitlab.ClientOptionFunc
+	rec := setupCassetteRecorder(t)
+	if rec != nil {
+		options = append(options, gitlab.WithInterceptor(rec.Interceptor()))
+	}
+
 	// Get the token from environment
 	token := os.Getenv("GITLAB_TOKEN")
 	if token == "" {
-		t.Skip("GITLAB_TOKEN environment variable not set")
+		if rec == nil || rec.Mode() != gitlabtesting.RecorderModeReplay {
+			t.Skip("GITLAB_TOKEN environment variable not set")
+		}
+		token = "replay-token"
 	}
 
 	// Get the baseUrl from environment. If it's not set, default
@@ -34,12 +52,78 @@ func SetupIntegrationClient(t *testing.T) *gitlab.Client {
 	}
 
 	// Return a client with the base URL and the token.
-	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(baseURL))
+	options = append(options, gitlab.WithBaseURL(baseURL))
+	client, err := gitlab.NewClient(token, options...)
 	require.NoError(t, err, "failed to create GitLab Client for BaseURL "+baseURL)
 
 	return client
 }
 
+// setupCassetteRecorder returns a recorder for the cassette of the current
+// test, depending on the GITLAB_CASSETTE_MODE environment variable:
+//   - "record" records all interactions with the live instance into
+//     testdata/cassettes/<test name>.json when the test finishes.
+//   - "replay" serves the interactions from that cassette. Tests without a
+//     cassette are skipped.
+//
+// If the variable is not set, nil is returned and the live instance is used.
+func setupCassetteRecorder(t *testing.T) *gitlabtesting.Recorder {
+	t.Helper()
+
+	var mode gitlabtesting.RecorderMode
+	switch m := os.Getenv("GITLAB_CASSETTE_MODE"); m {
+	case "":
+		return nil
+	case "record":
+		mode = gitlabtesting.RecorderModeRecord
+	case "replay":
+		mode = gitlabtesting.RecorderModeReplay
+	default:
+		t.Fatalf("invalid GITLAB_CASSETTE_MODE %q, must be one of record or replay", m)
+	}
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	path := filepath.Join("testdata", "cassettes", name+".json")
+
+	rec, err := gitlabtesting.NewRecorder(path, mode,
+		gitlabtesting.WithBodyScrubbers(gitlabtesting.ScrubJSONFields("token", "password")),
+	)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skipf("no cassette recorded at %s", path)
+	}
+	require.NoError(t, err, "failed to set up cassette recorder")
+
+	// Cleanups run in reverse order, so the resources deleted by the
+	// cleanups of the test itself are recorded before the cassette is saved.
+	t.Cleanup(func() {
+		require.NoError(t, rec.Save(), "failed to save cassette")
+	})
+
+	return rec
+}
+
+// testSuffixes counts the suffixes returned by testSuffix for every test.
+var testSuffixes sync.Map
+
+// testSuffix returns a number making the names of the resources created by a
+// test unique. Against a live instance, it is based on the current time.
+// When recording or replaying a cassette, it is derived from the test name
+// and the number of suffixes the test requested before, so replayed requests
+// match the recorded ones. Resources left behind by an aborted recording have
+// to be deleted before the test is recorded again.
+func testSuffix(t *testing.T) int64 {
+	t.Helper()
+
+	if os.Getenv("GITLAB_CASSETTE_MODE") == "" {
+		return time.Now().UnixNano()
+	}
+
+	count, _ := testSuffixes.LoadOrStore(t.Name(), new(atomic.Int64))
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s#%d", t.Name(), count.(*atomic.Int64).Add(1))
+	return int64(h.Sum64() >> 1)
+}
+
 // SkipIfNotLicensed skips the test if the GitLab instance doesn't have
 // a Premium or Ultimate license. This is required to ensure that integration
 // tests requiring licensed features don't fail on unlicensed instances.
@@ -89,7 +173,7 @@ func CreateTestUser(t *testing.T, client *gitlab.Client) (*gitlab.User, error) {
 	t.Helper()
 
 	// Generate random username and email
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 
 	username := fmt.Sprintf("testuser%d", suffix)
 	email := fmt.Sprintf("testuser%d@example.com", suffix)
@@ -125,7 +209,7 @@ func CreateTestUser(t *testing.T, client *gitlab.Client) (*gitlab.User, error) {
 func CreateTestProject(t *testing.T, client *gitlab.Client) *gitlab.Project {
 	t.Helper()
 
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	return CreateTestProjectWithOptions(t, client, &gitlab.CreateProjectOptions{
 		Name:       gitlab.Ptr(fmt.Sprintf("project%d", suffix)),
 		Visibility: gitlab.Ptr(gitlab.PublicVisibility),
@@ -157,7 +241,7 @@ func CreateTestProjectHook(t *testing.T, pid any, client *gitlab.Client) (*gitla
 	t.Helper()
 
 	// Generate random name
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	url := fmt.Sprintf("https://example.com/%d", suffix)
 
 	// Create the project hook
@@ -188,7 +272,7 @@ func CreateTestGroupHook(t *testing.T, gid any, client *gitlab.Client) (*gitlab.
 	t.Helper()
 
 	// Generate random name
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	url := fmt.Sprintf("https://example.com/%d", suffix)
 
 	// Create the group hook
@@ -218,7 +302,7 @@ func CreateTestGroupHook(t *testing.T, gid any, client *gitlab.Client) (*gitlab.
 func CreateTestGroup(t *testing.T, client *gitlab.Client) *gitlab.Group {
 	t.Helper()
 
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	return CreateTestGroupWithOptions(t, client, &gitlab.CreateGroupOptions{
 		Name:       gitlab.Ptr(fmt.Sprintf("testgroup%d", suffix)),
 		Path:       gitlab.Ptr(fmt.Sprintf("testgroup%d", suffix)),
@@ -250,7 +334,7 @@ func CreateTestGroupWithOptions(t *testing.T, client *gitlab.Client, opts *gitla
 func CreateTestEpic(t *testing.T, client *gitlab.Client, gid any) (*gitlab.Epic, error) {
 	t.Helper()
 
-	suffix := time.Now().UnixNano()
+	suffix := testSuffix(t)
 	return CreateTestEpicWithOptions(t, client, gid, &gitlab.CreateEpicOptions{
 		Title:       gitlab.Ptr(fmt.Sprintf("Test Epic %d", suffix)),
 		Description: gitlab.Ptr(fmt.Sprintf("Test epic created at %d", suffix)),
@@ -0,0 +1,167 @@
+package gitlaboauth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/oauth2"
+)
+
+// DeviceCodePromptFunc is a function type for presenting the device code to the user.
+//
+// This function is called once the device authorization was requested, and
+// should instruct the user to open the verification URI on any device with a
+// browser and to enter the user code there. The device flow then waits for the
+// user to approve the authorization.
+//
+// Parameters:
+//   - da: The device authorization response, containing the UserCode, the
+//     VerificationURI and the VerificationURIComplete, which includes the
+//     user code and can be used to render a QR code
+//
+// Returns:
+//   - error: An error if the device code could not be presented, which aborts the flow
+//
+// Example implementation printing the instructions to stderr:
+//
+//	promptFunc := func(da *oauth2.DeviceAuthResponse) error {
+//		_, err := fmt.Fprintf(os.Stderr, "Open %s and enter the code %s\n", da.VerificationURI, da.UserCode)
+//		return err
+//	}
+type DeviceCodePromptFunc func(da *oauth2.DeviceAuthResponse) error
+
+// PrintDeviceCode returns a DeviceCodePromptFunc that writes instructions for
+// completing the device authorization to w.
+//
+// Example usage:
+//
+//	flow := gitlaboauth2.NewDeviceFlow(config, gitlaboauth2.PrintDeviceCode(os.Stderr))
+func PrintDeviceCode(w io.Writer) DeviceCodePromptFunc {
+	return func(da *oauth2.DeviceAuthResponse) error {
+		_, err := fmt.Fprintf(w, "To authorize this device, open %s in a browser and enter the code: %s\n", da.VerificationURI, da.UserCode)
+		return err
+	}
+}
+
+// DeviceFlow handles the OAuth2 device authorization grant (RFC 8628) for GitLab authentication.
+//
+// The device flow doesn't require a browser or a local listener on the
+// machine requesting the token, which makes it suitable for headless hosts
+// and remote sessions. It:
+// - Requests a device code from the instance's /oauth/authorize_device endpoint
+// - Presents the user code and verification URI to the user
+// - Polls the token endpoint until the user approved or denied the authorization
+//
+// The OAuth2 application must have the device authorization grant enabled.
+//
+// GitLab docs:
+// https://docs.gitlab.com/api/oauth2/#device-authorization-grant-flow
+type DeviceFlow struct {
+	config *oauth2.Config
+	prompt DeviceCodePromptFunc
+}
+
+// NewDeviceFlow creates a new device flow for handling the OAuth2 device authorization grant.
+//
+// Parameters:
+//   - config: The OAuth2 configuration created with NewOAuth2Config
+//   - prompt: A function that presents the user code and verification URI to the user
+//
+// Returns:
+//   - *DeviceFlow: A configured device flow ready to obtain a token
+//
+// Example usage:
+//
+//	config := gitlaboauth2.NewOAuth2Config("", "client-id", "", []string{"read_user"})
+//	flow := gitlaboauth2.NewDeviceFlow(config, gitlaboauth2.PrintDeviceCode(os.Stderr))
+//
+//	token, err := flow.GetToken(context.Background())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	fmt.Printf("Access token: %s\n", token.AccessToken)
+func NewDeviceFlow(config *oauth2.Config, prompt DeviceCodePromptFunc) *DeviceFlow {
+	return &DeviceFlow{
+		config: config,
+		prompt: prompt,
+	}
+}
+
+// GetToken performs the complete device authorization flow and returns an access token.
+//
+// This method:
+// 1. Requests a device code and user code from GitLab
+// 2. Calls the prompt function with the user code and verification URI
+// 3. Polls the token endpoint at the interval requested by GitLab, waiting
+// while the authorization is pending and backing off when asked to slow down
+// 4. Returns the access token once the user approved the authorization
+//
+// The method will block until:
+// - The user approves the authorization and a token is obtained
+// - The user denies the authorization or the device code expires
+// - The context is canceled or times out
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//
+// Returns:
+//   - *oauth2.Token: The OAuth2 access token on successful authentication
+//   - error: An error if the authentication flow fails at any step
+func (f *DeviceFlow) GetToken(ctx context.Context) (*oauth2.Token, error) {
+	if f.prompt == nil {
+		return nil, errors.New("device flow requires a prompt function to present the user code")
+	}
+
+	da, err := f.config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+
+	if err := f.prompt(da); err != nil {
+		return nil, fmt.Errorf("failed to present device code: %w", err)
+	}
+
+	// DeviceAccessToken takes care of the polling as described in RFC 8628,
+	// including the authorization_pending and slow_down responses.
+	token, err := f.config.DeviceAccessToken(ctx, da)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain token: %w", err)
+	}
+
+	return token, nil
+}
+
+// DeviceAuthorizationFlow performs a complete OAuth2 device authorization flow for GitLab authentication.
+//
+// This function is the device flow counterpart of AuthorizationFlow, for
+// environments without a browser, like build hosts or SSH sessions. It is a
+// convenience wrapper around NewOAuth2Config and NewDeviceFlow.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - baseURL: The base URL of the GitLab instance. Use "" for GitLab.com,
+//     or provide the full URL for self-managed instances (e.g., "https://gitlab.example.com")
+//   - clientID: The OAuth2 client ID obtained from your GitLab application settings
+//   - scopes: A slice of OAuth2 scopes to request (e.g., []string{"read_user", "api"})
+//   - prompt: A function that presents the user code and verification URI to the user
+//
+// Returns:
+//   - *oauth2.Token: The OAuth2 access token on successful authentication
+//   - error: An error if the authentication flow fails at any step
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+//	defer cancel()
+//
+//	token, err := gitlaboauth2.DeviceAuthorizationFlow(ctx, "https://gitlab.company.com",
+//		"your-client-id", []string{"api"}, gitlaboauth2.PrintDeviceCode(os.Stderr))
+//	if err != nil {
+//		log.Fatalf("Authentication failed: %v", err)
+//	}
+func DeviceAuthorizationFlow(ctx context.Context, baseURL, clientID string, scopes []string, prompt DeviceCodePromptFunc) (*oauth2.Token, error) {
+	config := NewOAuth2Config(baseURL, clientID, "", scopes)
+	return NewDeviceFlow(config, prompt).GetToken(ctx)
+}
@@ -0,0 +1,143 @@
+package gitlaboauth2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newDeviceFlowTestServer(t *testing.T, tokenHandler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize_device", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "test-client-id", r.Form.Get("client_id"))
+		assert.Equal(t, "read_user api", r.Form.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"device_code": "device-code",
+			"user_code": "ABCD-EFGH",
+			"verification_uri": "https://gitlab.example.com/oauth/device",
+			"verification_uri_complete": "https://gitlab.example.com/oauth/device?user_code=ABCD-EFGH",
+			"expires_in": 300,
+			"interval": 1
+		}`)
+	})
+	mux.HandleFunc("/oauth/token", tokenHandler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestDeviceFlow_GetToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("polls until the authorization is approved", func(t *testing.T) {
+		t.Parallel()
+
+		var polls atomic.Int32
+		server := newDeviceFlowTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", r.Form.Get("grant_type"))
+			assert.Equal(t, "device-code", r.Form.Get("device_code"))
+
+			w.Header().Set("Content-Type", "application/json")
+			if polls.Add(1) == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error": "authorization_pending"}`)
+				return
+			}
+			fmt.Fprint(w, `{"access_token": "access-token", "refresh_token": "refresh-token", "token_type": "Bearer", "expires_in": 7200}`)
+		})
+
+		var prompted *oauth2.DeviceAuthResponse
+		config := NewOAuth2Config(server.URL, "test-client-id", "", []string{"read_user", "api"})
+		flow := NewDeviceFlow(config, func(da *oauth2.DeviceAuthResponse) error {
+			prompted = da
+			return nil
+		})
+
+		token, err := flow.GetToken(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "access-token", token.AccessToken)
+		assert.Equal(t, "refresh-token", token.RefreshToken)
+		assert.Equal(t, int32(2), polls.Load())
+
+		require.NotNil(t, prompted)
+		assert.Equal(t, "ABCD-EFGH", prompted.UserCode)
+		assert.Equal(t, "https://gitlab.example.com/oauth/device", prompted.VerificationURI)
+		assert.Equal(t, "https://gitlab.example.com/oauth/device?user_code=ABCD-EFGH", prompted.VerificationURIComplete)
+	})
+
+	t.Run("returns an error when the authorization is denied", func(t *testing.T) {
+		t.Parallel()
+
+		server := newDeviceFlowTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "access_denied", "error_description": "The resource owner denied the request."}`)
+		})
+
+		config := NewOAuth2Config(server.URL, "test-client-id", "", []string{"read_user", "api"})
+		flow := NewDeviceFlow(config, func(*oauth2.DeviceAuthResponse) error { return nil })
+
+		_, err := flow.GetToken(context.Background())
+
+		var retrieveErr *oauth2.RetrieveError
+		require.ErrorAs(t, err, &retrieveErr)
+		assert.Equal(t, "access_denied", retrieveErr.ErrorCode)
+	})
+
+	t.Run("aborts when the prompt fails", func(t *testing.T) {
+		t.Parallel()
+
+		server := newDeviceFlowTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("token endpoint must not be called")
+		})
+
+		expectedErr := errors.New("no terminal")
+		config := NewOAuth2Config(server.URL, "test-client-id", "", []string{"read_user", "api"})
+		flow := NewDeviceFlow(config, func(*oauth2.DeviceAuthResponse) error { return expectedErr })
+
+		_, err := flow.GetToken(context.Background())
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("requires a prompt function", func(t *testing.T) {
+		t.Parallel()
+
+		flow := NewDeviceFlow(NewOAuth2Config("", "test-client-id", "", nil), nil)
+
+		_, err := flow.GetToken(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestPrintDeviceCode(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := PrintDeviceCode(&buf)(&oauth2.DeviceAuthResponse{
+		UserCode:        "ABCD-EFGH",
+		VerificationURI: "https://gitlab.example.com/oauth/device",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "https://gitlab.example.com/oauth/device")
+	assert.Contains(t, buf.String(), "ABCD-EFGH")
+}
@@ -31,6 +31,7 @@ type (
 	GroupImportExportServiceInterface interface {
 		ScheduleExport(gid any, options ...RequestOptionFunc) (*Response, error)
 		ExportDownload(gid any, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+		StreamExportDownload(gid any, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
 		ImportFile(opt *GroupImportFileOptions, options ...RequestOptionFunc) (*Response, error)
 	}
 
@@ -74,6 +75,22 @@ func (s *GroupImportExportService) ExportDownload(gid any, options ...RequestOpt
 	return bytes.NewReader(buf.Bytes()), resp, nil
 }
 
+// StreamExportDownload streams the finished export without buffering it in
+// memory. Interrupted downloads are resumed using HTTP Range requests.
+//
+// The returned io.ReadCloser must be closed by the caller to avoid leaking the
+// underlying response body.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/group_import_export/#export-download
+func (s *GroupImportExportService) StreamExportDownload(gid any, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("groups/%s/export/download", GroupID{gid}),
+		nil,
+		options,
+	)
+}
+
 // GroupImportFileOptions represents the available ImportFile() options.
 //
 // GitLab API docs:
@@ -0,0 +1,131 @@
+package gitlab
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+type (
+	// HelmChartsServiceInterface defines all the API methods for the HelmChartsService
+	HelmChartsServiceInterface interface {
+		// UploadChart pushes a Helm chart package, as created by `helm
+		// package`, to a channel of a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/helm/#upload-a-chart
+		UploadChart(pid any, channel, fileName string, content io.Reader, options ...RequestOptionFunc) (*Response, error)
+
+		// GetIndex gets the index.yaml of a channel of a project, which lists
+		// all the charts in the channel.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/helm/#download-a-chart-index
+		GetIndex(pid any, channel string, options ...RequestOptionFunc) (*HelmIndex, *Response, error)
+
+		// DownloadChart downloads a Helm chart package of a channel of a
+		// project. The file is streamed from the server, so the caller must
+		// close the returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/helm/#download-a-chart
+		DownloadChart(pid any, channel, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+	}
+
+	// HelmChartsService handles communication with the Helm chart repository
+	// related methods of the GitLab API.
+	//
+	// GitLab API docs:
+	// https://docs.gitlab.com/api/packages/helm/
+	HelmChartsService struct {
+		client *Client
+	}
+)
+
+var _ HelmChartsServiceInterface = (*HelmChartsService)(nil)
+
+// HelmIndex represents the index.yaml of a Helm chart repository channel.
+//
+// Helm docs:
+// https://helm.sh/docs/topics/chart_repository/#the-index-file
+type HelmIndex struct {
+	APIVersion string                       `yaml:"apiVersion"`
+	Entries    map[string][]*HelmChartEntry `yaml:"entries"`
+	Generated  time.Time                    `yaml:"generated"`
+}
+
+// HelmChartEntry represents a version of a chart in a Helm chart repository
+// index.
+type HelmChartEntry struct {
+	APIVersion   string                 `yaml:"apiVersion"`
+	Name         string                 `yaml:"name"`
+	Version      string                 `yaml:"version"`
+	AppVersion   string                 `yaml:"appVersion"`
+	Description  string                 `yaml:"description"`
+	Type         string                 `yaml:"type"`
+	Home         string                 `yaml:"home"`
+	Icon         string                 `yaml:"icon"`
+	Keywords     []string               `yaml:"keywords"`
+	Sources      []string               `yaml:"sources"`
+	Maintainers  []*HelmChartMaintainer `yaml:"maintainers"`
+	Dependencies []*HelmChartDependency `yaml:"dependencies"`
+	Deprecated   bool                   `yaml:"deprecated"`
+	URLs         []string               `yaml:"urls"`
+	Created      time.Time              `yaml:"created"`
+	Digest       string                 `yaml:"digest"`
+}
+
+// HelmChartMaintainer represents a maintainer of a Helm chart.
+type HelmChartMaintainer struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+	URL   string `yaml:"url"`
+}
+
+// HelmChartDependency represents a dependency of a Helm chart.
+type HelmChartDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+	Condition  string `yaml:"condition"`
+	Alias      string `yaml:"alias"`
+}
+
+func (s *HelmChartsService) UploadChart(pid any, channel, fileName string, content io.Reader, options ...RequestOptionFunc) (*Response, error) {
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodPost),
+		withPath("projects/%s/packages/helm/api/%s/charts", ProjectID{pid}, channel),
+		withUpload(content, fileName, UploadChart),
+		withRequestOpts(options...),
+	)
+	return resp, err
+}
+
+func (s *HelmChartsService) GetIndex(pid any, channel string, options ...RequestOptionFunc) (*HelmIndex, *Response, error) {
+	buf, resp, err := do[bytes.Buffer](s.client,
+		withPath("projects/%s/packages/helm/%s/index.yaml", ProjectID{pid}, channel),
+		withRequestOpts(options...),
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	idx := new(HelmIndex)
+	if err := yaml.Unmarshal(buf.Bytes(), idx); err != nil {
+		return nil, resp, fmt.Errorf("parsing index.yaml: %w", err)
+	}
+
+	return idx, resp, nil
+}
+
+func (s *HelmChartsService) DownloadChart(pid any, channel, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/packages/helm/%s/charts/%s", ProjectID{pid}, channel, fileName),
+		nil,
+		options,
+	)
+}
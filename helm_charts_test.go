@@ -0,0 +1,111 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelmCharts_UploadChart(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/helm/api/stable/charts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		f, h, err := r.FormFile("chart")
+		require.NoError(t, err)
+		defer f.Close()
+		assert.Equal(t, "mychart-0.1.0.tgz", h.Filename)
+		b, _ := io.ReadAll(f)
+		assert.Equal(t, "chart", string(b))
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	resp, err := client.HelmCharts.UploadChart(1, "stable", "mychart-0.1.0.tgz", strings.NewReader("chart"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestHelmCharts_GetIndex(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/helm/stable/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `---
+apiVersion: v1
+entries:
+  mychart:
+  - name: mychart
+    type: application
+    version: 0.2.0
+    apiVersion: v2
+    appVersion: 1.17.0
+    description: A Helm chart for Kubernetes
+    created: '2024-01-02T03:04:05.000000Z'
+    digest: 8f9a3b
+    urls:
+    - charts/mychart-0.2.0.tgz
+  - name: mychart
+    version: 0.1.0
+    apiVersion: v2
+    maintainers:
+    - name: Jane
+      email: jane@example.com
+    dependencies:
+    - name: redis
+      version: 17.x.x
+      repository: https://charts.example.com
+    created: '2024-01-01T03:04:05.000000Z'
+    urls:
+    - charts/mychart-0.1.0.tgz
+generated: '2024-01-02T03:04:06Z'
+serverInfo:
+  contextPath: "/api/v4/projects/1/packages/helm"
+`)
+	})
+
+	idx, _, err := client.HelmCharts.GetIndex(1, "stable")
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1", idx.APIVersion)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), idx.Generated)
+	require.Len(t, idx.Entries["mychart"], 2)
+
+	latest := idx.Entries["mychart"][0]
+	assert.Equal(t, "0.2.0", latest.Version)
+	assert.Equal(t, "1.17.0", latest.AppVersion)
+	assert.Equal(t, "application", latest.Type)
+	assert.Equal(t, []string{"charts/mychart-0.2.0.tgz"}, latest.URLs)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), latest.Created)
+
+	older := idx.Entries["mychart"][1]
+	assert.Equal(t, []*HelmChartMaintainer{{Name: "Jane", Email: "jane@example.com"}}, older.Maintainers)
+	assert.Equal(t, []*HelmChartDependency{{Name: "redis", Version: "17.x.x", Repository: "https://charts.example.com"}}, older.Dependencies)
+}
+
+func TestHelmCharts_DownloadChart(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/helm/stable/charts/mychart-0.1.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "chart")
+	})
+
+	r, _, err := client.HelmCharts.DownloadChart(1, "stable", "mychart-0.1.0.tgz")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "chart", string(b))
+}
@@ -0,0 +1,283 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// RequestIDHeaderName is the header GitLab uses to return the correlation ID
+// of a request, which can be used to find the request in the instance logs.
+const RequestIDHeaderName = "X-Request-Id"
+
+// Instrumentation receives observability events for the API calls made by a
+// Client. It can be used to create tracing spans and record metrics, without
+// the client depending on a specific telemetry library. The otelgitlab module
+// provides an OpenTelemetry implementation.
+//
+// For every call to Client.Do, StartCall is called once. The returned
+// CallObserver is then notified of every HTTP attempt made for the call,
+// including retries, and of the end of the call.
+type Instrumentation interface {
+	// StartCall is called when an API call starts. The returned context is
+	// used for the rest of the call, so it can carry a span that the spans
+	// of the individual attempts become children of.
+	StartCall(ctx context.Context, info CallInfo) (context.Context, CallObserver)
+}
+
+// CallObserver observes a single API call started by Instrumentation.
+type CallObserver interface {
+	// StartAttempt is called before every HTTP attempt of the call. The
+	// attempt number starts at 1, and every retry increments it.
+	StartAttempt(ctx context.Context, attempt int) (context.Context, AttemptObserver)
+
+	// EndCall is called once the call is done.
+	EndCall(result CallResult)
+}
+
+// AttemptObserver observes a single HTTP attempt of an API call.
+type AttemptObserver interface {
+	// EndAttempt is called once the response headers of the attempt were
+	// received, or when the attempt failed.
+	EndAttempt(result AttemptResult)
+}
+
+// CallInfo describes an API call passed to Instrumentation.StartCall.
+type CallInfo struct {
+	// Method is the HTTP method of the call.
+	Method string
+
+	// Route is the templated path of the call relative to the API base URL,
+	// like "projects/:id/merge_requests/:iid". It doesn't contain any IDs or
+	// names, so it is suitable as span name or metric attribute.
+	Route string
+
+	// URL is the full URL of the call.
+	URL string
+}
+
+// CallResult describes the outcome of an API call.
+type CallResult struct {
+	// StatusCode is the HTTP status code of the last attempt, or 0 if no
+	// response was received.
+	StatusCode int
+
+	// RequestID is the X-Request-Id returned by GitLab for the last attempt.
+	RequestID string
+
+	// Duration is the total time of the call, including all attempts and
+	// the time spent waiting for the RateLimiter.
+	Duration time.Duration
+
+	// Retries is the number of attempts after the first one.
+	Retries int
+
+	// RateLimitWait is the time spent waiting for the RateLimiter before
+	// the request was sent.
+	RateLimitWait time.Duration
+
+	// Err is the error returned by Client.Do, if any.
+	Err error
+}
+
+// AttemptResult describes the outcome of a single HTTP attempt.
+type AttemptResult struct {
+	// StatusCode is the HTTP status code of the attempt, or 0 if no response
+	// was received.
+	StatusCode int
+
+	// RequestID is the X-Request-Id returned by GitLab.
+	RequestID string
+
+	// Duration is the time until the response headers were received.
+	Duration time.Duration
+
+	// Err is the transport error of the attempt, if any.
+	Err error
+}
+
+// instrumentedCall tracks the state of an instrumented API call. It is passed
+// through the request context so the transport can report the attempts.
+type instrumentedCall struct {
+	observer      CallObserver
+	attempts      atomic.Int32
+	rateLimitWait time.Duration
+}
+
+// instrumentedCallKey is the context key of an *instrumentedCall.
+type instrumentedCallKey struct{}
+
+// routeKey is the context key of the templated route of a request, as
+// derived from the path format passed to withPath.
+type routeKey struct{}
+
+// instrumentCall starts the instrumentation of an API call made with req. It
+// returns the context to use for the call and a function to end the call.
+func (c *Client) instrumentCall(req *retryablehttp.Request) (context.Context, func(*Response, error)) {
+	route := requestRoute(c, req)
+
+	start := time.Now()
+	ctx, observer := c.instrumentation.StartCall(req.Context(), CallInfo{
+		Method: req.Method,
+		Route:  route,
+		URL:    req.URL.String(),
+	})
+	call := &instrumentedCall{observer: observer}
+
+	return context.WithValue(ctx, instrumentedCallKey{}, call), func(resp *Response, err error) {
+		result := CallResult{
+			Duration:      time.Since(start),
+			RateLimitWait: call.rateLimitWait,
+			Err:           err,
+		}
+		if attempts := int(call.attempts.Load()); attempts > 1 {
+			result.Retries = attempts - 1
+		}
+		if resp != nil && resp.Response != nil {
+			result.StatusCode = resp.StatusCode
+			result.RequestID = resp.Header.Get(RequestIDHeaderName)
+		}
+		observer.EndCall(result)
+	}
+}
+
+// recordRateLimitWait records the time spent waiting for the RateLimiter on
+// the instrumented call in ctx, if any.
+func recordRateLimitWait(ctx context.Context, d time.Duration) {
+	if call, ok := ctx.Value(instrumentedCallKey{}).(*instrumentedCall); ok {
+		call.rateLimitWait = d
+	}
+}
+
+// requestRoute returns the templated route of req, as used for
+// instrumentation and retry policies.
+func requestRoute(c *Client, req *retryablehttp.Request) string {
+	if route, ok := req.Context().Value(routeKey{}).(string); ok {
+		return route
+	}
+	return routeFromPath(strings.TrimPrefix(req.URL.EscapedPath(), c.baseURL.Path))
+}
+
+// contextWithRoute returns a context carrying the templated route created
+// from a path format as passed to withPath.
+func contextWithRoute(ctx context.Context, format string) context.Context {
+	return context.WithValue(ctx, routeKey{}, routeFromFormat(format))
+}
+
+// instrumentationTransport reports every HTTP attempt of an instrumented call
+// to its CallObserver.
+type instrumentationTransport struct {
+	next http.RoundTripper
+}
+
+func (t *instrumentationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call, ok := req.Context().Value(instrumentedCallKey{}).(*instrumentedCall)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	attempt := int(call.attempts.Add(1))
+	ctx, observer := call.observer.StartAttempt(req.Context(), attempt)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+
+	result := AttemptResult{
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+		result.RequestID = resp.Header.Get(RequestIDHeaderName)
+	}
+	observer.EndAttempt(result)
+
+	return resp, err
+}
+
+// decorateHTTPClientTransportWithInstrumentation wraps the transport of the
+// HTTP client, so every attempt of an instrumented call is reported.
+func decorateHTTPClientTransportWithInstrumentation(c *Client) {
+	if c.instrumentation == nil {
+		return
+	}
+
+	next := c.client.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.client.HTTPClient.Transport = &instrumentationTransport{next: next}
+}
+
+// routeFormatVerbRegex matches the formatting verbs in a path format.
+var routeFormatVerbRegex = regexp.MustCompile(`%[-+# 0]*[0-9]*[a-zA-Z]`)
+
+// routeIIDResources are the resources that are addressed by their internal
+// ID within a project or group in the API paths.
+var routeIIDResources = map[string]bool{
+	"epics":          true,
+	"issues":         true,
+	"merge_requests": true,
+	"work_items":     true,
+}
+
+// routeIDResources are the resources whose path segment is always followed
+// by an ID or URL-encoded path.
+var routeIDResources = map[string]bool{
+	"groups":     true,
+	"namespaces": true,
+	"projects":   true,
+	"users":      true,
+}
+
+// routeFromFormat creates a templated route from a path format as passed to
+// withPath, by replacing every formatting verb with a placeholder.
+func routeFromFormat(format string) string {
+	segments := strings.Split(format, "/")
+	for i, s := range segments {
+		if !routeFormatVerbRegex.MatchString(s) {
+			continue
+		}
+		segments[i] = routeFormatVerbRegex.ReplaceAllString(s, routePlaceholder(segments, i))
+	}
+	return strings.Join(segments, "/")
+}
+
+// routeFromPath creates a templated route from an escaped path, for requests
+// that are not created with withPath. Numeric segments and the segments
+// following a resource that is addressed by a (path) ID are replaced with a
+// placeholder.
+func routeFromPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if s == "" {
+			continue
+		}
+		if isNumeric(s) || (i > 0 && routeIDResources[segments[i-1]]) {
+			segments[i] = routePlaceholder(segments, i)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// routePlaceholder returns the placeholder for the segment at index i.
+func routePlaceholder(segments []string, i int) string {
+	if i > 0 && routeIIDResources[segments[i-1]] {
+		return ":iid"
+	}
+	return ":id"
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,206 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInstrumentation struct {
+	mu    sync.Mutex
+	calls []*testCallObserver
+}
+
+func (i *testInstrumentation) StartCall(ctx context.Context, info CallInfo) (context.Context, CallObserver) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	o := &testCallObserver{info: info}
+	i.calls = append(i.calls, o)
+	return context.WithValue(ctx, testInstrumentationKey{}, info.Route), o
+}
+
+type testInstrumentationKey struct{}
+
+type testCallObserver struct {
+	mu       sync.Mutex
+	info     CallInfo
+	attempts []AttemptResult
+	parents  []string
+	result   *CallResult
+}
+
+func (o *testCallObserver) StartAttempt(ctx context.Context, attempt int) (context.Context, AttemptObserver) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	parent, _ := ctx.Value(testInstrumentationKey{}).(string)
+	o.parents = append(o.parents, fmt.Sprintf("%s#%d", parent, attempt))
+	return ctx, o
+}
+
+func (o *testCallObserver) EndAttempt(result AttemptResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.attempts = append(o.attempts, result)
+}
+
+func (o *testCallObserver) EndCall(result CallResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.result = &result
+}
+
+func TestInstrumentation(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group%2Fproject/merge_requests/5", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeaderName, fmt.Sprintf("request-%d", requests.Add(1)))
+		if requests.Load() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "iid": 5}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeaderName, "request-trace")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "404 Not found"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	instrumentation := &testInstrumentation{}
+	client, err := NewClient("",
+		WithBaseURL(server.URL),
+		WithInstrumentation(instrumentation),
+		WithCustomRetryWaitMinMax(0, 0),
+	)
+	require.NoError(t, err)
+
+	_, _, err = client.MergeRequests.GetMergeRequest("group/project", 5, nil)
+	require.NoError(t, err)
+
+	req, err := client.NewRequest(http.MethodGet, "projects/1/jobs/2/trace", nil, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req, nil)
+	require.Error(t, err)
+
+	require.Len(t, instrumentation.calls, 2)
+
+	mr := instrumentation.calls[0]
+	assert.Equal(t, http.MethodGet, mr.info.Method)
+	assert.Equal(t, "projects/:id/merge_requests/:iid", mr.info.Route)
+	assert.Equal(t, server.URL+"/api/v4/projects/group%2Fproject/merge_requests/5", mr.info.URL)
+	assert.Equal(t, []string{"projects/:id/merge_requests/:iid#1", "projects/:id/merge_requests/:iid#2"}, mr.parents)
+	require.Len(t, mr.attempts, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, mr.attempts[0].StatusCode)
+	assert.Equal(t, "request-1", mr.attempts[0].RequestID)
+	assert.Equal(t, http.StatusOK, mr.attempts[1].StatusCode)
+	assert.Equal(t, "request-2", mr.attempts[1].RequestID)
+	require.NotNil(t, mr.result)
+	assert.Equal(t, http.StatusOK, mr.result.StatusCode)
+	assert.Equal(t, "request-2", mr.result.RequestID)
+	assert.Equal(t, 1, mr.result.Retries)
+	assert.NoError(t, mr.result.Err)
+
+	trace := instrumentation.calls[1]
+	assert.Equal(t, "projects/:id/jobs/:id/trace", trace.info.Route)
+	require.NotNil(t, trace.result)
+	assert.Equal(t, http.StatusNotFound, trace.result.StatusCode)
+	assert.Equal(t, "request-trace", trace.result.RequestID)
+	assert.Equal(t, 0, trace.result.Retries)
+	assert.ErrorIs(t, trace.result.Err, ErrNotFound)
+}
+
+func TestInstrumentation_RateLimitWait(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/version", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version": "18.0.0"}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	instrumentation := &testInstrumentation{}
+	limiterErr := errors.New("limiter canceled")
+	client, err := NewClient("",
+		WithBaseURL(server.URL),
+		WithInstrumentation(instrumentation),
+		WithCustomLimiter(rateLimiterFunc(func(context.Context) error { return limiterErr })),
+	)
+	require.NoError(t, err)
+
+	_, _, err = client.Version.GetVersion()
+	require.ErrorIs(t, err, limiterErr)
+
+	require.Len(t, instrumentation.calls, 1)
+	call := instrumentation.calls[0]
+	assert.Equal(t, "version", call.info.Route)
+	assert.Empty(t, call.attempts)
+	require.NotNil(t, call.result)
+	assert.Equal(t, 0, call.result.StatusCode)
+	assert.ErrorIs(t, call.result.Err, limiterErr)
+}
+
+type rateLimiterFunc func(context.Context) error
+
+func (f rateLimiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestWithInstrumentation_Nil(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient("", WithInstrumentation(nil))
+	assert.Error(t, err)
+}
+
+func TestRouteFromFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"projects/%s/merge_requests/%d":             "projects/:id/merge_requests/:iid",
+		"projects/%s/merge_requests/%d/notes/%d":    "projects/:id/merge_requests/:iid/notes/:id",
+		"groups/%s/epics/%d":                        "groups/:id/epics/:iid",
+		"projects/%s/packages/helm/%s/index.yaml":   "projects/:id/packages/helm/:id/index.yaml",
+		"projects/%s/repository/files/%s/raw":       "projects/:id/repository/files/:id/raw",
+		"projects/%s/packages/generic/%s/%s/%s.zip": "projects/:id/packages/generic/:id/:id/:id.zip",
+		"version": "version",
+	}
+
+	for format, want := range tests {
+		assert.Equal(t, want, routeFromFormat(format), format)
+	}
+}
+
+func TestRouteFromPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"projects/group%2Fproject/merge_requests/5": "projects/:id/merge_requests/:iid",
+		"projects/1/jobs/2/trace":                   "projects/:id/jobs/:id/trace",
+		"users/jane/projects":                       "users/:id/projects",
+		"groups/1/issues/3/notes/4":                 "groups/:id/issues/:iid/notes/:id",
+		"projects":                                  "projects",
+	}
+
+	for path, want := range tests {
+		assert.Equal(t, want, routeFromPath(path), path)
+	}
+}
@@ -0,0 +1,295 @@
+package gitlab
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTracePollInterval is the default interval at which FollowTrace polls
+// for new trace output.
+const defaultTracePollInterval = 3 * time.Second
+
+// FollowTraceOptions represents the available FollowTrace() options.
+type FollowTraceOptions struct {
+	// PollInterval is the interval at which the trace is polled for new
+	// output. Defaults to 3 seconds.
+	PollInterval time.Duration
+	// Offset is the byte offset at which to start following the trace, e.g.
+	// to resume following a trace after a restart.
+	Offset int64
+}
+
+func (s *JobsService) FollowTrace(pid any, jobID int64, opt *FollowTraceOptions, options ...RequestOptionFunc) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		interval := defaultTracePollInterval
+		var offset int64
+		if opt != nil {
+			if opt.PollInterval > 0 {
+				interval = opt.PollInterval
+			}
+			offset = opt.Offset
+		}
+
+		ctx := s.client.requestContext(options)
+		for {
+			// Fetch the job status before the trace, so the last poll of a
+			// finished job is guaranteed to contain its complete trace.
+			job, _, err := s.GetJob(pid, jobID, options...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			chunk, err := s.traceFromOffset(pid, jobID, offset, options)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if len(chunk) > 0 {
+				offset += int64(len(chunk))
+				if !yield(chunk, nil) {
+					return
+				}
+			}
+
+			// A manual job doesn't write any output until it is started.
+			if isStoppedBuildState(job.Status) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+// traceFromOffset fetches the trace of a job starting at the given offset. It
+// uses a Range request, but also handles servers which ignore the range and
+// return the complete trace.
+func (s *JobsService) traceFromOffset(pid any, jobID int64, offset int64, options []RequestOptionFunc) ([]byte, error) {
+	if offset > 0 {
+		options = append(options[:len(options):len(options)], WithHeader("Range", fmt.Sprintf("bytes=%d-", offset)))
+	}
+
+	buf, resp, err := do[bytes.Buffer](s.client,
+		withPath("projects/%s/jobs/%d/trace", ProjectID{pid}, jobID),
+		withRequestOpts(options...),
+	)
+	if err != nil {
+		// The range starts at the end of the trace, so there is no new output.
+		if resp != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data := buf.Bytes()
+	if resp.StatusCode != http.StatusPartialContent {
+		if int64(len(data)) <= offset {
+			return nil, nil
+		}
+		data = data[offset:]
+	}
+
+	return data, nil
+}
+
+// isTerminalBuildState reports whether a job or pipeline with the given
+// status has finished running.
+func isTerminalBuildState(status string) bool {
+	switch BuildStateValue(status) {
+	case Success, Failed, Canceled, Skipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// TraceSection represents a collapsible section in a job trace, as created
+// with the section_start and section_end markers.
+//
+// GitLab docs:
+// https://docs.gitlab.com/ci/jobs/job_logs/#custom-collapsible-sections
+type TraceSection struct {
+	Name      string
+	Header    string
+	Collapsed bool
+	Options   map[string]string
+	Parent    *TraceSection
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// TraceLine represents a single line of a job trace.
+type TraceLine struct {
+	// Raw is the line as it was written to the trace, without the trailing
+	// line break.
+	Raw string
+	// Content is the line with ANSI escape sequences and section markers
+	// removed. Carriage returns are handled like a terminal would, so only
+	// the last overwritten part of the line remains.
+	Content string
+	// Section is the innermost section the line belongs to, or nil if the
+	// line doesn't belong to any section.
+	Section *TraceSection
+}
+
+var (
+	ansiEscapeRegexp    = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+	traceSectionRegexp  = regexp.MustCompile(`(?:\x1b\[0K)?section_(start|end):(\d+):([A-Za-z0-9_.-]+)(?:\[([^\]]*)\])?\r?(?:\x1b\[0K)?`)
+	errTraceLineTooLong = errors.New("trace line exceeds maximum length")
+)
+
+// maxTraceLineLength is the maximum length of a single trace line buffered by
+// TraceLines before it gives up.
+const maxTraceLineLength = 4 << 20
+
+// StripANSI removes ANSI escape sequences, like colors, from s.
+func StripANSI(s string) string {
+	return ansiEscapeRegexp.ReplaceAllString(s, "")
+}
+
+// TraceLines splits the trace output yielded by trace, for example by
+// FollowTrace, into lines and parses the section markers in it. Lines that
+// only consist of a section_end marker are not yielded.
+func TraceLines(trace iter.Seq2[[]byte, error]) iter.Seq2[*TraceLine, error] {
+	return func(yield func(*TraceLine, error) bool) {
+		p := &traceParser{}
+		var buf []byte
+
+		for chunk, err := range trace {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			buf = append(buf, chunk...)
+			for {
+				i := bytes.IndexByte(buf, '\n')
+				if i < 0 {
+					break
+				}
+				line := string(buf[:i])
+				buf = buf[i+1:]
+
+				if l := p.parseLine(line); l != nil && !yield(l, nil) {
+					return
+				}
+			}
+
+			if len(buf) > maxTraceLineLength {
+				yield(nil, errTraceLineTooLong)
+				return
+			}
+		}
+
+		if len(buf) > 0 {
+			if l := p.parseLine(string(buf)); l != nil {
+				yield(l, nil)
+			}
+		}
+	}
+}
+
+// ReadTraceLines is a convenience wrapper around TraceLines for traces that
+// are available as an io.Reader, for example from GetTraceFile.
+func ReadTraceLines(r io.Reader) iter.Seq2[*TraceLine, error] {
+	return TraceLines(func(yield func([]byte, error) bool) {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 && !yield(bytes.Clone(buf[:n]), nil) {
+				return
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	})
+}
+
+// traceParser keeps track of the open sections while parsing a trace.
+type traceParser struct {
+	current *TraceSection
+}
+
+func (p *traceParser) parseLine(raw string) *TraceLine {
+	raw = strings.TrimSuffix(raw, "\r")
+
+	markers := traceSectionRegexp.FindAllStringSubmatchIndex(raw, -1)
+	started := false
+	for _, m := range markers {
+		kind := raw[m[2]:m[3]]
+		ts, _ := strconv.ParseInt(raw[m[4]:m[5]], 10, 64)
+		name := raw[m[6]:m[7]]
+
+		switch kind {
+		case "start":
+			section := &TraceSection{
+				Name:      name,
+				Parent:    p.current,
+				StartedAt: time.Unix(ts, 0).UTC(),
+			}
+			if m[8] >= 0 {
+				section.Options = parseTraceSectionOptions(raw[m[8]:m[9]])
+				section.Collapsed = section.Options["collapsed"] == "true"
+			}
+			p.current = section
+			started = true
+		case "end":
+			for s := p.current; s != nil; s = s.Parent {
+				if s.Name == name {
+					s.EndedAt = time.Unix(ts, 0).UTC()
+					p.current = s.Parent
+					break
+				}
+			}
+		}
+	}
+
+	content := traceSectionRegexp.ReplaceAllString(raw, "")
+	if i := strings.LastIndexByte(content, '\r'); i >= 0 {
+		content = content[i+1:]
+	}
+	content = StripANSI(content)
+
+	if started {
+		p.current.Header = content
+	}
+	if len(markers) > 0 && !started && content == "" {
+		return nil
+	}
+
+	return &TraceLine{
+		Raw:     raw,
+		Content: content,
+		Section: p.current,
+	}
+}
+
+func parseTraceSectionOptions(s string) map[string]string {
+	options := make(map[string]string)
+	for opt := range strings.SplitSeq(s, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		if k != "" {
+			options[k] = v
+		}
+	}
+	return options
+}
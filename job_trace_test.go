@@ -0,0 +1,203 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowTrace(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	// Every poll of the job appends a line to the trace, until the job
+	// finished after the third poll.
+	var (
+		mu    sync.Mutex
+		polls int
+		trace string
+	)
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mu.Lock()
+		defer mu.Unlock()
+
+		polls++
+		trace += fmt.Sprintf("line %d\n", polls)
+		status := "running"
+		if polls == 3 {
+			status = "success"
+		}
+		fmt.Fprintf(w, `{"id": 2, "status": %q}`, status)
+	})
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Only honor the Range header on the second poll, to exercise both
+		// partial and full responses.
+		rng := r.Header.Get("Range")
+		if rng == "" || polls != 2 {
+			fmt.Fprint(w, trace)
+			return
+		}
+		start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		require.NoError(t, err)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(trace)-1, len(trace)))
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, trace[start:])
+	})
+
+	var chunks []string
+	for chunk, err := range client.Jobs.FollowTrace(1, 2, &FollowTraceOptions{PollInterval: time.Millisecond}) {
+		require.NoError(t, err)
+		chunks = append(chunks, string(chunk))
+	}
+
+	assert.Equal(t, []string{"line 1\n", "line 2\n", "line 3\n"}, chunks)
+}
+
+func TestFollowTrace_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 2, "status": "running"}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var traceRequests int
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		// There is no new output, so FollowTrace waits for the next poll.
+		assert.Equal(t, "bytes=10-", r.Header.Get("Range"))
+		traceRequests++
+		cancel()
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+	})
+
+	var errs []error
+	for _, err := range client.Jobs.FollowTrace(1, 2, &FollowTraceOptions{PollInterval: time.Hour, Offset: 10}, WithContext(ctx)) {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+	assert.Equal(t, 1, traceRequests)
+}
+
+func TestFollowTrace_Manual(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	var jobRequests int
+	mux.HandleFunc("/api/v4/projects/1/jobs/2", func(w http.ResponseWriter, r *http.Request) {
+		jobRequests++
+		fmt.Fprint(w, `{"id": 2, "status": "manual"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/trace", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+	})
+
+	var chunks int
+	for _, err := range client.Jobs.FollowTrace(1, 2, &FollowTraceOptions{PollInterval: time.Hour}) {
+		require.NoError(t, err)
+		chunks++
+	}
+
+	assert.Zero(t, chunks)
+	assert.Equal(t, 1, jobRequests)
+}
+
+func TestTraceLines(t *testing.T) {
+	t.Parallel()
+
+	trace := "Running with gitlab-runner 17.0.0\n" +
+		"\x1b[0Ksection_start:1560896352:prepare[collapsed=true]\r\x1b[0K\x1b[36;1mPreparing environment\x1b[0;m\n" +
+		"Running on runner-1\n" +
+		"\x1b[0Ksection_start:1560896353:fetch\r\x1b[0KFetching sources\n" +
+		"Downloading 10%\rDownloading 100%\r\n" +
+		"\x1b[0Ksection_end:1560896354:fetch\r\x1b[0K\n" +
+		"\x1b[0Ksection_end:1560896355:prepare\r\x1b[0K\n" +
+		"\x1b[32;1mJob succeeded\x1b[0;m"
+
+	// Split the trace in small chunks to make sure lines and markers
+	// spanning multiple chunks are handled.
+	chunks := func(yield func([]byte, error) bool) {
+		for i := 0; i < len(trace); i += 7 {
+			if !yield([]byte(trace[i:min(i+7, len(trace))]), nil) {
+				return
+			}
+		}
+	}
+
+	var lines []*TraceLine
+	for line, err := range TraceLines(chunks) {
+		require.NoError(t, err)
+		lines = append(lines, line)
+	}
+
+	require.Len(t, lines, 6)
+
+	var contents []string
+	for _, l := range lines {
+		contents = append(contents, l.Content)
+	}
+	assert.Equal(t, []string{
+		"Running with gitlab-runner 17.0.0",
+		"Preparing environment",
+		"Running on runner-1",
+		"Fetching sources",
+		"Downloading 100%",
+		"Job succeeded",
+	}, contents)
+
+	assert.Nil(t, lines[0].Section)
+	assert.Nil(t, lines[5].Section)
+
+	prepare := lines[1].Section
+	require.NotNil(t, prepare)
+	assert.Equal(t, "prepare", prepare.Name)
+	assert.Equal(t, "Preparing environment", prepare.Header)
+	assert.True(t, prepare.Collapsed)
+	assert.Equal(t, time.Unix(1560896352, 0).UTC(), prepare.StartedAt)
+	assert.Equal(t, time.Unix(1560896355, 0).UTC(), prepare.EndedAt)
+	assert.Same(t, prepare, lines[2].Section)
+
+	fetch := lines[3].Section
+	require.NotNil(t, fetch)
+	assert.Equal(t, "fetch", fetch.Name)
+	assert.Same(t, prepare, fetch.Parent)
+	assert.Same(t, fetch, lines[4].Section)
+	assert.Equal(t, time.Unix(1560896354, 0).UTC(), fetch.EndedAt)
+}
+
+func TestReadTraceLines(t *testing.T) {
+	t.Parallel()
+
+	var contents []string
+	for line, err := range ReadTraceLines(strings.NewReader("first\n\x1b[31msecond\x1b[0m\n")) {
+		require.NoError(t, err)
+		contents = append(contents, line.Content)
+	}
+
+	assert.Equal(t, []string{"first", "second"}, contents)
+}
+
+func TestStripANSI(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Job succeeded", StripANSI("\x1b[32;1mJob succeeded\x1b[0;m"))
+	assert.Equal(t, "link", StripANSI("\x1b]8;;https://gitlab.com\x07link\x1b]8;;\x07"))
+	assert.Equal(t, "plain", StripANSI("plain"))
+}
@@ -18,6 +18,8 @@ package gitlab
 
 import (
 	"bytes"
+	"io"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -53,17 +55,45 @@ type (
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/jobs/#get-a-single-job
 		GetJob(pid any, jobID int64, options ...RequestOptionFunc) (*Job, *Response, error)
+		// WaitForJob polls a job until it reaches a terminal status. If the job
+		// succeeded, it is returned. Otherwise a *JobFailedError is returned,
+		// or a *JobManualError for a manual job that was not started. Use
+		// WithContext to stop waiting early.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/jobs/#get-a-single-job
+		WaitForJob(pid any, jobID int64, opt *WaitForJobOptions, options ...RequestOptionFunc) (*Job, *Response, error)
 		// GetJobArtifacts gets jobs artifacts of a project
 		//
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/job_artifacts/#get-job-artifacts
 		GetJobArtifacts(pid any, jobID int64, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+		// StreamJobArtifacts streams the artifacts archive of a job without
+		// buffering it in memory. Interrupted downloads are resumed using
+		// HTTP Range requests.
+		//
+		// The returned io.ReadCloser must be closed by the caller to avoid
+		// leaking the underlying response body.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/job_artifacts/#get-job-artifacts
+		StreamJobArtifacts(pid any, jobID int64, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
 		// DownloadArtifactsFile downloads the artifacts file from the given
 		// reference name and job provided the job finished successfully.
 		//
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/job_artifacts/#download-the-artifacts-archive
 		DownloadArtifactsFile(pid any, refName string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+		// StreamArtifactsFile streams the artifacts archive from the given
+		// reference name and job without buffering it in memory. Interrupted
+		// downloads are resumed using HTTP Range requests.
+		//
+		// The returned io.ReadCloser must be closed by the caller to avoid
+		// leaking the underlying response body.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/job_artifacts/#download-the-artifacts-archive
+		StreamArtifactsFile(pid any, refName string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
 		// DownloadSingleArtifactsFile downloads a file from the artifacts from the
 		// given reference name and job provided the job finished successfully.
 		// Only a single file is going to be extracted from the archive and streamed
@@ -72,6 +102,16 @@ type (
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/job_artifacts/#download-a-single-artifact-file-by-job-id
 		DownloadSingleArtifactsFile(pid any, jobID int64, artifactPath string, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+		// StreamSingleArtifactsFile streams a single file from the artifacts
+		// of a job without buffering it in memory. Interrupted downloads are
+		// resumed using HTTP Range requests.
+		//
+		// The returned io.ReadCloser must be closed by the caller to avoid
+		// leaking the underlying response body.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/job_artifacts/#download-a-single-artifact-file-by-job-id
+		StreamSingleArtifactsFile(pid any, jobID int64, artifactPath string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
 		// DownloadSingleArtifactsFileByTagOrBranch downloads a single file from
 		// a job's artifacts in the latest successful pipeline using the reference name.
 		// The file is extracted from the archive and streamed to the client.
@@ -79,11 +119,51 @@ type (
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/job_artifacts/#download-a-single-artifact-file-from-specific-tag-or-branch
 		DownloadSingleArtifactsFileByTagOrBranch(pid any, refName string, artifactPath string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+		// StreamSingleArtifactsFileByTagOrBranch streams a single file from a
+		// job's artifacts in the latest successful pipeline using the reference
+		// name, without buffering it in memory. Interrupted downloads are
+		// resumed using HTTP Range requests.
+		//
+		// The returned io.ReadCloser must be closed by the caller to avoid
+		// leaking the underlying response body.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/job_artifacts/#download-a-single-artifact-file-from-specific-tag-or-branch
+		StreamSingleArtifactsFileByTagOrBranch(pid any, refName string, artifactPath string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
 		// GetTraceFile gets a trace of a specific job of a project
 		//
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/jobs/#get-a-log-file
 		GetTraceFile(pid any, jobID int64, options ...RequestOptionFunc) (*bytes.Reader, *Response, error)
+		// StreamTraceFile streams the trace of a specific job of a project
+		// without buffering it in memory. Interrupted downloads are resumed
+		// using HTTP Range requests.
+		//
+		// The returned io.ReadCloser must be closed by the caller to avoid
+		// leaking the underlying response body.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/jobs/#get-a-log-file
+		StreamTraceFile(pid any, jobID int64, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+		// FollowTrace follows the trace of a (running) job and yields the trace
+		// output as it is written. Each yielded chunk only contains the bytes
+		// that were not yielded before. The iterator stops once the job reached
+		// a terminal status and its complete trace has been yielded, when the
+		// job waits for a manual action, or when an error occurs. Use
+		// WithContext to stop following the trace early.
+		//
+		// Use TraceLines to split the output into lines and parse its sections.
+		//
+		//	for chunk, err := range client.Jobs.FollowTrace(pid, jobID, nil) {
+		//		if err != nil {
+		//			return err
+		//		}
+		//		os.Stdout.Write(chunk)
+		//	}
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/jobs/#get-a-log-file
+		FollowTrace(pid any, jobID int64, opt *FollowTraceOptions, options ...RequestOptionFunc) iter.Seq2[[]byte, error]
 		// CancelJob cancels a single job of a project.
 		//
 		// GitLab API docs:
@@ -302,6 +382,14 @@ func (s *JobsService) GetJobArtifacts(pid any, jobID int64, options ...RequestOp
 	return bytes.NewReader(b.Bytes()), resp, err
 }
 
+func (s *JobsService) StreamJobArtifacts(pid any, jobID int64, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/jobs/%d/artifacts", ProjectID{pid}, jobID),
+		nil,
+		options,
+	)
+}
+
 // DownloadArtifactsFileOptions represents the available DownloadArtifactsFile()
 // options.
 //
@@ -321,6 +409,14 @@ func (s *JobsService) DownloadArtifactsFile(pid any, refName string, opt *Downlo
 	return bytes.NewReader(b.Bytes()), resp, err
 }
 
+func (s *JobsService) StreamArtifactsFile(pid any, refName string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/jobs/artifacts/%s/download", ProjectID{pid}, NoEscape{refName}),
+		opt,
+		options,
+	)
+}
+
 func (s *JobsService) DownloadSingleArtifactsFile(pid any, jobID int64, artifactPath string, options ...RequestOptionFunc) (*bytes.Reader, *Response, error) {
 	b, resp, err := do[bytes.Buffer](s.client,
 		withPath("projects/%s/jobs/%d/artifacts/%s", ProjectID{pid}, jobID, NoEscape{artifactPath}),
@@ -330,6 +426,14 @@ func (s *JobsService) DownloadSingleArtifactsFile(pid any, jobID int64, artifact
 	return bytes.NewReader(b.Bytes()), resp, err
 }
 
+func (s *JobsService) StreamSingleArtifactsFile(pid any, jobID int64, artifactPath string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/jobs/%d/artifacts/%s", ProjectID{pid}, jobID, NoEscape{artifactPath}),
+		nil,
+		options,
+	)
+}
+
 func (s *JobsService) DownloadSingleArtifactsFileByTagOrBranch(pid any, refName string, artifactPath string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (*bytes.Reader, *Response, error) {
 	b, resp, err := do[bytes.Buffer](s.client,
 		withPath("projects/%s/jobs/artifacts/%s/raw/%s", ProjectID{pid}, refName, NoEscape{artifactPath}),
@@ -340,6 +444,14 @@ func (s *JobsService) DownloadSingleArtifactsFileByTagOrBranch(pid any, refName
 	return bytes.NewReader(b.Bytes()), resp, err
 }
 
+func (s *JobsService) StreamSingleArtifactsFileByTagOrBranch(pid any, refName string, artifactPath string, opt *DownloadArtifactsFileOptions, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/jobs/artifacts/%s/raw/%s", ProjectID{pid}, refName, NoEscape{artifactPath}),
+		opt,
+		options,
+	)
+}
+
 func (s *JobsService) GetTraceFile(pid any, jobID int64, options ...RequestOptionFunc) (*bytes.Reader, *Response, error) {
 	b, resp, err := do[bytes.Buffer](s.client,
 		withPath("projects/%s/jobs/%d/trace", ProjectID{pid}, jobID),
@@ -349,6 +461,14 @@ func (s *JobsService) GetTraceFile(pid any, jobID int64, options ...RequestOptio
 	return bytes.NewReader(b.Bytes()), resp, err
 }
 
+func (s *JobsService) StreamTraceFile(pid any, jobID int64, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/jobs/%d/trace", ProjectID{pid}, jobID),
+		nil,
+		options,
+	)
+}
+
 func (s *JobsService) CancelJob(pid any, jobID int64, options ...RequestOptionFunc) (*Job, *Response, error) {
 	return do[*Job](s.client,
 		withMethod(http.MethodPost),
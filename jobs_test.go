@@ -216,3 +216,57 @@ func TestDownloadSingleArtifactsFileByTagOrBranch(t *testing.T) {
 	assert.Equal(t, wantContent, content)
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestStreamTraceFile(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/9/jobs/42/trace", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "Running with gitlab-runner")
+	})
+
+	reader, resp, err := client.Jobs.StreamTraceFile(9, 42)
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "Running with gitlab-runner", string(content))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestStreamArtifactsFile(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/9/jobs/artifacts/abranch/download", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		testParam(t, r, "job", "publish")
+		fmt.Fprint(w, "This is the archive content")
+	})
+
+	opt := &DownloadArtifactsFileOptions{Job: Ptr("publish")}
+	reader, _, err := client.Jobs.StreamArtifactsFile(9, "abranch", opt)
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "This is the archive content", string(content))
+}
+
+func TestStreamSingleArtifactsFile_NotFound(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/9/jobs/42/artifacts/foo/bar.pdf", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	reader, resp, err := client.Jobs.StreamSingleArtifactsFile(9, 42, "foo/bar.pdf")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, reader)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,238 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type (
+	// MavenPackagesServiceInterface defines all the API methods for the MavenPackagesService
+	MavenPackagesServiceInterface interface {
+		// UploadPackageFile uploads a file to a Maven package of a project.
+		// The packagePath is the path of the package in the repository layout,
+		// see MavenPackagePath.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#upload-a-package-file
+		UploadPackageFile(pid any, packagePath, fileName string, content io.Reader, options ...RequestOptionFunc) (*Response, error)
+
+		// DownloadPackageFile downloads a Maven package file of a project. The
+		// file is streamed from the server, so the caller must close the
+		// returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#download-a-package-file-at-the-project-level
+		DownloadPackageFile(pid any, packagePath, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+
+		// DownloadGroupPackageFile downloads a Maven package file of any
+		// project in a group. The file is streamed from the server, so the
+		// caller must close the returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#download-a-package-file-at-the-group-level
+		DownloadGroupPackageFile(gid any, packagePath, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+
+		// DownloadInstancePackageFile downloads a Maven package file of any
+		// project on the instance. The file is streamed from the server, so the
+		// caller must close the returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#download-a-package-file-at-the-instance-level
+		DownloadInstancePackageFile(packagePath, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+
+		// GetPackageMetadata gets the maven-metadata.xml of a Maven package of
+		// a project. The packagePath is either the path of the artifact, to get
+		// the available versions, or the path of a snapshot version, to get its
+		// snapshot builds.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#download-a-package-file-at-the-project-level
+		GetPackageMetadata(pid any, packagePath string, options ...RequestOptionFunc) (*MavenMetadata, *Response, error)
+
+		// GetGroupPackageMetadata gets the maven-metadata.xml of a Maven
+		// package of any project in a group.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#download-a-package-file-at-the-group-level
+		GetGroupPackageMetadata(gid any, packagePath string, options ...RequestOptionFunc) (*MavenMetadata, *Response, error)
+
+		// GetInstancePackageMetadata gets the maven-metadata.xml of a Maven
+		// package of any project on the instance.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/maven/#download-a-package-file-at-the-instance-level
+		GetInstancePackageMetadata(packagePath string, options ...RequestOptionFunc) (*MavenMetadata, *Response, error)
+	}
+
+	// MavenPackagesService handles communication with the Maven package
+	// registry related methods of the GitLab API.
+	//
+	// GitLab API docs:
+	// https://docs.gitlab.com/api/packages/maven/
+	MavenPackagesService struct {
+		client *Client
+	}
+)
+
+var _ MavenPackagesServiceInterface = (*MavenPackagesService)(nil)
+
+// MavenMetadataFileName is the name of the metadata file of Maven packages.
+const MavenMetadataFileName = "maven-metadata.xml"
+
+// MavenMetadata represents the maven-metadata.xml of a Maven package.
+//
+// Maven docs:
+// https://maven.apache.org/repositories/metadata.html
+type MavenMetadata struct {
+	XMLName    xml.Name                 `xml:"metadata"`
+	GroupID    string                   `xml:"groupId"`
+	ArtifactID string                   `xml:"artifactId"`
+	Version    string                   `xml:"version,omitempty"`
+	Versioning *MavenMetadataVersioning `xml:"versioning"`
+}
+
+// MavenMetadataVersioning represents the versioning information of a Maven
+// package.
+type MavenMetadataVersioning struct {
+	Latest           string                          `xml:"latest,omitempty"`
+	Release          string                          `xml:"release,omitempty"`
+	Versions         []string                        `xml:"versions>version"`
+	LastUpdated      string                          `xml:"lastUpdated,omitempty"`
+	Snapshot         *MavenMetadataSnapshot          `xml:"snapshot"`
+	SnapshotVersions []*MavenMetadataSnapshotVersion `xml:"snapshotVersions>snapshotVersion"`
+}
+
+// MavenMetadataSnapshot represents the latest snapshot build of a Maven
+// snapshot version.
+type MavenMetadataSnapshot struct {
+	Timestamp   string `xml:"timestamp,omitempty"`
+	BuildNumber int64  `xml:"buildNumber,omitempty"`
+	LocalCopy   bool   `xml:"localCopy,omitempty"`
+}
+
+// MavenMetadataSnapshotVersion represents a file of a snapshot build of a
+// Maven snapshot version.
+type MavenMetadataSnapshotVersion struct {
+	Classifier string `xml:"classifier,omitempty"`
+	Extension  string `xml:"extension"`
+	Value      string `xml:"value"`
+	Updated    string `xml:"updated"`
+}
+
+// MavenPackagePath returns the path of a Maven package in the repository
+// layout, which is the group ID with dots replaced by slashes, followed by
+// the artifact ID and the version. If version is empty, the path of the
+// artifact is returned, which holds the metadata of all its versions.
+func MavenPackagePath(groupID, artifactID, version string) string {
+	p := strings.ReplaceAll(groupID, ".", "/") + "/" + artifactID
+	if version != "" {
+		p += "/" + version
+	}
+	return p
+}
+
+func (s *MavenPackagesService) UploadPackageFile(pid any, packagePath, fileName string, content io.Reader, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf(
+		"projects/%s/packages/maven/%s/%s",
+		PathEscape(project),
+		escapePackagePath(packagePath),
+		PathEscape(fileName),
+	)
+
+	// We need to create the request as a GET request to make sure the options
+	// are set correctly. After the request is created we will overwrite both
+	// the method and the body.
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Overwrite the method and body.
+	req.Method = http.MethodPut
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if err := req.SetBody(content); err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *MavenPackagesService) DownloadPackageFile(pid any, packagePath, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/packages/maven/%s/%s", ProjectID{pid}, NoEscape{escapePackagePath(packagePath)}, fileName),
+		nil,
+		options,
+	)
+}
+
+func (s *MavenPackagesService) DownloadGroupPackageFile(gid any, packagePath, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("groups/%s/-/packages/maven/%s/%s", GroupID{gid}, NoEscape{escapePackagePath(packagePath)}, fileName),
+		nil,
+		options,
+	)
+}
+
+func (s *MavenPackagesService) DownloadInstancePackageFile(packagePath, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("packages/maven/%s/%s", NoEscape{escapePackagePath(packagePath)}, fileName),
+		nil,
+		options,
+	)
+}
+
+func (s *MavenPackagesService) GetPackageMetadata(pid any, packagePath string, options ...RequestOptionFunc) (*MavenMetadata, *Response, error) {
+	return getMavenMetadata(s.client,
+		withPath("projects/%s/packages/maven/%s/%s", ProjectID{pid}, NoEscape{escapePackagePath(packagePath)}, MavenMetadataFileName),
+		options,
+	)
+}
+
+func (s *MavenPackagesService) GetGroupPackageMetadata(gid any, packagePath string, options ...RequestOptionFunc) (*MavenMetadata, *Response, error) {
+	return getMavenMetadata(s.client,
+		withPath("groups/%s/-/packages/maven/%s/%s", GroupID{gid}, NoEscape{escapePackagePath(packagePath)}, MavenMetadataFileName),
+		options,
+	)
+}
+
+func (s *MavenPackagesService) GetInstancePackageMetadata(packagePath string, options ...RequestOptionFunc) (*MavenMetadata, *Response, error) {
+	return getMavenMetadata(s.client,
+		withPath("packages/maven/%s/%s", NoEscape{escapePackagePath(packagePath)}, MavenMetadataFileName),
+		options,
+	)
+}
+
+func getMavenMetadata(client *Client, path doOption, options []RequestOptionFunc) (*MavenMetadata, *Response, error) {
+	buf, resp, err := do[bytes.Buffer](client,
+		path,
+		withRequestOpts(options...),
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	m := new(MavenMetadata)
+	if err := xml.Unmarshal(buf.Bytes(), m); err != nil {
+		return nil, resp, fmt.Errorf("parsing %s: %w", MavenMetadataFileName, err)
+	}
+
+	return m, resp, nil
+}
+
+// escapePackagePath escapes the segments of a slash separated package path,
+// while keeping the slashes themselves.
+func escapePackagePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
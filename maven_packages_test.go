@@ -0,0 +1,141 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMavenPackagePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "com/example/my-app", MavenPackagePath("com.example", "my-app", ""))
+	assert.Equal(t, "com/example/my-app/1.0-SNAPSHOT", MavenPackagePath("com.example", "my-app", "1.0-SNAPSHOT"))
+}
+
+func TestMavenPackages_UploadPackageFile(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/maven/com/example/my-app/1.0/my-app-1.0.jar", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		assert.Equal(t, "/api/v4/projects/1/packages/maven/com/example/my-app/1%2E0/my-app-1%2E0%2Ejar", r.URL.EscapedPath())
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+		assert.Equal(t, "deploy-token", r.Header.Get(DeployTokenHeaderName))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "jar content", string(body))
+	})
+
+	resp, err := client.MavenPackages.UploadPackageFile(1, MavenPackagePath("com.example", "my-app", "1.0"), "my-app-1.0.jar",
+		strings.NewReader("jar content"),
+		WithHeader(DeployTokenHeaderName, "deploy-token"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestMavenPackages_DownloadPackageFile(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/maven/com/example/my-app/1.0/my-app-1.0.pom", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "<project/>")
+	})
+	mux.HandleFunc("/api/v4/groups/2/-/packages/maven/com/example/my-app/1.0/my-app-1.0.pom", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<project>group</project>")
+	})
+	mux.HandleFunc("/api/v4/packages/maven/com/example/my-app/1.0/my-app-1.0.pom", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<project>instance</project>")
+	})
+
+	read := func(r io.ReadCloser, _ *Response, err error) string {
+		t.Helper()
+		require.NoError(t, err)
+		defer r.Close()
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	path := MavenPackagePath("com.example", "my-app", "1.0")
+	assert.Equal(t, "<project/>", read(client.MavenPackages.DownloadPackageFile(1, path, "my-app-1.0.pom")))
+	assert.Equal(t, "<project>group</project>", read(client.MavenPackages.DownloadGroupPackageFile(2, path, "my-app-1.0.pom")))
+	assert.Equal(t, "<project>instance</project>", read(client.MavenPackages.DownloadInstancePackageFile(path, "my-app-1.0.pom")))
+}
+
+func TestMavenPackages_GetPackageMetadata(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/maven/com/example/my-app/maven-metadata.xml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<metadata>
+  <groupId>com.example</groupId>
+  <artifactId>my-app</artifactId>
+  <versioning>
+    <latest>1.1-SNAPSHOT</latest>
+    <release>1.0</release>
+    <versions>
+      <version>1.0</version>
+      <version>1.1-SNAPSHOT</version>
+    </versions>
+    <lastUpdated>20240102030405</lastUpdated>
+  </versioning>
+</metadata>`)
+	})
+
+	m, _, err := client.MavenPackages.GetPackageMetadata(1, MavenPackagePath("com.example", "my-app", ""))
+	require.NoError(t, err)
+
+	assert.Equal(t, "com.example", m.GroupID)
+	assert.Equal(t, "my-app", m.ArtifactID)
+	require.NotNil(t, m.Versioning)
+	assert.Equal(t, "1.1-SNAPSHOT", m.Versioning.Latest)
+	assert.Equal(t, "1.0", m.Versioning.Release)
+	assert.Equal(t, []string{"1.0", "1.1-SNAPSHOT"}, m.Versioning.Versions)
+	assert.Equal(t, "20240102030405", m.Versioning.LastUpdated)
+}
+
+func TestMavenPackages_GetPackageMetadata_Snapshot(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/groups/2/-/packages/maven/com/example/my-app/1.1-SNAPSHOT/maven-metadata.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<metadata>
+  <groupId>com.example</groupId>
+  <artifactId>my-app</artifactId>
+  <version>1.1-SNAPSHOT</version>
+  <versioning>
+    <snapshot>
+      <timestamp>20240102.030405</timestamp>
+      <buildNumber>3</buildNumber>
+    </snapshot>
+    <snapshotVersions>
+      <snapshotVersion>
+        <extension>jar</extension>
+        <value>1.1-20240102.030405-3</value>
+        <updated>20240102030405</updated>
+      </snapshotVersion>
+    </snapshotVersions>
+  </versioning>
+</metadata>`)
+	})
+
+	m, _, err := client.MavenPackages.GetGroupPackageMetadata(2, "com/example/my-app/1.1-SNAPSHOT")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.1-SNAPSHOT", m.Version)
+	require.NotNil(t, m.Versioning.Snapshot)
+	assert.Equal(t, int64(3), m.Versioning.Snapshot.BuildNumber)
+	require.Len(t, m.Versioning.SnapshotVersions, 1)
+	assert.Equal(t, "1.1-20240102.030405-3", m.Versioning.SnapshotVersions[0].Value)
+}
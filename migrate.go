@@ -0,0 +1,333 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MigrationStage is the stage of a migration reported by MigrationProgress.
+type MigrationStage string
+
+// The available migration stages.
+const (
+	MigrationExporting   MigrationStage = "exporting"
+	MigrationDownloading MigrationStage = "downloading"
+	MigrationImporting   MigrationStage = "importing"
+	MigrationFinished    MigrationStage = "finished"
+)
+
+// MigrationProgress describes the progress of a migration.
+type MigrationProgress struct {
+	Stage MigrationStage
+
+	// Status is the export or import status reported by GitLab, if any.
+	Status string
+
+	// Bytes is the size of the downloaded archive, once the download
+	// finished.
+	Bytes int64
+}
+
+// MigratorOptions represents the available NewMigrator() options.
+type MigratorOptions struct {
+	// PollInterval and MaxPollInterval control how export and import
+	// statuses are polled, see WaitForExportOptions.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+
+	// OnProgress is called whenever a migration makes progress.
+	OnProgress func(MigrationProgress)
+
+	// TempDir is the directory of the archives of MigrateProject and
+	// MigrateGroup. Defaults to os.TempDir.
+	TempDir string
+}
+
+// Migrator moves projects and groups from one GitLab instance to another
+// using file based exports and imports.
+//
+// The request options passed to its methods, like WithContext, are used for
+// the requests to both instances.
+type Migrator struct {
+	source *Client
+	target *Client
+	opt    MigratorOptions
+}
+
+// NewMigrator returns a Migrator exporting from the source client and
+// importing with the target client. The options can be nil to use the
+// defaults.
+func NewMigrator(source, target *Client, opt *MigratorOptions) *Migrator {
+	m := &Migrator{source: source, target: target}
+	if opt != nil {
+		m.opt = *opt
+	}
+	return m
+}
+
+func (m *Migrator) progress(p MigrationProgress) {
+	if m.opt.OnProgress != nil {
+		m.opt.OnProgress(p)
+	}
+}
+
+// ExportProject schedules an export of a project on the source instance,
+// waits for it to finish and streams the archive to w. It returns the number
+// of bytes written.
+func (m *Migrator) ExportProject(pid any, w io.Writer, opt *ScheduleExportOptions, options ...RequestOptionFunc) (int64, error) {
+	s := m.source.ProjectImportExport
+
+	if _, err := s.ScheduleExport(pid, opt, options...); err != nil {
+		return 0, fmt.Errorf("scheduling export: %w", err)
+	}
+
+	_, _, err := s.WaitForExport(pid, &WaitForExportOptions{
+		PollInterval:    m.opt.PollInterval,
+		MaxPollInterval: m.opt.MaxPollInterval,
+		OnStatusChange: func(e *ExportStatus) {
+			m.progress(MigrationProgress{Stage: MigrationExporting, Status: e.ExportStatus})
+		},
+	}, options...)
+	if err != nil {
+		return 0, err
+	}
+
+	m.progress(MigrationProgress{Stage: MigrationDownloading})
+	body, _, err := s.StreamExportDownload(pid, options...)
+	if err != nil {
+		return 0, fmt.Errorf("downloading export: %w", err)
+	}
+	defer body.Close()
+
+	return m.copyDownload(w, body)
+}
+
+// ExportGroup schedules an export of a group on the source instance, waits
+// for it to finish and streams the archive to w. It returns the number of
+// bytes written.
+//
+// The API doesn't report the status of group exports, so the download is
+// retried until the export is available. An archive of a previous export,
+// modified before the export was scheduled according to its Last-Modified
+// header, is skipped.
+func (m *Migrator) ExportGroup(gid any, w io.Writer, options ...RequestOptionFunc) (int64, error) {
+	s := m.source.GroupImportExport
+
+	resp, err := s.ScheduleExport(gid, options...)
+	if err != nil {
+		return 0, fmt.Errorf("scheduling export: %w", err)
+	}
+	scheduledAt := serverTime(resp)
+
+	m.progress(MigrationProgress{Stage: MigrationExporting})
+	body, _, err := pollUntil(m.source.requestContext(options), m.opt.PollInterval, m.opt.MaxPollInterval, func() (io.ReadCloser, *Response, bool, error) {
+		body, resp, err := s.StreamExportDownload(gid, options...)
+		if errors.Is(err, ErrNotFound) {
+			return nil, resp, false, nil
+		}
+		if err != nil {
+			return nil, resp, false, err
+		}
+		if isStaleExport(resp, scheduledAt) {
+			body.Close()
+			return nil, resp, false, nil
+		}
+		return body, resp, false, nil
+	}, func(body io.ReadCloser) bool {
+		return body != nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("downloading export: %w", err)
+	}
+	defer body.Close()
+
+	m.progress(MigrationProgress{Stage: MigrationDownloading})
+	return m.copyDownload(w, body)
+}
+
+// serverTime returns the time of the server that sent resp, so it can be
+// compared to other times of the server regardless of clock skew. It falls
+// back to the local time if resp has no Date header.
+func serverTime(resp *Response) time.Time {
+	if resp != nil {
+		if t, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// isStaleExport reports whether the export archive downloaded with resp was
+// modified before the export was scheduled. Archives without a Last-Modified
+// header can't be checked and are assumed to be current.
+func isStaleExport(resp *Response, scheduledAt time.Time) bool {
+	modified, err := http.ParseTime(resp.Header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	// Both headers have a resolution of one second.
+	return modified.Before(scheduledAt.Truncate(time.Second))
+}
+
+func (m *Migrator) copyDownload(w io.Writer, body io.Reader) (int64, error) {
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("downloading export: %w", err)
+	}
+	m.progress(MigrationProgress{Stage: MigrationDownloading, Status: "finished", Bytes: n})
+	return n, nil
+}
+
+// ImportSummary summarizes a finished project import.
+type ImportSummary struct {
+	Status *ImportStatus
+
+	// FailedRelations counts the relations which failed to import by their
+	// name, like "issues" or "merge_requests". The details are available in
+	// Status.FailedRelations.
+	FailedRelations map[string]int
+}
+
+// newImportSummary returns the summary of a finished import.
+func newImportSummary(status *ImportStatus) *ImportSummary {
+	s := &ImportSummary{Status: status, FailedRelations: make(map[string]int)}
+	for _, r := range status.FailedRelations {
+		s.FailedRelations[r.RelationName]++
+	}
+	return s
+}
+
+func (s *ImportSummary) String() string {
+	msg := fmt.Sprintf("imported project %s", s.Status.PathWithNamespace)
+	if len(s.FailedRelations) == 0 {
+		return msg
+	}
+
+	failed := make([]string, 0, len(s.FailedRelations))
+	for _, name := range slices.Sorted(maps.Keys(s.FailedRelations)) {
+		failed = append(failed, fmt.Sprintf("%s (%d)", name, s.FailedRelations[name]))
+	}
+	return fmt.Sprintf("%s with %d failed relation(s): %s", msg, len(s.Status.FailedRelations), strings.Join(failed, ", "))
+}
+
+// ImportProject imports a project archive on the target instance and waits
+// for the import to finish. If the import failed, an *ImportFailedError is
+// returned.
+func (m *Migrator) ImportProject(archive io.Reader, opt *ImportFileOptions, options ...RequestOptionFunc) (*ImportSummary, error) {
+	s := m.target.ProjectImportExport
+
+	m.progress(MigrationProgress{Stage: MigrationImporting})
+	status, _, err := s.ImportFromFile(archive, opt, options...)
+	if err != nil {
+		return nil, fmt.Errorf("starting import: %w", err)
+	}
+
+	status, _, err = s.WaitForImport(status.ID, &WaitForImportOptions{
+		PollInterval:    m.opt.PollInterval,
+		MaxPollInterval: m.opt.MaxPollInterval,
+		OnStatusChange: func(i *ImportStatus) {
+			m.progress(MigrationProgress{Stage: MigrationImporting, Status: i.ImportStatus})
+		},
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.progress(MigrationProgress{Stage: MigrationFinished, Status: status.ImportStatus})
+	return newImportSummary(status), nil
+}
+
+// MigrateProjectOptions represents the available MigrateProject() options.
+type MigrateProjectOptions struct {
+	Export *ScheduleExportOptions
+	Import *ImportFileOptions
+}
+
+// MigrateProject exports a project from the source instance and imports it
+// on the target instance. The archive is stored in a temporary file in
+// between. Without import options, the project is imported with the path of
+// the exported project into the namespace of the target user.
+func (m *Migrator) MigrateProject(pid any, opt *MigrateProjectOptions, options ...RequestOptionFunc) (*ImportSummary, error) {
+	if opt == nil {
+		opt = &MigrateProjectOptions{}
+	}
+
+	f, err := os.CreateTemp(m.opt.TempDir, "gitlab-project-export-*.tar.gz")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := m.ExportProject(pid, f, opt.Export, options...); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	importOpt := opt.Import
+	if importOpt == nil || importOpt.Path == nil {
+		project, _, err := m.source.Projects.GetProject(pid, nil, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		o := ImportFileOptions{}
+		if importOpt != nil {
+			o = *importOpt
+		}
+		o.Path = Ptr(project.Path)
+		importOpt = &o
+	}
+
+	return m.ImportProject(f, importOpt, options...)
+}
+
+// MigrateGroup exports a group from the source instance and imports it on the
+// target instance. The archive is stored in a temporary file in between, the
+// File option is ignored.
+//
+// Unlike MigrateProject, MigrateGroup doesn't wait for the import to finish
+// and can't report failed relations: the group import API has no status
+// endpoint, so MigrateGroup returns as soon as the target instance accepted
+// the archive. Check the imported group on the target instance, or migrate
+// the group by direct transfer with BulkImportsService instead, if the
+// target instance can reach the source instance.
+func (m *Migrator) MigrateGroup(gid any, opt *GroupImportFileOptions, options ...RequestOptionFunc) error {
+	if opt == nil {
+		return errors.New("group import options cannot be nil")
+	}
+
+	f, err := os.CreateTemp(m.opt.TempDir, "gitlab-group-export-*.tar.gz")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := m.ExportGroup(gid, f, options...); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	o := *opt
+	o.File = Ptr(f.Name())
+
+	m.progress(MigrationProgress{Stage: MigrationImporting})
+	if _, err := m.target.GroupImportExport.ImportFile(&o, options...); err != nil {
+		return fmt.Errorf("starting import: %w", err)
+	}
+	m.progress(MigrationProgress{Stage: MigrationFinished})
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package gitlab
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_MigrateProject(t *testing.T) {
+	t.Parallel()
+	sourceMux, source := setup(t)
+	targetMux, target := setup(t)
+
+	archive := bytes.Repeat([]byte("archive"), 1024)
+
+	var exportPolls atomic.Int32
+	sourceMux.HandleFunc("POST /api/v4/projects/1/export", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"message": "202 Accepted"}`)
+	})
+	sourceMux.HandleFunc("GET /api/v4/projects/1/export", func(w http.ResponseWriter, r *http.Request) {
+		status := "started"
+		if exportPolls.Add(1) > 1 {
+			status = "finished"
+		}
+		fmt.Fprintf(w, `{"id": 1, "export_status": %q}`, status)
+	})
+	sourceMux.HandleFunc("GET /api/v4/projects/1/export/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	})
+	sourceMux.HandleFunc("GET /api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "path": "project"}`)
+	})
+
+	targetMux.HandleFunc("POST /api/v4/projects/import", func(w http.ResponseWriter, r *http.Request) {
+		f, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		b, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, archive, b)
+		assert.Equal(t, "project", r.FormValue("path"))
+		assert.Equal(t, "migrated", r.FormValue("namespace"))
+
+		fmt.Fprint(w, `{"id": 9, "import_status": "scheduled"}`)
+	})
+	targetMux.HandleFunc("GET /api/v4/projects/9/import", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"id": 9,
+			"path_with_namespace": "migrated/project",
+			"import_status": "finished",
+			"failed_relations": [
+				{"id": 1, "relation_name": "merge_requests"},
+				{"id": 2, "relation_name": "issues"},
+				{"id": 3, "relation_name": "issues"}
+			]
+		}`)
+	})
+
+	var stages []string
+	m := NewMigrator(source, target, &MigratorOptions{
+		PollInterval: time.Millisecond,
+		TempDir:      t.TempDir(),
+		OnProgress: func(p MigrationProgress) {
+			stages = append(stages, fmt.Sprintf("%s %s %d", p.Stage, p.Status, p.Bytes))
+		},
+	})
+
+	summary, err := m.MigrateProject(1, &MigrateProjectOptions{
+		Import: &ImportFileOptions{Namespace: Ptr("migrated")},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"issues": 2, "merge_requests": 1}, summary.FailedRelations)
+	assert.Equal(t, "imported project migrated/project with 3 failed relation(s): issues (2), merge_requests (1)", summary.String())
+	assert.Equal(t, []string{
+		"exporting started 0",
+		"exporting finished 0",
+		"downloading  0",
+		fmt.Sprintf("downloading finished %d", len(archive)),
+		"importing  0",
+		"importing finished 0",
+		"finished finished 0",
+	}, stages)
+}
+
+func TestMigrator_ImportProjectFailed(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("POST /api/v4/projects/import", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 9, "import_status": "scheduled"}`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/9/import", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 9, "path_with_namespace": "group/project", "import_status": "failed", "import_error": "invalid archive"}`)
+	})
+
+	m := NewMigrator(client, client, &MigratorOptions{PollInterval: time.Millisecond})
+	_, err := m.ImportProject(bytes.NewReader([]byte("archive")), &ImportFileOptions{Path: Ptr("project")})
+
+	var failed *ImportFailedError
+	require.ErrorAs(t, err, &failed)
+	assert.Equal(t, "invalid archive", failed.Status.ImportError)
+}
+
+func TestMigrator_ExportGroup(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("POST /api/v4/groups/1/export", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	var downloads atomic.Int32
+	mux.HandleFunc("GET /api/v4/groups/1/export/download", func(w http.ResponseWriter, r *http.Request) {
+		switch downloads.Add(1) {
+		case 1:
+			// The archive of a previous export is still available.
+			w.Header().Set("Last-Modified", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+			fmt.Fprint(w, "old group archive")
+		case 2:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "404 Not found"}`)
+		default:
+			w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+			fmt.Fprint(w, "group archive")
+		}
+	})
+
+	var buf bytes.Buffer
+	n, err := NewMigrator(client, nil, &MigratorOptions{PollInterval: time.Millisecond}).ExportGroup(1, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), n)
+	assert.Equal(t, "group archive", buf.String())
+	assert.Equal(t, int32(3), downloads.Load())
+}
@@ -0,0 +1,286 @@
+package gitlab
+
+import (
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type (
+	// NPMPackagesServiceInterface defines all the API methods for the NPMPackagesService
+	NPMPackagesServiceInterface interface {
+		// GetPackageMetadata gets the package document of an npm package of a
+		// project, which lists all its versions and dist-tags.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#metadata
+		GetPackageMetadata(pid any, packageName string, options ...RequestOptionFunc) (*NPMPackageMetadata, *Response, error)
+
+		// GetGroupPackageMetadata gets the package document of an npm package
+		// of any project in a group.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#metadata
+		GetGroupPackageMetadata(gid any, packageName string, options ...RequestOptionFunc) (*NPMPackageMetadata, *Response, error)
+
+		// GetInstancePackageMetadata gets the package document of an npm
+		// package of any project on the instance.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#metadata
+		GetInstancePackageMetadata(packageName string, options ...RequestOptionFunc) (*NPMPackageMetadata, *Response, error)
+
+		// DownloadPackageTarball downloads the tarball of an npm package of a
+		// project. The file is streamed from the server, so the caller must
+		// close the returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#download-a-package
+		DownloadPackageTarball(pid any, packageName, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+
+		// PublishPackage publishes a version of an npm package to a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#upload-a-package-file
+		PublishPackage(pid any, opt *PublishNPMPackageOptions, options ...RequestOptionFunc) (*Response, error)
+
+		// ListDistTags lists the dist-tags of an npm package of a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#list-tags
+		ListDistTags(pid any, packageName string, options ...RequestOptionFunc) (map[string]string, *Response, error)
+
+		// CreateOrUpdateDistTag points a dist-tag of an npm package of a
+		// project to the given version.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#create-or-update-a-tag
+		CreateOrUpdateDistTag(pid any, packageName, tag, version string, options ...RequestOptionFunc) (*Response, error)
+
+		// DeleteDistTag deletes a dist-tag of an npm package of a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/npm/#delete-a-tag
+		DeleteDistTag(pid any, packageName, tag string, options ...RequestOptionFunc) (*Response, error)
+	}
+
+	// NPMPackagesService handles communication with the npm package registry
+	// related methods of the GitLab API.
+	//
+	// Package names are passed unescaped, including the scope of scoped
+	// packages, like "@scope/name".
+	//
+	// GitLab API docs:
+	// https://docs.gitlab.com/api/packages/npm/
+	NPMPackagesService struct {
+		client *Client
+	}
+)
+
+var _ NPMPackagesServiceInterface = (*NPMPackagesService)(nil)
+
+// NPMPackageMetadata represents the package document of an npm package.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/packages/npm/#metadata
+type NPMPackageMetadata struct {
+	Name     string                        `json:"name"`
+	DistTags map[string]string             `json:"dist-tags"`
+	Versions map[string]*NPMPackageVersion `json:"versions"`
+}
+
+// NPMPackageVersion represents a version in the package document of an npm
+// package.
+type NPMPackageVersion struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dist                 NPMPackageDist    `json:"dist"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	PeerDependencies     map[string]string `json:"peerDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+	BundleDependencies   []string          `json:"bundleDependencies,omitempty"`
+	Deprecated           string            `json:"deprecated,omitempty"`
+}
+
+// NPMPackageDist represents the tarball of a version of an npm package.
+type NPMPackageDist struct {
+	Shasum    string `json:"shasum"`
+	Tarball   string `json:"tarball"`
+	Integrity string `json:"integrity,omitempty"`
+}
+
+// PublishNPMPackageOptions represents the available PublishPackage() options.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/packages/npm/#upload-a-package-file
+type PublishNPMPackageOptions struct {
+	// Name and Version of the package, required.
+	Name    string
+	Version string
+	// Tag is the dist-tag pointing to the published version. Defaults to
+	// "latest".
+	Tag *string
+	// Manifest is the content of the package.json of the package. Its name
+	// and version are overwritten by Name and Version. Optional.
+	Manifest json.RawMessage
+	// Tarball is the packed package, as created by `npm pack`, required.
+	Tarball io.Reader
+}
+
+// npmPublishDocument is the document sent by npm clients to publish a package.
+type npmPublishDocument struct {
+	ID          string                           `json:"_id"`
+	Name        string                           `json:"name"`
+	DistTags    map[string]string                `json:"dist-tags"`
+	Versions    map[string]map[string]any        `json:"versions"`
+	Attachments map[string]*npmPublishAttachment `json:"_attachments"`
+}
+
+type npmPublishAttachment struct {
+	ContentType string `json:"content_type"`
+	Data        string `json:"data"`
+	Length      int    `json:"length"`
+}
+
+func (s *NPMPackagesService) GetPackageMetadata(pid any, packageName string, options ...RequestOptionFunc) (*NPMPackageMetadata, *Response, error) {
+	return do[*NPMPackageMetadata](s.client,
+		withPath("projects/%s/packages/npm/%s", ProjectID{pid}, packageName),
+		withRequestOpts(options...),
+	)
+}
+
+func (s *NPMPackagesService) GetGroupPackageMetadata(gid any, packageName string, options ...RequestOptionFunc) (*NPMPackageMetadata, *Response, error) {
+	return do[*NPMPackageMetadata](s.client,
+		withPath("groups/%s/-/packages/npm/%s", GroupID{gid}, packageName),
+		withRequestOpts(options...),
+	)
+}
+
+func (s *NPMPackagesService) GetInstancePackageMetadata(packageName string, options ...RequestOptionFunc) (*NPMPackageMetadata, *Response, error) {
+	return do[*NPMPackageMetadata](s.client,
+		withPath("packages/npm/%s", packageName),
+		withRequestOpts(options...),
+	)
+}
+
+func (s *NPMPackagesService) DownloadPackageTarball(pid any, packageName, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/packages/npm/%s/-/%s", ProjectID{pid}, packageName, fileName),
+		nil,
+		options,
+	)
+}
+
+func (s *NPMPackagesService) PublishPackage(pid any, opt *PublishNPMPackageOptions, options ...RequestOptionFunc) (*Response, error) {
+	if opt == nil || opt.Name == "" || opt.Version == "" || opt.Tarball == nil {
+		return nil, errors.New("publishing an npm package requires a name, version and tarball")
+	}
+
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	tarball, err := io.ReadAll(opt.Tarball)
+	if err != nil {
+		return nil, fmt.Errorf("reading tarball: %w", err)
+	}
+
+	manifest := make(map[string]any)
+	if len(opt.Manifest) > 0 {
+		if err := json.Unmarshal(opt.Manifest, &manifest); err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+	}
+
+	// The tarball of scoped packages is named without the scope.
+	_, baseName, _ := strings.Cut(opt.Name, "/")
+	if !strings.HasPrefix(opt.Name, "@") {
+		baseName = opt.Name
+	}
+	fileName := fmt.Sprintf("%s-%s.tgz", baseName, opt.Version)
+
+	u, err := s.client.BaseURL().Parse(fmt.Sprintf(
+		"projects/%s/packages/npm/%s/-/%s",
+		PathEscape(project),
+		PathEscape(opt.Name),
+		PathEscape(fileName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	sha1sum := sha1.Sum(tarball)
+	sha512sum := sha512.Sum512(tarball)
+
+	manifest["name"] = opt.Name
+	manifest["version"] = opt.Version
+	manifest["dist"] = NPMPackageDist{
+		Shasum:    hex.EncodeToString(sha1sum[:]),
+		Tarball:   u.String(),
+		Integrity: "sha512-" + base64.StdEncoding.EncodeToString(sha512sum[:]),
+	}
+
+	tag := "latest"
+	if opt.Tag != nil {
+		tag = *opt.Tag
+	}
+
+	doc := &npmPublishDocument{
+		ID:       opt.Name,
+		Name:     opt.Name,
+		DistTags: map[string]string{tag: opt.Version},
+		Versions: map[string]map[string]any{opt.Version: manifest},
+		Attachments: map[string]*npmPublishAttachment{
+			fileName: {
+				ContentType: "application/octet-stream",
+				Data:        base64.StdEncoding.EncodeToString(tarball),
+				Length:      len(tarball),
+			},
+		},
+	}
+
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodPut),
+		withPath("projects/%s/packages/npm/%s", ProjectID{pid}, opt.Name),
+		withAPIOpts(doc),
+		withRequestOpts(options...),
+	)
+	return resp, err
+}
+
+func (s *NPMPackagesService) ListDistTags(pid any, packageName string, options ...RequestOptionFunc) (map[string]string, *Response, error) {
+	return do[map[string]string](s.client,
+		withPath("projects/%s/packages/npm/-/package/%s/dist-tags", ProjectID{pid}, packageName),
+		withRequestOpts(options...),
+	)
+}
+
+func (s *NPMPackagesService) CreateOrUpdateDistTag(pid any, packageName, tag, version string, options ...RequestOptionFunc) (*Response, error) {
+	// The body is the version encoded as a JSON string, the same way npm
+	// clients send it.
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodPut),
+		withPath("projects/%s/packages/npm/-/package/%s/dist-tags/%s", ProjectID{pid}, packageName, tag),
+		withAPIOpts(version),
+		withRequestOpts(options...),
+	)
+	return resp, err
+}
+
+func (s *NPMPackagesService) DeleteDistTag(pid any, packageName, tag string, options ...RequestOptionFunc) (*Response, error) {
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodDelete),
+		withPath("projects/%s/packages/npm/-/package/%s/dist-tags/%s", ProjectID{pid}, packageName, tag),
+		withRequestOpts(options...),
+	)
+	return resp, err
+}
@@ -0,0 +1,160 @@
+package gitlab
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNPMPackages_GetPackageMetadata(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/npm/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "/api/v4/projects/1/packages/npm/@scope%2Fmy-package", r.URL.EscapedPath())
+		assert.Equal(t, "job-token", r.Header.Get(JobTokenHeaderName))
+		fmt.Fprint(w, `{
+			"name": "@scope/my-package",
+			"versions": {
+				"1.0.0": {
+					"name": "@scope/my-package",
+					"version": "1.0.0",
+					"dist": {
+						"shasum": "f572d396fae9206628714fb2ce00f72e94f2258f",
+						"tarball": "https://gitlab.example.com/api/v4/projects/1/packages/npm/@scope/my-package/-/@scope/my-package-1.0.0.tgz"
+					},
+					"dependencies": {"left-pad": "^1.3.0"}
+				}
+			},
+			"dist-tags": {"latest": "1.0.0"}
+		}`)
+	})
+
+	m, _, err := client.NPMPackages.GetPackageMetadata(1, "@scope/my-package", WithToken(JobToken, "job-token"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "@scope/my-package", m.Name)
+	assert.Equal(t, map[string]string{"latest": "1.0.0"}, m.DistTags)
+	require.Contains(t, m.Versions, "1.0.0")
+	v := m.Versions["1.0.0"]
+	assert.Equal(t, "f572d396fae9206628714fb2ce00f72e94f2258f", v.Dist.Shasum)
+	assert.Equal(t, map[string]string{"left-pad": "^1.3.0"}, v.Dependencies)
+}
+
+func TestNPMPackages_DownloadPackageTarball(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/npm/my-package/-/my-package-1.0.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "tarball")
+	})
+
+	r, _, err := client.NPMPackages.DownloadPackageTarball(1, "my-package", "my-package-1.0.0.tgz")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "tarball", string(b))
+}
+
+func TestNPMPackages_PublishPackage(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	var doc struct {
+		Name        string                     `json:"name"`
+		DistTags    map[string]string          `json:"dist-tags"`
+		Versions    map[string]json.RawMessage `json:"versions"`
+		Attachments map[string]struct {
+			Data   string `json:"data"`
+			Length int    `json:"length"`
+		} `json:"_attachments"`
+	}
+	mux.HandleFunc("/api/v4/projects/1/packages/npm/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		assert.Equal(t, "/api/v4/projects/1/packages/npm/@scope%2Fmy-package", r.URL.EscapedPath())
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&doc))
+		fmt.Fprint(w, `{"message": "201 Created"}`)
+	})
+
+	_, err := client.NPMPackages.PublishPackage(1, &PublishNPMPackageOptions{
+		Name:     "@scope/my-package",
+		Version:  "1.0.0",
+		Tag:      Ptr("beta"),
+		Manifest: json.RawMessage(`{"name": "ignored", "description": "A package", "main": "index.js"}`),
+		Tarball:  strings.NewReader("tarball"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "@scope/my-package", doc.Name)
+	assert.Equal(t, map[string]string{"beta": "1.0.0"}, doc.DistTags)
+
+	require.Contains(t, doc.Attachments, "my-package-1.0.0.tgz")
+	attachment := doc.Attachments["my-package-1.0.0.tgz"]
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("tarball")), attachment.Data)
+	assert.Equal(t, 7, attachment.Length)
+
+	var version struct {
+		Name        string         `json:"name"`
+		Version     string         `json:"version"`
+		Description string         `json:"description"`
+		Dist        NPMPackageDist `json:"dist"`
+	}
+	require.NoError(t, json.Unmarshal(doc.Versions["1.0.0"], &version))
+	assert.Equal(t, "@scope/my-package", version.Name)
+	assert.Equal(t, "1.0.0", version.Version)
+	assert.Equal(t, "A package", version.Description)
+	assert.Equal(t, "e10f6e70661d167ef514ab6e6d98607438c6a8c6", version.Dist.Shasum)
+	assert.True(t, strings.HasPrefix(version.Dist.Integrity, "sha512-"))
+	assert.Equal(t, client.BaseURL().String()+"projects/1/packages/npm/@scope%2Fmy-package/-/my-package-1%2E0%2E0%2Etgz", version.Dist.Tarball)
+}
+
+func TestNPMPackages_PublishPackage_MissingOptions(t *testing.T) {
+	t.Parallel()
+	_, client := setup(t)
+
+	_, err := client.NPMPackages.PublishPackage(1, &PublishNPMPackageOptions{Name: "my-package"})
+	assert.Error(t, err)
+}
+
+func TestNPMPackages_DistTags(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/npm/-/package/my-package/dist-tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"latest": "1.0.0", "beta": "1.1.0-beta.1"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/packages/npm/-/package/my-package/dist-tags/beta", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `"1.1.0"`, string(body))
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		case http.MethodDelete:
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	tags, _, err := client.NPMPackages.ListDistTags(1, "my-package")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"latest": "1.0.0", "beta": "1.1.0-beta.1"}, tags)
+
+	_, err = client.NPMPackages.CreateOrUpdateDistTag(1, "my-package", "beta", "1.1.0")
+	require.NoError(t, err)
+
+	_, err = client.NPMPackages.DeleteDistTag(1, "my-package", "beta")
+	require.NoError(t, err)
+}
@@ -0,0 +1,222 @@
+// Package otelgitlab instruments a *gitlab.Client with OpenTelemetry.
+//
+// It is a separate module, so the client itself doesn't depend on
+// OpenTelemetry. Every API call creates a client span, with a child span for
+// every HTTP attempt including retries, and records the duration, retries and
+// rate limit waits of the call as histograms.
+//
+// Example:
+//
+//	instrumentation, err := otelgitlab.New()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client, err := gitlab.NewClient(token, gitlab.WithInstrumentation(instrumentation))
+package otelgitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "gitlab.com/gitlab-org/api/client-go/v2/otelgitlab"
+
+// The names of the recorded histograms.
+const (
+	CallDurationMetric  = "gitlab.client.call.duration"
+	CallRetriesMetric   = "gitlab.client.call.retries"
+	RateLimitWaitMetric = "gitlab.client.rate_limit.wait.duration"
+)
+
+// The attributes set on spans and metrics, besides the HTTP semantic
+// conventions.
+const (
+	RequestIDKey     = attribute.Key("gitlab.request_id")
+	RetriesKey       = attribute.Key("gitlab.retries")
+	RateLimitWaitKey = attribute.Key("gitlab.rate_limit.wait")
+)
+
+// Option configures the Instrumentation returned by New.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider used to create spans. Defaults
+// to the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics. Defaults
+// to the global MeterProvider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Instrumentation is a gitlab.Instrumentation creating OpenTelemetry spans
+// and metrics.
+type Instrumentation struct {
+	tracer trace.Tracer
+
+	duration      metric.Float64Histogram
+	retries       metric.Int64Histogram
+	rateLimitWait metric.Float64Histogram
+}
+
+var _ gitlab.Instrumentation = (*Instrumentation)(nil)
+
+// New returns an Instrumentation using the given providers.
+func New(options ...Option) (*Instrumentation, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, fn := range options {
+		if fn != nil {
+			fn(c)
+		}
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	i := &Instrumentation{tracer: c.tracerProvider.Tracer(ScopeName)}
+
+	var err, e error
+	i.duration, e = meter.Float64Histogram(CallDurationMetric,
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of GitLab API calls, including retries and rate limit waits."),
+	)
+	err = errors.Join(err, e)
+	i.retries, e = meter.Int64Histogram(CallRetriesMetric,
+		metric.WithUnit("{retry}"),
+		metric.WithDescription("Number of retries of GitLab API calls."),
+		metric.WithExplicitBucketBoundaries(0, 1, 2, 3, 5, 10),
+	)
+	err = errors.Join(err, e)
+	i.rateLimitWait, e = meter.Float64Histogram(RateLimitWaitMetric,
+		metric.WithUnit("s"),
+		metric.WithDescription("Time GitLab API calls waited for the client rate limiter."),
+	)
+	err = errors.Join(err, e)
+	if err != nil {
+		return nil, fmt.Errorf("creating instruments: %w", err)
+	}
+
+	return i, nil
+}
+
+// StartCall implements gitlab.Instrumentation.
+func (i *Instrumentation) StartCall(ctx context.Context, info gitlab.CallInfo) (context.Context, gitlab.CallObserver) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", info.Method),
+		attribute.String("http.route", info.Route),
+	}
+
+	ctx, span := i.tracer.Start(ctx, info.Method+" "+info.Route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(attribute.String("url.full", info.URL)),
+	)
+
+	return ctx, &call{instrumentation: i, ctx: ctx, span: span, name: info.Method + " " + info.Route, attrs: attrs}
+}
+
+// call observes a single API call.
+type call struct {
+	instrumentation *Instrumentation
+	ctx             context.Context
+	span            trace.Span
+	name            string
+	attrs           []attribute.KeyValue
+}
+
+// StartAttempt implements gitlab.CallObserver.
+func (c *call) StartAttempt(ctx context.Context, attempt int) (context.Context, gitlab.AttemptObserver) {
+	ctx, span := c.instrumentation.tracer.Start(ctx, c.name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(c.attrs...),
+		trace.WithAttributes(attribute.Int("http.request.resend_count", attempt-1)),
+	)
+	return ctx, &attemptObserver{span: span}
+}
+
+// EndCall implements gitlab.CallObserver.
+func (c *call) EndCall(result gitlab.CallResult) {
+	attrs := append(c.attrs[:len(c.attrs):len(c.attrs)], resultAttributes(result.StatusCode, result.Err)...)
+
+	c.span.SetAttributes(
+		RetriesKey.Int(result.Retries),
+		RateLimitWaitKey.Float64(result.RateLimitWait.Seconds()),
+	)
+	endSpan(c.span, result.StatusCode, result.RequestID, result.Err)
+
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	c.instrumentation.duration.Record(c.ctx, result.Duration.Seconds(), set)
+	c.instrumentation.retries.Record(c.ctx, int64(result.Retries), set)
+	c.instrumentation.rateLimitWait.Record(c.ctx, result.RateLimitWait.Seconds(), set)
+}
+
+// attemptObserver observes a single HTTP attempt of a call.
+type attemptObserver struct {
+	span trace.Span
+}
+
+// EndAttempt implements gitlab.AttemptObserver.
+func (a *attemptObserver) EndAttempt(result gitlab.AttemptResult) {
+	endSpan(a.span, result.StatusCode, result.RequestID, result.Err)
+}
+
+// endSpan sets the outcome of a call or attempt on its span and ends it.
+// Following the HTTP semantic conventions, client spans fail on transport
+// errors and 4xx or 5xx responses.
+func endSpan(span trace.Span, statusCode int, requestID string, err error) {
+	span.SetAttributes(resultAttributes(statusCode, err)...)
+	if requestID != "" {
+		span.SetAttributes(RequestIDKey.String(requestID))
+	}
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case statusCode >= http.StatusBadRequest:
+		span.SetStatus(codes.Error, "")
+	}
+	span.End()
+}
+
+// resultAttributes returns the attributes describing the outcome of a call
+// or attempt.
+func resultAttributes(statusCode int, err error) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if statusCode != 0 {
+		attrs = append(attrs, attribute.Int("http.response.status_code", statusCode))
+	}
+
+	switch {
+	case statusCode >= http.StatusBadRequest:
+		attrs = append(attrs, attribute.String("error.type", strconv.Itoa(statusCode)))
+	case err != nil:
+		attrs = append(attrs, attribute.String("error.type", fmt.Sprintf("%T", err)))
+	}
+
+	return attrs
+}
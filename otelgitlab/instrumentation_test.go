@@ -0,0 +1,171 @@
+package otelgitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+type rateLimiterFunc func(context.Context) error
+
+func (f rateLimiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
+
+func setupInstrumentation(t *testing.T, handler http.Handler) (*gitlab.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	require.NoError(t, err)
+
+	client, err := gitlab.NewClient("",
+		gitlab.WithBaseURL(server.URL),
+		gitlab.WithInstrumentation(instrumentation),
+		gitlab.WithCustomRetryWaitMinMax(0, 0),
+		gitlab.WithCustomLimiter(rateLimiterFunc(func(context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})),
+	)
+	require.NoError(t, err)
+
+	return client, spans, reader
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func collectHistograms(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	histograms := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		assert.Equal(t, ScopeName, sm.Scope.Name)
+		for _, m := range sm.Metrics {
+			histograms[m.Name] = m.Data
+		}
+	}
+	return histograms
+}
+
+func TestInstrumentation(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group%2Fproject/merge_requests/5", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(gitlab.RequestIDHeaderName, fmt.Sprintf("request-%d", requests.Add(1)))
+		if requests.Load() == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "iid": 5}`)
+	})
+	client, spans, reader := setupInstrumentation(t, mux)
+
+	_, _, err := client.MergeRequests.GetMergeRequest("group/project", 5, nil)
+	require.NoError(t, err)
+
+	// The attempts end before the call.
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+	first, retry, call := ended[0], ended[1], ended[2]
+
+	assert.Equal(t, "GET projects/:id/merge_requests/:iid", call.Name())
+	assert.False(t, call.Parent().IsValid())
+	callAttrs := spanAttributes(call)
+	assert.Equal(t, int64(http.StatusOK), callAttrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, "request-2", callAttrs[RequestIDKey].AsString())
+	assert.Equal(t, int64(1), callAttrs[RetriesKey].AsInt64())
+	assert.Positive(t, callAttrs[RateLimitWaitKey].AsFloat64())
+	assert.Equal(t, codes.Unset, call.Status().Code)
+
+	for i, attempt := range []sdktrace.ReadOnlySpan{first, retry} {
+		assert.Equal(t, call.Name(), attempt.Name())
+		assert.Equal(t, call.SpanContext().SpanID(), attempt.Parent().SpanID())
+		assert.Equal(t, int64(i), spanAttributes(attempt)["http.request.resend_count"].AsInt64())
+	}
+	assert.Equal(t, int64(http.StatusServiceUnavailable), spanAttributes(first)["http.response.status_code"].AsInt64())
+	assert.Equal(t, "request-1", spanAttributes(first)[RequestIDKey].AsString())
+	assert.Equal(t, codes.Error, first.Status().Code)
+	assert.Equal(t, codes.Unset, retry.Status().Code)
+
+	histograms := collectHistograms(t, reader)
+	require.Len(t, histograms, 3)
+
+	duration := histograms[CallDurationMetric].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	route, _ := duration.DataPoints[0].Attributes.Value("http.route")
+	assert.Equal(t, "projects/:id/merge_requests/:iid", route.AsString())
+	status, _ := duration.DataPoints[0].Attributes.Value("http.response.status_code")
+	assert.Equal(t, int64(http.StatusOK), status.AsInt64())
+
+	retries := histograms[CallRetriesMetric].(metricdata.Histogram[int64])
+	require.Len(t, retries.DataPoints, 1)
+	assert.Equal(t, int64(1), retries.DataPoints[0].Sum)
+
+	wait := histograms[RateLimitWaitMetric].(metricdata.Histogram[float64])
+	require.Len(t, wait.DataPoints, 1)
+	assert.GreaterOrEqual(t, wait.DataPoints[0].Sum, (10 * time.Millisecond).Seconds())
+}
+
+func TestInstrumentation_Error(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "404 Project Not Found"}`)
+	})
+	client, spans, reader := setupInstrumentation(t, mux)
+
+	_, _, err := client.Projects.GetProject(1, nil)
+	require.ErrorIs(t, err, gitlab.ErrNotFound)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	call := ended[1]
+	assert.Equal(t, "GET projects/:id", call.Name())
+	assert.Equal(t, codes.Error, call.Status().Code)
+	assert.Equal(t, "404", spanAttributes(call)["error.type"].AsString())
+	assert.Equal(t, int64(0), spanAttributes(call)[RetriesKey].AsInt64())
+	require.Len(t, call.Events(), 1)
+	assert.Equal(t, "exception", call.Events()[0].Name)
+
+	duration := collectHistograms(t, reader)[CallDurationMetric].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	errorType, _ := duration.DataPoints[0].Attributes.Value("error.type")
+	assert.Equal(t, "404", errorType.AsString())
+}
@@ -1,8 +1,12 @@
 package gitlab
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
+	"regexp"
+	"strings"
 )
 
 type PaginationOptionFunc = RequestOptionFunc
@@ -129,6 +133,278 @@ func Scan2[T any](f func(p PaginationOptionFunc) ([]T, *Response, error)) iter.S
 	}
 }
 
+// ScanConcurrently scans all pages for the given request function f, like
+// Scan2, but fetches up to workers pages in parallel. The items are returned in
+// the same order as with Scan2.
+//
+// Pages are only fetched in parallel for offset-based pagination when the
+// first response reports the total number of pages using the X-Total-Pages
+// header. GitLab omits this header for very large collections, in which case
+// the pages are fetched sequentially, just like with keyset-based pagination.
+// The number of pages is determined once from the first response, so items
+// created while scanning may be missed.
+//
+// All requests go through the client, so they are subject to its RateLimiter.
+// As f is called concurrently, it must be safe for concurrent use.
+//
+//	opts := &ListProjectsOptions{ListOptions: ListOptions{PerPage: 100}}
+//	for p, err := range ScanConcurrently(func(p PaginationOptionFunc) ([]*Project, *Response, error) {
+//		return c.Projects.ListProjects(opts, p)
+//	}, 8) {
+//		if err != nil {
+//			return err
+//		}
+//		// do something with p
+//	}
+//
+// Attention: This API is experimental and may be subject to breaking changes to improve the API in the future.
+func ScanConcurrently[T any](f func(p PaginationOptionFunc) ([]T, *Response, error), workers int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ts, resp, err := f(nil)
+		if err != nil {
+			var t T
+			yield(t, err)
+			return
+		}
+
+		for _, t := range ts {
+			if !yield(t, nil) {
+				return
+			}
+		}
+
+		next, ok := WithNext(resp)
+		if !ok {
+			return
+		}
+
+		var pages iter.Seq2[T, error]
+		if workers > 1 && resp.NextLink == "" && resp.NextPage != 0 && resp.TotalPages >= resp.NextPage {
+			pages = scanPagesConcurrently(f, resp.NextPage, resp.TotalPages, workers)
+		} else {
+			pages = Scan2(func(p PaginationOptionFunc) ([]T, *Response, error) {
+				if p == nil {
+					p = next
+				}
+				return f(p)
+			})
+		}
+
+		for t, err := range pages {
+			if !yield(t, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ScanAndCollectConcurrently is a convenience function that collects all
+// results of ScanConcurrently and returns them as a slice as well as an error
+// if one happens.
+//
+// Attention: This API is experimental and may be subject to breaking changes to improve the API in the future.
+func ScanAndCollectConcurrently[T any](f func(p PaginationOptionFunc) ([]T, *Response, error), workers int) ([]T, error) {
+	var items []T
+
+	for item, err := range ScanConcurrently(f, workers) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+type scanPage[T any] struct {
+	items []T
+	err   error
+}
+
+// scanPagesConcurrently fetches the pages first to last using offset-based
+// pagination with the given number of workers, and returns their items in
+// order. Fetching stops as soon as the iteration stops.
+func scanPagesConcurrently[T any](f func(p PaginationOptionFunc) ([]T, *Response, error), first, last int64, workers int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		// Every page gets a buffered channel, so workers never block on
+		// delivering a page, even if the iteration stopped.
+		results := make([]chan scanPage[T], last-first+1)
+		for i := range results {
+			results[i] = make(chan scanPage[T], 1)
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+
+		pages := make(chan int64)
+		go func() {
+			defer close(pages)
+			for page := first; page <= last; page++ {
+				select {
+				case pages <- page:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		for range min(workers, len(results)) {
+			go func() {
+				for page := range pages {
+					select {
+					case <-done:
+						return
+					default:
+					}
+
+					items, _, err := f(WithOffsetPaginationParameters(page))
+					results[page-first] <- scanPage[T]{items: items, err: err}
+				}
+			}()
+		}
+
+		for _, result := range results {
+			r := <-result
+			if r.err != nil {
+				var t T
+				yield(t, r.err)
+				return
+			}
+
+			for _, t := range r.items {
+				if !yield(t, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// graphQLAfterVariableRegex matches the declaration or use of the $after
+// variable in a GraphQL query.
+var graphQLAfterVariableRegex = regexp.MustCompile(`\$after\b`)
+
+// ScanGraphQL runs the given GraphQL query for all pages of the connection at
+// path and returns its nodes and potential errors in an iterator. The caller
+// must consume the error element of the iterator during each iteration to
+// ensure that no errors happened.
+//
+// The query must declare an $after variable of type String and pass it as the
+// after argument of the connection, which is set to the end cursor of the
+// previous page for every subsequent request. The path is the dot separated
+// list of fields leading from the data of the response to the connection,
+// which must select the pageInfo and either nodes or edges.node.
+//
+//	query := GraphQLQuery{
+//		Query: `query($fullPath: ID!, $after: String) {
+//			project(fullPath: $fullPath) {
+//				vulnerabilities(after: $after) {
+//					pageInfo { endCursor hasNextPage }
+//					nodes { id title severity }
+//				}
+//			}
+//		}`,
+//		Variables: map[string]any{"fullPath": "gitlab-org/gitlab"},
+//	}
+//	for v, err := range ScanGraphQL[Vulnerability](c.GraphQL, query, "project.vulnerabilities") {
+//		if err != nil {
+//			return err
+//		}
+//		// do something with v
+//	}
+//
+// Attention: This API is experimental and may be subject to breaking changes to improve the API in the future.
+func ScanGraphQL[T any](g GraphQLInterface, query GraphQLQuery, path string, options ...RequestOptionFunc) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if !graphQLAfterVariableRegex.MatchString(query.Query) {
+			var t T
+			yield(t, errors.New("GraphQL query must use an $after variable for pagination"))
+			return
+		}
+
+		var cursor string
+		for t, err := range Scan2(func(p PaginationOptionFunc) ([]T, *Response, error) {
+			var result struct {
+				Data json.RawMessage `json:"data"`
+				GenericGraphQLErrors
+			}
+
+			resp, err := g.Do(query, &result, append(options[:len(options):len(options)], p)...)
+			if err != nil {
+				return nil, resp, err
+			}
+
+			if len(result.Errors) != 0 {
+				return nil, resp, &GraphQLResponseError{
+					Err:    errors.New("GraphQL query failed"),
+					Errors: result.GenericGraphQLErrors,
+				}
+			}
+
+			conn, err := graphQLConnectionAt[T](result.Data, path)
+			if err != nil {
+				return nil, resp, err
+			}
+
+			// An unchanged end cursor means the connection ignores the $after
+			// variable, which would otherwise make us loop forever.
+			if conn.PageInfo.HasNextPage && cursor != "" && conn.PageInfo.EndCursor == cursor {
+				return nil, resp, fmt.Errorf("GraphQL connection at %q returned the same end cursor twice, is $after passed to the connection?", path)
+			}
+			cursor = conn.PageInfo.EndCursor
+
+			// Setting the page info makes Scan2 request the next page using
+			// the end cursor as $after variable.
+			resp.PageInfo = &conn.PageInfo
+
+			nodes := conn.Nodes
+			for _, e := range conn.Edges {
+				nodes = append(nodes, e.Node)
+			}
+
+			return nodes, resp, nil
+		}) {
+			if !yield(t, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// scanConnectionGQL is a connectionGQL which also accepts the nodes of a
+// connection selected as edges.
+type scanConnectionGQL[T any] struct {
+	connectionGQL[T]
+	Edges []struct {
+		Node T `json:"node"`
+	} `json:"edges"`
+}
+
+// graphQLConnectionAt decodes the connection at the given dot separated path
+// of the data of a GraphQL response.
+func graphQLConnectionAt[T any](data json.RawMessage, path string) (*scanConnectionGQL[T], error) {
+	raw := data
+	for field := range strings.SplitSeq(strings.TrimPrefix(path, "data."), ".") {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("decoding GraphQL response at %q: %w", path, err)
+		}
+
+		v, ok := obj[field]
+		if !ok || string(v) == "null" {
+			return nil, fmt.Errorf("GraphQL response has no value at %q", path)
+		}
+		raw = v
+	}
+
+	conn := new(scanConnectionGQL[T])
+	if err := json.Unmarshal(raw, conn); err != nil {
+		return nil, fmt.Errorf("decoding GraphQL connection at %q: %w", path, err)
+	}
+
+	return conn, nil
+}
+
 // Must provides a single item iterator for the provided two item iterator and panics if an error happens.
 //
 //	opts := &ListProjectsOptions{}
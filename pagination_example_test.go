@@ -75,3 +75,45 @@ func ExampleScanAndCollectN() {
 		panic(err)
 	}
 }
+
+func ExampleScanGraphQL() {
+	// Create a client (this would normally use your GitLab instance URL and token)
+	client, err := gitlab.NewAuthSourceClient(
+		gitlab.AccessTokenAuthSource{"your-token"},
+		gitlab.WithBaseURL("https://gitlab.example.com/api/v4"),
+	)
+	if err != nil {
+		// Handle the error
+		panic(err)
+	}
+
+	// The query must pass the $after variable to the connection it paginates
+	query := gitlab.GraphQLQuery{
+		Query: `query($fullPath: ID!, $after: String) {
+			group(fullPath: $fullPath) {
+				runners(after: $after) {
+					pageInfo { endCursor hasNextPage }
+					nodes { id description status }
+				}
+			}
+		}`,
+		Variables: map[string]any{"fullPath": "gitlab-org"},
+	}
+
+	type runner struct {
+		ID          string `json:"id"`
+		Description string `json:"description"`
+		Status      string `json:"status"`
+	}
+
+	// Iterate over the runners of all pages of the group.runners connection
+	for r, err := range gitlab.ScanGraphQL[runner](client.GraphQL, query, "group.runners") {
+		// Errors are delivered inline — check for them and break the loop before using the value
+		if err != nil {
+			log.Println("ERROR:", err)
+			break
+		}
+
+		fmt.Printf("- %s (%s): %s\n", r.Description, r.ID, r.Status)
+	}
+}
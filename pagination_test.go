@@ -1,12 +1,16 @@
 package gitlab
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"iter"
 	"net/http"
 	"slices"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -215,6 +219,269 @@ func TestPagination_ScanAndCollectN_Zero(t *testing.T) {
 	assert.Nil(t, projects)
 }
 
+func TestPagination_ScanConcurrently(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+
+	var inFlight, maxInFlight atomic.Int32
+	mux.HandleFunc("GET /api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		// Later pages respond faster, to make sure the order is preserved.
+		time.Sleep(time.Duration(10-page) * time.Millisecond)
+
+		w.Header().Set("X-Total-Pages", "9")
+		w.Header().Set("X-Page", strconv.Itoa(page))
+		if page < 9 {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, 2*page-1, 2*page)
+	})
+
+	opt := &ListProjectsOptions{}
+	projects, err := ScanAndCollectConcurrently(func(p PaginationOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(opt, p)
+	}, 3)
+	require.NoError(t, err)
+
+	require.Len(t, projects, 18)
+	for i, p := range projects {
+		assert.Equal(t, int64(i+1), p.ID)
+	}
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+}
+
+func TestPagination_ScanConcurrently_KeysetBased(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+	handleTwoPagesSuccessfullyWithKeyset(t, mux)
+
+	opt := &ListProjectsOptions{
+		ListOptions: ListOptions{
+			Pagination: "keyset",
+			OrderBy:    "id",
+		},
+	}
+	projects, err := ScanAndCollectConcurrently(func(p PaginationOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(opt, p)
+	}, 4)
+	require.NoError(t, err)
+
+	want := []*Project{{ID: 1}, {ID: 2}}
+	assert.Equal(t, want, projects)
+}
+
+func TestPagination_ScanConcurrently_Error(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+	mux.HandleFunc("GET /api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		w.Header().Set("X-Total-Pages", "4")
+		switch page {
+		case "":
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":1}]`)
+		case "3":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprintf(w, `[{"id":%s}]`, page)
+		}
+	})
+
+	opt := &ListProjectsOptions{}
+	var ids []int64
+	var errs []error
+	for p, err := range ScanConcurrently(func(p PaginationOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(opt, p)
+	}, 2) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, p.ID)
+	}
+
+	assert.Equal(t, []int64{1, 2}, ids)
+	require.Len(t, errs, 1)
+	var errResp *ErrorResponse
+	assert.ErrorAs(t, errs[0], &errResp)
+}
+
+func TestPagination_ScanConcurrently_Break(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+
+	var requests atomic.Int32
+	mux.HandleFunc("GET /api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		w.Header().Set("X-Total-Pages", "100")
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		fmt.Fprintf(w, `[{"id":%d}]`, page)
+	})
+
+	opt := &ListProjectsOptions{}
+	for p, err := range ScanConcurrently(func(p PaginationOptionFunc) ([]*Project, *Response, error) {
+		return client.Projects.ListProjects(opt, p)
+	}, 2) {
+		require.NoError(t, err)
+		if p.ID == 3 {
+			break
+		}
+	}
+
+	// Workers stop fetching pages once the iteration stopped, except for the
+	// ones which were already in flight.
+	assert.Less(t, requests.Load(), int32(10))
+}
+
+func TestPagination_ScanGraphQL(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+	handleTwoGraphQLPagesSuccessfully(t, mux)
+
+	type runner struct {
+		ID string `json:"id"`
+	}
+
+	query := GraphQLQuery{
+		Query:     `query($fullPath: ID!, $after: String) { group(fullPath: $fullPath) { runners(after: $after) { pageInfo { endCursor hasNextPage } nodes { id } } } }`,
+		Variables: map[string]any{"fullPath": "gitlab-org"},
+	}
+
+	var runners []runner
+	for r, err := range ScanGraphQL[runner](client.GraphQL, query, "group.runners") {
+		require.NoError(t, err)
+		runners = append(runners, r)
+	}
+
+	assert.Equal(t, []runner{{ID: "gid://gitlab/Ci::Runner/1"}, {ID: "gid://gitlab/Ci::Runner/2"}}, runners)
+	assert.Equal(t, map[string]any{"fullPath": "gitlab-org"}, query.Variables)
+}
+
+func TestPagination_ScanGraphQL_Edges(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"project": {"issues": {
+			"pageInfo": {"endCursor": "b", "hasNextPage": false},
+			"edges": [{"node": {"iid": "1"}}, {"node": {"iid": "2"}}]
+		}}}}`)
+	})
+
+	type issue struct {
+		IID string `json:"iid"`
+	}
+	issues, err := collectGraphQL(ScanGraphQL[issue](client.GraphQL, GraphQLQuery{
+		Query: `query($after: String) { project(fullPath: "a/b") { issues(after: $after) { pageInfo { endCursor hasNextPage } edges { node { iid } } } } }`,
+	}, "data.project.issues"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []issue{{IID: "1"}, {IID: "2"}}, issues)
+}
+
+func TestPagination_ScanGraphQL_Errors(t *testing.T) {
+	t.Parallel()
+
+	mux, client := setup(t)
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var q GraphQLQuery
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&q))
+
+		switch q.Variables["case"] {
+		case "errors":
+			fmt.Fprint(w, `{"data": {"project": null}, "errors": [{"message": "Field 'foo' doesn't exist"}]}`)
+		case "null":
+			fmt.Fprint(w, `{"data": {"project": null}}`)
+		case "cursor":
+			fmt.Fprint(w, `{"data": {"project": {"issues": {"pageInfo": {"endCursor": "a", "hasNextPage": true}, "nodes": [{}]}}}}`)
+		}
+	})
+
+	tests := map[string]struct {
+		query string
+		want  string
+	}{
+		"errors": {
+			query: `query($after: String) { project { foo } }`,
+			want:  "Field 'foo' doesn't exist",
+		},
+		"null": {
+			query: `query($after: String) { project { issues(after: $after) { nodes { iid } } } }`,
+			want:  `GraphQL response has no value at "project.issues"`,
+		},
+		"cursor": {
+			query: `query($after: String) { project { issues { nodes { iid } } } }`,
+			want:  "returned the same end cursor twice",
+		},
+		"missing after": {
+			query: `query { project { issues { nodes { iid } } } }`,
+			want:  "must use an $after variable",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := collectGraphQL(ScanGraphQL[struct{}](client.GraphQL, GraphQLQuery{
+				Query:     tt.query,
+				Variables: map[string]any{"case": name},
+			}, "project.issues"))
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func collectGraphQL[T any](it iter.Seq2[T, error]) ([]T, error) {
+	var ts []T
+	for t, err := range it {
+		if err != nil {
+			return nil, err
+		}
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
+func handleTwoGraphQLPagesSuccessfully(t *testing.T, mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var q GraphQLQuery
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&q))
+		assert.Equal(t, "gitlab-org", q.Variables["fullPath"])
+
+		switch after := q.Variables["after"]; after {
+		case nil:
+			fmt.Fprint(w, `{"data": {"group": {"runners": {"pageInfo": {"endCursor": "cursor-1", "hasNextPage": true}, "nodes": [{"id": "gid://gitlab/Ci::Runner/1"}]}}}}`)
+		case "cursor-1":
+			fmt.Fprint(w, `{"data": {"group": {"runners": {"pageInfo": {"endCursor": "cursor-2", "hasNextPage": false}, "nodes": [{"id": "gid://gitlab/Ci::Runner/2"}]}}}}`)
+		default:
+			assert.Failf(t, "received request for unexpected cursor", "%v", after)
+		}
+	})
+}
+
 func handleTwoPagesSuccessfully(t *testing.T, mux *http.ServeMux) {
 	mux.HandleFunc("GET /api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
 		page := r.URL.Query().Get("page")
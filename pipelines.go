@@ -57,6 +57,7 @@ type (
 		CancelPipelineBuild(pid any, pipeline int64, options ...RequestOptionFunc) (*Pipeline, *Response, error)
 		DeletePipeline(pid any, pipeline int64, options ...RequestOptionFunc) (*Response, error)
 		UpdatePipelineMetadata(pid any, pipeline int64, opt *UpdatePipelineMetadataOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error)
+		WaitForPipeline(pid any, pipeline int64, opt *WaitForPipelineOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error)
 	}
 
 	// PipelinesService handles communication with the repositories related
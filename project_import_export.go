@@ -18,6 +18,7 @@ package gitlab
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -40,6 +41,24 @@ type (
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/project_import_export/#export-download
 		ExportDownload(pid any, options ...RequestOptionFunc) ([]byte, *Response, error)
+		// StreamExportDownload streams the finished export without buffering
+		// it in memory. Interrupted downloads are resumed using HTTP Range
+		// requests.
+		//
+		// The returned io.ReadCloser must be closed by the caller to avoid
+		// leaking the underlying response body.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/project_import_export/#export-download
+		StreamExportDownload(pid any, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+		// WaitForExport polls the export status of a project until the export
+		// finished. If the export failed, or the project has no export, an
+		// *ExportFailedError is returned. Use WithContext to stop waiting
+		// early.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/project_import_export/#export-status
+		WaitForExport(pid any, opt *WaitForExportOptions, options ...RequestOptionFunc) (*ExportStatus, *Response, error)
 		// ImportFromFile imports a project from an archive file.
 		//
 		// GitLab API docs:
@@ -50,6 +69,15 @@ type (
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/project_import_export/#import-status
 		ImportStatus(pid any, options ...RequestOptionFunc) (*ImportStatus, *Response, error)
+		// WaitForImport polls the import status of a project until the import
+		// finished. If the import failed, or the project was not imported, an
+		// *ImportFailedError is returned. Relations which failed to import
+		// don't fail the import, they are listed in
+		// ImportStatus.FailedRelations. Use WithContext to stop waiting early.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/project_import_export/#import-status
+		WaitForImport(pid any, opt *WaitForImportOptions, options ...RequestOptionFunc) (*ImportStatus, *Response, error)
 	}
 
 	// ProjectImportExportService handles communication with the project
@@ -80,12 +108,32 @@ type ImportStatus struct {
 	ImportType        string     `json:"import_type"`
 	CorrelationID     string     `json:"correlation_id"`
 	ImportError       string     `json:"import_error"`
+
+	FailedRelations []*ImportFailedRelation `json:"failed_relations"`
 }
 
 func (s ImportStatus) String() string {
 	return Stringify(s)
 }
 
+// ImportFailedRelation represents a relation, like an issue or a merge
+// request, which failed to import.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/project_import_export/#import-status
+type ImportFailedRelation struct {
+	ID               int64      `json:"id"`
+	CreatedAt        *time.Time `json:"created_at"`
+	ExceptionClass   string     `json:"exception_class"`
+	ExceptionMessage string     `json:"exception_message"`
+	Source           string     `json:"source"`
+	RelationName     string     `json:"relation_name"`
+}
+
+func (r ImportFailedRelation) String() string {
+	return Stringify(r)
+}
+
 // ExportStatus represents a project export status.
 //
 // GitLab API docs:
@@ -162,6 +210,14 @@ func (s *ProjectImportExportService) ExportDownload(pid any, options ...RequestO
 	return buf.Bytes(), resp, nil
 }
 
+func (s *ProjectImportExportService) StreamExportDownload(pid any, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/export/download", ProjectID{pid}),
+		nil,
+		options,
+	)
+}
+
 // ImportFileOptions represents the available ImportFile() options.
 //
 // GitLab API docs:
@@ -190,3 +246,146 @@ func (s *ProjectImportExportService) ImportStatus(pid any, options ...RequestOpt
 		withRequestOpts(options...),
 	)
 }
+
+// WaitForExportOptions represents the available WaitForExport() options.
+type WaitForExportOptions struct {
+	// PollInterval is the initial interval between two polls. It is doubled
+	// after every poll that didn't change the status, up to MaxPollInterval.
+	// Defaults to 3 seconds.
+	PollInterval time.Duration
+	// MaxPollInterval is the maximum interval between two polls. Defaults to
+	// 30 seconds.
+	MaxPollInterval time.Duration
+	// OnStatusChange is called with the export status every time it
+	// changes, including once for the initial status.
+	OnStatusChange func(*ExportStatus)
+}
+
+// WaitForImportOptions represents the available WaitForImport() options.
+type WaitForImportOptions struct {
+	// PollInterval is the initial interval between two polls. It is doubled
+	// after every poll that didn't change the status, up to MaxPollInterval.
+	// Defaults to 3 seconds.
+	PollInterval time.Duration
+	// MaxPollInterval is the maximum interval between two polls. Defaults to
+	// 30 seconds.
+	MaxPollInterval time.Duration
+	// OnStatusChange is called with the import status every time it
+	// changes, including once for the initial status.
+	OnStatusChange func(*ImportStatus)
+}
+
+// ExportFailedError is returned by WaitForExport if the export failed, or if
+// the project has no export.
+type ExportFailedError struct {
+	Status *ExportStatus
+}
+
+func (e *ExportFailedError) Error() string {
+	if e.Status.ExportStatus == "none" {
+		return fmt.Sprintf("project %s has no export", e.Status.PathWithNamespace)
+	}
+	msg := fmt.Sprintf("export of project %s failed", e.Status.PathWithNamespace)
+	if e.Status.Message != "" {
+		msg += ": " + e.Status.Message
+	}
+	return msg
+}
+
+// ImportFailedError is returned by WaitForImport if the import failed, or if
+// the project was not imported.
+type ImportFailedError struct {
+	Status *ImportStatus
+}
+
+func (e *ImportFailedError) Error() string {
+	if e.Status.ImportStatus == "none" {
+		return fmt.Sprintf("project %s has no import", e.Status.PathWithNamespace)
+	}
+	msg := fmt.Sprintf("import of project %s failed", e.Status.PathWithNamespace)
+	if e.Status.ImportError != "" {
+		msg += ": " + e.Status.ImportError
+	}
+	return msg
+}
+
+// WaitForExport polls the export status of a project until the export
+// finished. If the export failed, or the project has no export, because none
+// was scheduled or it expired, an *ExportFailedError is returned. Use
+// WithContext to stop waiting early.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/project_import_export/#export-status
+func (s *ProjectImportExportService) WaitForExport(pid any, opt *WaitForExportOptions, options ...RequestOptionFunc) (*ExportStatus, *Response, error) {
+	if opt == nil {
+		opt = &WaitForExportOptions{}
+	}
+
+	var status string
+	e, resp, err := pollUntil(s.client.requestContext(options), opt.PollInterval, opt.MaxPollInterval, func() (*ExportStatus, *Response, bool, error) {
+		e, resp, err := s.ExportStatus(pid, options...)
+		if err != nil {
+			return nil, resp, false, err
+		}
+
+		changed := e.ExportStatus != status
+		if changed && opt.OnStatusChange != nil {
+			opt.OnStatusChange(e)
+		}
+		status = e.ExportStatus
+
+		return e, resp, changed, nil
+	}, func(e *ExportStatus) bool {
+		return e.ExportStatus == "finished" || e.ExportStatus == "failed" || e.ExportStatus == "none"
+	})
+	if err != nil {
+		return e, resp, err
+	}
+
+	if e.ExportStatus != "finished" {
+		return e, resp, &ExportFailedError{Status: e}
+	}
+
+	return e, resp, nil
+}
+
+// WaitForImport polls the import status of a project until the import
+// finished. If the import failed, or the project was not imported, an
+// *ImportFailedError is returned. Relations which failed to import don't fail
+// the import, they are listed in ImportStatus.FailedRelations. Use
+// WithContext to stop waiting early.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/project_import_export/#import-status
+func (s *ProjectImportExportService) WaitForImport(pid any, opt *WaitForImportOptions, options ...RequestOptionFunc) (*ImportStatus, *Response, error) {
+	if opt == nil {
+		opt = &WaitForImportOptions{}
+	}
+
+	var status string
+	i, resp, err := pollUntil(s.client.requestContext(options), opt.PollInterval, opt.MaxPollInterval, func() (*ImportStatus, *Response, bool, error) {
+		i, resp, err := s.ImportStatus(pid, options...)
+		if err != nil {
+			return nil, resp, false, err
+		}
+
+		changed := i.ImportStatus != status
+		if changed && opt.OnStatusChange != nil {
+			opt.OnStatusChange(i)
+		}
+		status = i.ImportStatus
+
+		return i, resp, changed, nil
+	}, func(i *ImportStatus) bool {
+		return i.ImportStatus == "finished" || i.ImportStatus == "failed" || i.ImportStatus == "none"
+	})
+	if err != nil {
+		return i, resp, err
+	}
+
+	if i.ImportStatus != "finished" {
+		return i, resp, &ImportFailedError{Status: i}
+	}
+
+	return i, resp, nil
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -220,3 +223,85 @@ func TestProjectImportExportService_ImportStatus(t *testing.T) {
 	require.Nil(t, es)
 	require.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
+
+func TestWaitForExport_Failed(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	statuses := []string{"queued", "started", "failed"}
+	var polls atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/export", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		status := statuses[polls.Add(1)-1]
+		fmt.Fprintf(w, `{"id": 1, "path_with_namespace": "group/project", "export_status": %q, "message": "out of disk space"}`, status)
+	})
+
+	status, _, err := client.ProjectImportExport.WaitForExport(1, &WaitForExportOptions{PollInterval: time.Millisecond})
+
+	var failed *ExportFailedError
+	require.ErrorAs(t, err, &failed)
+	assert.Equal(t, status, failed.Status)
+	assert.EqualError(t, err, "export of project group/project failed: out of disk space")
+	assert.Equal(t, int32(3), polls.Load())
+}
+
+func TestWaitForImport_FinishedWithFailedRelations(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	statuses := []string{"scheduled", "started", "finished"}
+	var polls atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/import", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		status := statuses[polls.Add(1)-1]
+		fmt.Fprintf(w, `{
+			"id": 1,
+			"import_status": %q,
+			"failed_relations": [{"id": 42, "exception_class": "ActiveRecord::RecordInvalid", "relation_name": "issues"}]
+		}`, status)
+	})
+
+	var changes []string
+	status, _, err := client.ProjectImportExport.WaitForImport(1, &WaitForImportOptions{
+		PollInterval: time.Millisecond,
+		OnStatusChange: func(i *ImportStatus) {
+			changes = append(changes, i.ImportStatus)
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, statuses, changes)
+	require.Len(t, status.FailedRelations, 1)
+	assert.Equal(t, "issues", status.FailedRelations[0].RelationName)
+}
+
+func TestWaitForExport_None(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/export", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "path_with_namespace": "group/project", "export_status": "none"}`)
+	})
+
+	status, _, err := client.ProjectImportExport.WaitForExport(1, &WaitForExportOptions{PollInterval: time.Hour})
+
+	var failed *ExportFailedError
+	require.ErrorAs(t, err, &failed)
+	assert.Equal(t, status, failed.Status)
+	assert.EqualError(t, err, "project group/project has no export")
+}
+
+func TestWaitForImport_None(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/import", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "path_with_namespace": "group/project", "import_status": "none"}`)
+	})
+
+	_, _, err := client.ProjectImportExport.WaitForImport(1, &WaitForImportOptions{PollInterval: time.Hour})
+
+	var failed *ImportFailedError
+	require.ErrorAs(t, err, &failed)
+	assert.EqualError(t, err, "project group/project has no import")
+}
@@ -0,0 +1,869 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ProjectState is the desired state of a project, see PlanProjectState and
+// ReconcileProjectState. It uses the options of the corresponding API calls,
+// so it can be decoded from a JSON document using the field names of the API.
+//
+// Only what is set in the state is managed. Nil settings, nil push rules and
+// nil fields of the options are left as they are. A non-nil list, even an
+// empty one, manages all resources of its kind: resources missing from the
+// list are deleted.
+//
+// Fields the API doesn't return, like hook tokens, can't be compared. They
+// are sent whenever their resource is created or updated. Settings the API
+// doesn't return are listed as unverifiable fields of the settings change, so
+// they are sent every time the state is reconciled.
+type ProjectState struct {
+	Settings  *EditProjectOptions        `json:"settings,omitempty"`
+	PushRules *AddProjectPushRuleOptions `json:"push_rules,omitempty"`
+
+	// ApprovalRules are identified by their name.
+	ApprovalRules []*CreateProjectLevelRuleOptions `json:"approval_rules"`
+
+	// ProtectedBranches are identified by their name. The access levels of
+	// roles are updated in place. The access granted to individual users,
+	// groups and deploy keys is only set when a branch is protected.
+	ProtectedBranches []*ProtectRepositoryBranchesOptions `json:"protected_branches"`
+
+	// Hooks are identified by their URL.
+	Hooks []*AddProjectHookOptions `json:"hooks"`
+
+	// Variables are identified by their key and environment scope.
+	Variables []*CreateProjectVariableOptions `json:"variables"`
+
+	// Labels are identified by their name. Labels inherited from groups are
+	// not managed.
+	Labels []*CreateLabelOptions `json:"labels"`
+}
+
+// ReconcileAction is the action taken on a resource to reconcile it.
+type ReconcileAction string
+
+// The available reconcile actions.
+const (
+	ReconcileCreate  ReconcileAction = "create"
+	ReconcileUpdate  ReconcileAction = "update"
+	ReconcileReplace ReconcileAction = "replace"
+	ReconcileDelete  ReconcileAction = "delete"
+)
+
+var reconcileActionSymbols = map[ReconcileAction]string{
+	ReconcileCreate:  "+",
+	ReconcileUpdate:  "~",
+	ReconcileReplace: "-/+",
+	ReconcileDelete:  "-",
+}
+
+// ReconcileField is a field changed by a ReconcileChange. Old is nil for
+// created resources. The values are decoded from JSON.
+type ReconcileField struct {
+	Name      string
+	Old       any
+	New       any
+	Sensitive bool
+
+	// Unverifiable reports whether the API doesn't return the field, so Old
+	// is unknown and New is sent regardless of the live value.
+	Unverifiable bool
+}
+
+// ReconcileChange is a change to a single resource of a project.
+type ReconcileChange struct {
+	// Resource is the kind of the resource, like "settings" or "label".
+	Resource string
+
+	// Name identifies the resource within its kind. It is empty for the
+	// settings and the push rules.
+	Name string
+
+	Action ReconcileAction
+	Fields []*ReconcileField
+
+	// Applied reports whether the change was applied successfully.
+	Applied bool
+
+	apply func(options ...RequestOptionFunc) error
+}
+
+func (c *ReconcileChange) String() string {
+	if c.Name == "" {
+		return fmt.Sprintf("%s %s", c.Action, c.Resource)
+	}
+	return fmt.Sprintf("%s %s %q", c.Action, c.Resource, c.Name)
+}
+
+// ProjectPlan is the list of changes reconciling a project with its desired
+// state.
+type ProjectPlan struct {
+	Changes []*ReconcileChange
+}
+
+// Empty reports whether the project is already in its desired state.
+func (p *ProjectPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String formats the plan for humans. Sensitive values are redacted.
+func (p *ProjectPlan) String() string {
+	var (
+		b      strings.Builder
+		counts = make(map[ReconcileAction]int)
+	)
+	for _, c := range p.Changes {
+		counts[c.Action]++
+
+		fmt.Fprintf(&b, "%s %s", reconcileActionSymbols[c.Action], c.Resource)
+		if c.Name != "" {
+			fmt.Fprintf(&b, " %q", c.Name)
+		}
+		b.WriteByte('\n')
+
+		for _, f := range c.Fields {
+			newValue := formatReconcileValue(f.New, f.Sensitive)
+			switch {
+			case c.Action == ReconcileCreate:
+				fmt.Fprintf(&b, "    %s: %s\n", f.Name, newValue)
+			case f.Unverifiable:
+				fmt.Fprintf(&b, "    %s: (unverifiable) -> %s\n", f.Name, newValue)
+			default:
+				fmt.Fprintf(&b, "    %s: %s -> %s\n", f.Name, formatReconcileValue(f.Old, f.Sensitive), newValue)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to replace, %d to delete.\n",
+		counts[ReconcileCreate], counts[ReconcileUpdate], counts[ReconcileReplace], counts[ReconcileDelete])
+
+	return b.String()
+}
+
+// Apply applies the changes in order. It stops at the first failing change
+// and returns its error; Applied reports which changes were applied before.
+func (p *ProjectPlan) Apply(options ...RequestOptionFunc) error {
+	for _, c := range p.Changes {
+		if c.Applied {
+			continue
+		}
+		if err := c.apply(options...); err != nil {
+			return fmt.Errorf("%s: %w", c, err)
+		}
+		c.Applied = true
+	}
+	return nil
+}
+
+func formatReconcileValue(v any, sensitive bool) string {
+	if sensitive {
+		return "(sensitive)"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// PlanProjectState compares the desired state of a project with its live
+// state and returns the changes needed to reconcile them. Nothing is changed
+// until the plan is applied.
+func (s *ProjectsService) PlanProjectState(pid any, state *ProjectState, options ...RequestOptionFunc) (*ProjectPlan, error) {
+	if state == nil {
+		return nil, errors.New("state cannot be nil")
+	}
+
+	r := &projectReconciler{client: s.client, pid: pid, options: options}
+	planners := []func(*ProjectState) ([]*ReconcileChange, error){
+		r.planSettings,
+		r.planPushRules,
+		r.planProtectedBranches,
+		r.planApprovalRules,
+		r.planHooks,
+		r.planVariables,
+		r.planLabels,
+	}
+
+	plan := new(ProjectPlan)
+	for _, planner := range planners {
+		changes, err := planner(state)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+// ReconcileProjectState plans the changes of a project like PlanProjectState
+// and applies them. The plan is returned even if applying it fails.
+func (s *ProjectsService) ReconcileProjectState(pid any, state *ProjectState, options ...RequestOptionFunc) (*ProjectPlan, error) {
+	plan, err := s.PlanProjectState(pid, state, options...)
+	if err != nil {
+		return nil, err
+	}
+	return plan, plan.Apply(options...)
+}
+
+// projectReconciler plans the changes of a single project.
+type projectReconciler struct {
+	client  *Client
+	pid     any
+	options []RequestOptionFunc
+}
+
+func (r *projectReconciler) planSettings(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.Settings == nil {
+		return nil, nil
+	}
+
+	project, _, err := r.client.Projects.GetProject(r.pid, nil, r.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := diffReconcileFields(state.Settings, project, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	unverifiable, err := unverifiableReconcileFields(state.Settings, project)
+	if err != nil {
+		return nil, err
+	}
+	fields = append(fields, unverifiable...)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return []*ReconcileChange{{
+		Resource: "settings",
+		Action:   ReconcileUpdate,
+		Fields:   fields,
+		apply: func(options ...RequestOptionFunc) error {
+			_, _, err := r.client.Projects.EditProject(r.pid, state.Settings, options...)
+			return err
+		},
+	}}, nil
+}
+
+func (r *projectReconciler) planPushRules(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.PushRules == nil {
+		return nil, nil
+	}
+
+	rules, _, err := r.client.Projects.GetProjectPushRules(r.pid, r.options...)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if rules == nil {
+		fields, err := reconcileCreateFields(state.PushRules, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []*ReconcileChange{{
+			Resource: "push_rules",
+			Action:   ReconcileCreate,
+			Fields:   fields,
+			apply: func(options ...RequestOptionFunc) error {
+				_, _, err := r.client.Projects.AddProjectPushRule(r.pid, state.PushRules, options...)
+				return err
+			},
+		}}, nil
+	}
+
+	fields, err := diffReconcileFields(state.PushRules, rules, nil, nil)
+	if err != nil || len(fields) == 0 {
+		return nil, err
+	}
+	opt, err := convertReconcileOptions[EditProjectPushRuleOptions](state.PushRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*ReconcileChange{{
+		Resource: "push_rules",
+		Action:   ReconcileUpdate,
+		Fields:   fields,
+		apply: func(options ...RequestOptionFunc) error {
+			_, _, err := r.client.Projects.EditProjectPushRule(r.pid, opt, options...)
+			return err
+		},
+	}}, nil
+}
+
+func (r *projectReconciler) planProtectedBranches(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.ProtectedBranches == nil {
+		return nil, nil
+	}
+
+	live, err := ScanAndCollect(func(p PaginationOptionFunc) ([]*ProtectedBranch, *Response, error) {
+		return r.client.ProtectedBranches.ListProtectedBranches(r.pid, nil, append(r.options[:len(r.options):len(r.options)], p)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (&reconcileCollection[*ProtectRepositoryBranchesOptions, *ProtectedBranch]{
+		resource: "protected_branch",
+		key: func(d *ProtectRepositoryBranchesOptions) (string, error) {
+			return requiredReconcileKey("protected branch", "name", d.Name)
+		},
+		liveKey: func(l *ProtectedBranch) string { return l.Name },
+		normalize: func(l *ProtectedBranch, m map[string]any) {
+			m["push_access_level"] = float64(roleAccessLevel(l.PushAccessLevels))
+			m["merge_access_level"] = float64(roleAccessLevel(l.MergeAccessLevels))
+			m["unprotect_access_level"] = float64(roleAccessLevel(l.UnprotectAccessLevels))
+		},
+		create: func(d *ProtectRepositoryBranchesOptions, options ...RequestOptionFunc) error {
+			_, _, err := r.client.ProtectedBranches.ProtectRepositoryBranches(r.pid, d, options...)
+			return err
+		},
+		update: func(d *ProtectRepositoryBranchesOptions, l *ProtectedBranch, options ...RequestOptionFunc) error {
+			_, _, err := r.client.ProtectedBranches.UpdateProtectedBranch(r.pid, l.Name, &UpdateProtectedBranchOptions{
+				AllowForcePush:            d.AllowForcePush,
+				CodeOwnerApprovalRequired: d.CodeOwnerApprovalRequired,
+				AllowedToPush:             roleAccessLevelChanges(l.PushAccessLevels, d.PushAccessLevel),
+				AllowedToMerge:            roleAccessLevelChanges(l.MergeAccessLevels, d.MergeAccessLevel),
+				AllowedToUnprotect:        roleAccessLevelChanges(l.UnprotectAccessLevels, d.UnprotectAccessLevel),
+			}, options...)
+			return err
+		},
+		delete: func(l *ProtectedBranch, options ...RequestOptionFunc) error {
+			_, err := r.client.ProtectedBranches.UnprotectRepositoryBranches(r.pid, l.Name, options...)
+			return err
+		},
+	}).plan(state.ProtectedBranches, live)
+}
+
+// roleAccessLevel returns the access level granted to a role, ignoring the
+// access granted to individual users, groups and deploy keys.
+func roleAccessLevel(levels []*BranchAccessDescription) AccessLevelValue {
+	for _, l := range levels {
+		if l.UserID == 0 && l.GroupID == 0 && l.DeployKeyID == 0 {
+			return l.AccessLevel
+		}
+	}
+	return NoPermissions
+}
+
+// roleAccessLevelChanges returns the entries replacing the access level granted
+// to a role with level, or nil if level is nil or already granted. Existing
+// entries are removed by their ID.
+func roleAccessLevelChanges(levels []*BranchAccessDescription, level *AccessLevelValue) *[]*BranchPermissionOptions {
+	if level == nil || roleAccessLevel(levels) == *level {
+		return nil
+	}
+
+	var changes []*BranchPermissionOptions
+	for _, l := range levels {
+		if l.UserID == 0 && l.GroupID == 0 && l.DeployKeyID == 0 {
+			changes = append(changes, &BranchPermissionOptions{ID: Ptr(l.ID), Destroy: Ptr(true)})
+		}
+	}
+	if *level != NoPermissions {
+		changes = append(changes, &BranchPermissionOptions{AccessLevel: level})
+	}
+
+	return &changes
+}
+
+func (r *projectReconciler) planApprovalRules(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.ApprovalRules == nil {
+		return nil, nil
+	}
+
+	live, err := ScanAndCollect(func(p PaginationOptionFunc) ([]*ProjectApprovalRule, *Response, error) {
+		return r.client.Projects.GetProjectApprovalRules(r.pid, nil, append(r.options[:len(r.options):len(r.options)], p)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (&reconcileCollection[*CreateProjectLevelRuleOptions, *ProjectApprovalRule]{
+		resource: "approval_rule",
+		key: func(d *CreateProjectLevelRuleOptions) (string, error) {
+			return requiredReconcileKey("approval rule", "name", d.Name)
+		},
+		liveKey: func(l *ProjectApprovalRule) string { return l.Name },
+		normalize: func(l *ProjectApprovalRule, m map[string]any) {
+			var userIDs, usernames, groupIDs, branchIDs []any
+			for _, u := range l.Users {
+				userIDs = append(userIDs, float64(u.ID))
+				usernames = append(usernames, u.Username)
+			}
+			for _, g := range l.Groups {
+				groupIDs = append(groupIDs, float64(g.ID))
+			}
+			for _, b := range l.ProtectedBranches {
+				branchIDs = append(branchIDs, float64(b.ID))
+			}
+			m["user_ids"] = userIDs
+			m["usernames"] = usernames
+			m["group_ids"] = groupIDs
+			m["protected_branch_ids"] = branchIDs
+		},
+		sets:    []string{"user_ids", "usernames", "group_ids", "protected_branch_ids"},
+		replace: []string{"rule_type", "report_type"},
+		create: func(d *CreateProjectLevelRuleOptions, options ...RequestOptionFunc) error {
+			_, _, err := r.client.Projects.CreateProjectApprovalRule(r.pid, d, options...)
+			return err
+		},
+		update: func(d *CreateProjectLevelRuleOptions, l *ProjectApprovalRule, options ...RequestOptionFunc) error {
+			opt, err := convertReconcileOptions[UpdateProjectLevelRuleOptions](d)
+			if err != nil {
+				return err
+			}
+			_, _, err = r.client.Projects.UpdateProjectApprovalRule(r.pid, l.ID, opt, options...)
+			return err
+		},
+		delete: func(l *ProjectApprovalRule, options ...RequestOptionFunc) error {
+			_, err := r.client.Projects.DeleteProjectApprovalRule(r.pid, l.ID, options...)
+			return err
+		},
+	}).plan(state.ApprovalRules, live)
+}
+
+func (r *projectReconciler) planHooks(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.Hooks == nil {
+		return nil, nil
+	}
+
+	live, err := ScanAndCollect(func(p PaginationOptionFunc) ([]*ProjectHook, *Response, error) {
+		return r.client.Projects.ListProjectHooks(r.pid, nil, append(r.options[:len(r.options):len(r.options)], p)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (&reconcileCollection[*AddProjectHookOptions, *ProjectHook]{
+		resource: "hook",
+		key: func(d *AddProjectHookOptions) (string, error) {
+			return requiredReconcileKey("hook", "url", d.URL)
+		},
+		liveKey: func(l *ProjectHook) string { return l.URL },
+		normalize: func(l *ProjectHook, m map[string]any) {
+			// The API doesn't return the values of custom headers.
+			delete(m, "custom_headers")
+		},
+		sensitive: []string{"token", "custom_headers"},
+		create: func(d *AddProjectHookOptions, options ...RequestOptionFunc) error {
+			_, _, err := r.client.Projects.AddProjectHook(r.pid, d, options...)
+			return err
+		},
+		update: func(d *AddProjectHookOptions, l *ProjectHook, options ...RequestOptionFunc) error {
+			opt, err := convertReconcileOptions[EditProjectHookOptions](d)
+			if err != nil {
+				return err
+			}
+			_, _, err = r.client.Projects.EditProjectHook(r.pid, l.ID, opt, options...)
+			return err
+		},
+		delete: func(l *ProjectHook, options ...RequestOptionFunc) error {
+			_, err := r.client.Projects.DeleteProjectHook(r.pid, l.ID, options...)
+			return err
+		},
+	}).plan(state.Hooks, live)
+}
+
+func (r *projectReconciler) planVariables(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.Variables == nil {
+		return nil, nil
+	}
+
+	live, err := ScanAndCollect(func(p PaginationOptionFunc) ([]*ProjectVariable, *Response, error) {
+		return r.client.ProjectVariables.ListVariables(r.pid, nil, append(r.options[:len(r.options):len(r.options)], p)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scope := func(s *string) string {
+		if s == nil || *s == "" {
+			return "*"
+		}
+		return *s
+	}
+	variableKey := func(key, scope string) string {
+		if scope == "*" {
+			return key
+		}
+		return fmt.Sprintf("%s (%s)", key, scope)
+	}
+
+	return (&reconcileCollection[*CreateProjectVariableOptions, *ProjectVariable]{
+		resource: "variable",
+		key: func(d *CreateProjectVariableOptions) (string, error) {
+			key, err := requiredReconcileKey("variable", "key", d.Key)
+			if err != nil {
+				return "", err
+			}
+			return variableKey(key, scope(d.EnvironmentScope)), nil
+		},
+		liveKey: func(l *ProjectVariable) string {
+			return variableKey(l.Key, scope(&l.EnvironmentScope))
+		},
+		normalize: func(l *ProjectVariable, m map[string]any) {
+			// The API doesn't return the values of hidden variables.
+			if l.Hidden {
+				delete(m, "value")
+			}
+		},
+		sensitive: []string{"value"},
+		create: func(d *CreateProjectVariableOptions, options ...RequestOptionFunc) error {
+			_, _, err := r.client.ProjectVariables.CreateVariable(r.pid, d, options...)
+			return err
+		},
+		update: func(d *CreateProjectVariableOptions, l *ProjectVariable, options ...RequestOptionFunc) error {
+			opt, err := convertReconcileOptions[UpdateProjectVariableOptions](d)
+			if err != nil {
+				return err
+			}
+			opt.Filter = &VariableFilter{EnvironmentScope: l.EnvironmentScope}
+			_, _, err = r.client.ProjectVariables.UpdateVariable(r.pid, l.Key, opt, options...)
+			return err
+		},
+		delete: func(l *ProjectVariable, options ...RequestOptionFunc) error {
+			_, err := r.client.ProjectVariables.RemoveVariable(r.pid, l.Key, &RemoveProjectVariableOptions{
+				Filter: &VariableFilter{EnvironmentScope: l.EnvironmentScope},
+			}, options...)
+			return err
+		},
+	}).plan(state.Variables, live)
+}
+
+func (r *projectReconciler) planLabels(state *ProjectState) ([]*ReconcileChange, error) {
+	if state.Labels == nil {
+		return nil, nil
+	}
+
+	labels, err := ScanAndCollect(func(p PaginationOptionFunc) ([]*Label, *Response, error) {
+		return r.client.Labels.ListLabels(r.pid, &ListLabelsOptions{IncludeAncestorGroups: Ptr(false)}, append(r.options[:len(r.options):len(r.options)], p)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var live []*Label
+	for _, l := range labels {
+		if l.IsProjectLabel {
+			live = append(live, l)
+		}
+	}
+
+	return (&reconcileCollection[*CreateLabelOptions, *Label]{
+		resource: "label",
+		key: func(d *CreateLabelOptions) (string, error) {
+			return requiredReconcileKey("label", "name", d.Name)
+		},
+		liveKey: func(l *Label) string { return l.Name },
+		create: func(d *CreateLabelOptions, options ...RequestOptionFunc) error {
+			_, _, err := r.client.Labels.CreateLabel(r.pid, d, options...)
+			return err
+		},
+		update: func(d *CreateLabelOptions, l *Label, options ...RequestOptionFunc) error {
+			opt, err := convertReconcileOptions[UpdateLabelOptions](d)
+			if err != nil {
+				return err
+			}
+			_, _, err = r.client.Labels.UpdateLabel(r.pid, l.ID, opt, options...)
+			return err
+		},
+		delete: func(l *Label, options ...RequestOptionFunc) error {
+			_, err := r.client.Labels.DeleteLabel(r.pid, l.ID, nil, options...)
+			return err
+		},
+	}).plan(state.Labels, live)
+}
+
+// reconcileCollection plans the changes reconciling a list of resources with
+// their desired state D, using their live state L.
+type reconcileCollection[D, L any] struct {
+	resource string
+	key      func(D) (string, error)
+	liveKey  func(L) string
+
+	// normalize optionally adds fields to the JSON representation of the
+	// live state, so they can be compared with the desired state.
+	normalize func(L, map[string]any)
+
+	// sets are the fields compared regardless of the order of their values.
+	sets []string
+
+	// replace are the fields that can't be updated. The resource is
+	// deleted and created again if they change.
+	replace []string
+
+	sensitive []string
+
+	create func(D, ...RequestOptionFunc) error
+	update func(D, L, ...RequestOptionFunc) error
+	delete func(L, ...RequestOptionFunc) error
+}
+
+func (c *reconcileCollection[D, L]) plan(desired []D, live []L) ([]*ReconcileChange, error) {
+	byKey := make(map[string]L, len(live))
+	for _, l := range live {
+		byKey[c.liveKey(l)] = l
+	}
+
+	var (
+		changes []*ReconcileChange
+		seen    = make(map[string]bool, len(desired))
+	)
+	for _, d := range desired {
+		key, err := c.key(d)
+		if err != nil {
+			return nil, err
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate %s %q", c.resource, key)
+		}
+		seen[key] = true
+
+		l, ok := byKey[key]
+		if !ok {
+			fields, err := reconcileCreateFields(d, c.sensitive)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, &ReconcileChange{
+				Resource: c.resource,
+				Name:     key,
+				Action:   ReconcileCreate,
+				Fields:   fields,
+				apply: func(options ...RequestOptionFunc) error {
+					return c.create(d, options...)
+				},
+			})
+			continue
+		}
+
+		var normalize func(map[string]any)
+		if c.normalize != nil {
+			normalize = func(m map[string]any) { c.normalize(l, m) }
+		}
+		fields, err := diffReconcileFields(d, l, normalize, c.sets)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		for _, f := range fields {
+			f.Sensitive = slices.Contains(c.sensitive, f.Name)
+		}
+
+		change := &ReconcileChange{
+			Resource: c.resource,
+			Name:     key,
+			Action:   ReconcileUpdate,
+			Fields:   fields,
+			apply: func(options ...RequestOptionFunc) error {
+				return c.update(d, l, options...)
+			},
+		}
+		if slices.ContainsFunc(fields, func(f *ReconcileField) bool { return slices.Contains(c.replace, f.Name) }) {
+			change.Action = ReconcileReplace
+			change.apply = func(options ...RequestOptionFunc) error {
+				if err := c.delete(l, options...); err != nil {
+					return err
+				}
+				return c.create(d, options...)
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for _, l := range live {
+		key := c.liveKey(l)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		changes = append(changes, &ReconcileChange{
+			Resource: c.resource,
+			Name:     key,
+			Action:   ReconcileDelete,
+			apply: func(options ...RequestOptionFunc) error {
+				return c.delete(l, options...)
+			},
+		})
+	}
+
+	return changes, nil
+}
+
+func requiredReconcileKey(resource, field string, v *string) (string, error) {
+	if v == nil || *v == "" {
+		return "", fmt.Errorf("%s without %s", resource, field)
+	}
+	return *v, nil
+}
+
+// convertReconcileOptions converts the options of a create call into the
+// options of the corresponding update call, which use the same field names.
+func convertReconcileOptions[T any](v any) (*T, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	t := new(T)
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// toJSONObject returns the JSON representation of v.
+func toJSONObject(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// reconcileCreateFields returns all fields set in desired.
+func reconcileCreateFields(desired any, sensitive []string) ([]*ReconcileField, error) {
+	want, err := toJSONObject(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*ReconcileField, 0, len(want))
+	for name, v := range want {
+		fields = append(fields, &ReconcileField{
+			Name:      name,
+			New:       v,
+			Sensitive: slices.Contains(sensitive, name),
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	return fields, nil
+}
+
+// diffReconcileFields returns the fields set in desired that differ from
+// live. Fields missing from the live state are not compared.
+func diffReconcileFields(desired, live any, normalize func(map[string]any), sets []string) ([]*ReconcileField, error) {
+	want, err := toJSONObject(desired)
+	if err != nil {
+		return nil, err
+	}
+	have, err := toJSONObject(live)
+	if err != nil {
+		return nil, err
+	}
+	if normalize != nil {
+		normalize(have)
+	}
+	for _, name := range sets {
+		sortJSONArray(want[name])
+		sortJSONArray(have[name])
+	}
+
+	var fields []*ReconcileField
+	for name, w := range want {
+		h, ok := have[name]
+		if !ok || jsonContains(h, w) {
+			continue
+		}
+		fields = append(fields, &ReconcileField{Name: name, Old: h, New: w})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	return fields, nil
+}
+
+// unverifiableReconcileFields returns the fields set in desired that are
+// missing from live, as the API doesn't return them.
+func unverifiableReconcileFields(desired, live any) ([]*ReconcileField, error) {
+	want, err := toJSONObject(desired)
+	if err != nil {
+		return nil, err
+	}
+	have, err := toJSONObject(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []*ReconcileField
+	for name, w := range want {
+		if _, ok := have[name]; !ok {
+			fields = append(fields, &ReconcileField{Name: name, New: w, Unverifiable: true})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	return fields, nil
+}
+
+// jsonContains reports whether the decoded JSON value have contains want.
+// Objects contain the fields of want which they have, all other values must
+// be equal.
+func jsonContains(have, want any) bool {
+	switch w := want.(type) {
+	case map[string]any:
+		h, ok := have.(map[string]any)
+		if !ok {
+			return false
+		}
+		for name, wv := range w {
+			if hv, ok := h[name]; ok && !jsonContains(hv, wv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		h, ok := have.([]any)
+		if !ok {
+			// The API returns empty lists as null.
+			return have == nil && len(w) == 0
+		}
+		if len(h) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !jsonContains(h[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	case nil:
+		if h, ok := have.([]any); ok {
+			return len(h) == 0
+		}
+		return have == nil
+	default:
+		return have == want
+	}
+}
+
+// sortJSONArray sorts a decoded JSON array of numbers or strings in place.
+func sortJSONArray(v any) {
+	a, ok := v.([]any)
+	if !ok {
+		return
+	}
+	sort.SliceStable(a, func(i, j int) bool {
+		if x, ok := a[i].(float64); ok {
+			if y, ok := a[j].(float64); ok {
+				return x < y
+			}
+		}
+		return fmt.Sprint(a[i]) < fmt.Sprint(a[j])
+	})
+}
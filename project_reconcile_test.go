@@ -0,0 +1,263 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupProjectState returns a client talking to a server with the live state
+// of project 1, and the list of changing requests sent to it.
+func setupProjectState(t *testing.T) (*Client, func() []string) {
+	t.Helper()
+	mux, client := setup(t)
+
+	live := map[string]string{
+		"/api/v4/projects/1":           `{"id": 1, "description": "old", "merge_method": "merge"}`,
+		"/api/v4/projects/1/push_rule": `null`,
+		"/api/v4/projects/1/protected_branches": `[
+			{"id": 1, "name": "main", "push_access_levels": [{"id": 11, "access_level": 40}, {"id": 12, "access_level": 30, "user_id": 5}], "merge_access_levels": [{"id": 13, "access_level": 40}]},
+			{"id": 2, "name": "old", "push_access_levels": [{"id": 21, "access_level": 40}]}
+		]`,
+		"/api/v4/projects/1/approval_rules": `[
+			{"id": 5, "name": "security", "approvals_required": 1, "users": [{"id": 2, "username": "b"}, {"id": 1, "username": "a"}]}
+		]`,
+		"/api/v4/projects/1/hooks": `[
+			{"id": 7, "url": "https://example.com/hook", "push_events": true, "custom_headers": [{"key": "X-Secret"}]}
+		]`,
+		"/api/v4/projects/1/variables": `[
+			{"key": "A", "value": "1", "environment_scope": "*"},
+			{"key": "H", "value": null, "hidden": true, "environment_scope": "production"}
+		]`,
+		"/api/v4/projects/1/labels": `[
+			{"id": 3, "name": "bug", "color": "#ff0000", "is_project_label": true},
+			{"id": 4, "name": "inherited", "color": "#00ff00", "is_project_label": false}
+		]`,
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			body, ok := live[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, body)
+			return
+		}
+
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestPlanProjectState(t *testing.T) {
+	t.Parallel()
+	client, requests := setupProjectState(t)
+
+	state := &ProjectState{
+		Settings: &EditProjectOptions{
+			Description: Ptr("new"),
+			MergeMethod: Ptr(NoFastForwardMerge),
+		},
+		PushRules: &AddProjectPushRuleOptions{DenyDeleteTag: Ptr(true)},
+		ProtectedBranches: []*ProtectRepositoryBranchesOptions{
+			{Name: Ptr("main"), PushAccessLevel: Ptr(DeveloperPermissions), MergeAccessLevel: Ptr(MaintainerPermissions)},
+			{Name: Ptr("release/*"), PushAccessLevel: Ptr(MaintainerPermissions)},
+		},
+		ApprovalRules: []*CreateProjectLevelRuleOptions{
+			{Name: Ptr("security"), ApprovalsRequired: Ptr(int64(2)), UserIDs: &[]int64{1, 2}},
+		},
+		Hooks: []*AddProjectHookOptions{
+			{URL: Ptr("https://example.com/hook"), PushEvents: Ptr(true), Token: Ptr("secret")},
+		},
+		Variables: []*CreateProjectVariableOptions{
+			{Key: Ptr("A"), Value: Ptr("2")},
+			{Key: Ptr("H"), Value: Ptr("hidden"), EnvironmentScope: Ptr("production")},
+		},
+		Labels: []*CreateLabelOptions{},
+	}
+
+	plan, err := client.Projects.PlanProjectState(1, state)
+	require.NoError(t, err)
+	assert.Empty(t, requests())
+
+	want := `~ settings
+    description: "old" -> "new"
++ push_rules
+    deny_delete_tag: true
+~ protected_branch "main"
+    push_access_level: 40 -> 30
++ protected_branch "release/*"
+    name: "release/*"
+    push_access_level: 40
+- protected_branch "old"
+~ approval_rule "security"
+    approvals_required: 1 -> 2
+~ variable "A"
+    value: (sensitive) -> (sensitive)
+- label "bug"
+Plan: 2 to create, 4 to update, 0 to replace, 2 to delete.
+`
+	assert.Equal(t, want, plan.String())
+
+	require.NoError(t, plan.Apply())
+	assert.Equal(t, []string{
+		"PUT /api/v4/projects/1",
+		"POST /api/v4/projects/1/push_rule",
+		"PATCH /api/v4/projects/1/protected_branches/main",
+		"POST /api/v4/projects/1/protected_branches",
+		"DELETE /api/v4/projects/1/protected_branches/old",
+		"PUT /api/v4/projects/1/approval_rules/5",
+		"PUT /api/v4/projects/1/variables/A",
+		"DELETE /api/v4/projects/1/labels/3",
+	}, requests())
+
+	for _, c := range plan.Changes {
+		assert.True(t, c.Applied, c.String())
+	}
+}
+
+func TestPlanProjectState_Unmanaged(t *testing.T) {
+	t.Parallel()
+	client, _ := setupProjectState(t)
+
+	plan, err := client.Projects.PlanProjectState(1, &ProjectState{
+		Settings: &EditProjectOptions{MergeMethod: Ptr(NoFastForwardMerge)},
+	})
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+	assert.Equal(t, "Plan: 0 to create, 0 to update, 0 to replace, 0 to delete.\n", plan.String())
+}
+
+func TestPlanProjectState_UnverifiableSettings(t *testing.T) {
+	t.Parallel()
+	client, _ := setupProjectState(t)
+
+	plan, err := client.Projects.PlanProjectState(1, &ProjectState{
+		Settings: &EditProjectOptions{
+			MergeMethod:            Ptr(NoFastForwardMerge),
+			ShowDefaultAwardEmojis: Ptr(false),
+			MirrorBranchRegex:      Ptr("^main$"),
+		},
+	})
+	require.NoError(t, err)
+	assert.False(t, plan.Empty())
+
+	want := `~ settings
+    mirror_branch_regex: (unverifiable) -> "^main$"
+    show_default_award_emojis: (unverifiable) -> false
+Plan: 0 to create, 1 to update, 0 to replace, 0 to delete.
+`
+	assert.Equal(t, want, plan.String())
+}
+
+func TestReconcileProjectState_ProtectedBranchAccessLevels(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("GET /api/v4/projects/1/protected_branches", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 1, "name": "main",
+			"push_access_levels": [{"id": 11, "access_level": 40}, {"id": 12, "access_level": 30, "user_id": 5}],
+			"merge_access_levels": [{"id": 13, "access_level": 40}],
+			"unprotect_access_levels": [{"id": 14, "access_level": 40}]
+		}]`)
+	})
+	mux.HandleFunc("PATCH /api/v4/projects/1/protected_branches/main", func(w http.ResponseWriter, r *http.Request) {
+		testBodyJSON(t, r, map[string]any{
+			"allowed_to_push": []any{
+				map[string]any{"id": 11.0, "_destroy": true},
+				map[string]any{"access_level": 30.0},
+			},
+			"allowed_to_merge": []any{
+				map[string]any{"id": 13.0, "_destroy": true},
+			},
+		})
+		fmt.Fprint(w, `{"id": 1, "name": "main"}`)
+	})
+
+	_, err := client.Projects.ReconcileProjectState(1, &ProjectState{
+		ProtectedBranches: []*ProtectRepositoryBranchesOptions{{
+			Name:                 Ptr("main"),
+			PushAccessLevel:      Ptr(DeveloperPermissions),
+			MergeAccessLevel:     Ptr(NoPermissions),
+			UnprotectAccessLevel: Ptr(MaintainerPermissions),
+		}},
+	})
+	require.NoError(t, err)
+}
+
+func TestPlanProjectState_InvalidState(t *testing.T) {
+	t.Parallel()
+	client, _ := setupProjectState(t)
+
+	_, err := client.Projects.PlanProjectState(1, &ProjectState{
+		Labels: []*CreateLabelOptions{{Name: Ptr("bug")}, {Name: Ptr("bug")}},
+	})
+	assert.EqualError(t, err, `duplicate label "bug"`)
+
+	_, err = client.Projects.PlanProjectState(1, &ProjectState{
+		Hooks: []*AddProjectHookOptions{{PushEvents: Ptr(true)}},
+	})
+	assert.EqualError(t, err, "hook without url")
+}
+
+func TestReconcileProjectState_StopsAtFailure(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("GET /api/v4/projects/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("POST /api/v4/projects/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message": {"color": ["must be a valid color code"]}}`)
+	})
+
+	plan, err := client.Projects.ReconcileProjectState(1, &ProjectState{
+		Labels: []*CreateLabelOptions{
+			{Name: Ptr("bug"), Color: Ptr("red")},
+			{Name: Ptr("feature"), Color: Ptr("blue")},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `create label "bug": `)
+	assert.True(t, HasStatusCode(err, http.StatusBadRequest))
+
+	require.Len(t, plan.Changes, 2)
+	assert.False(t, plan.Changes[0].Applied)
+	assert.False(t, plan.Changes[1].Applied)
+}
+
+func TestJSONContains(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, jsonContains(map[string]any{"a": 1.0, "b": "x"}, map[string]any{"a": 1.0}))
+	assert.True(t, jsonContains(map[string]any{"a": 1.0}, map[string]any{"c": true}))
+	assert.False(t, jsonContains(map[string]any{"a": 1.0}, map[string]any{"a": 2.0}))
+	assert.True(t, jsonContains(nil, []any{}))
+	assert.True(t, jsonContains([]any{}, nil))
+	assert.False(t, jsonContains([]any{1.0}, []any{1.0, 2.0}))
+	assert.False(t, jsonContains(map[string]any{}, "x"))
+}
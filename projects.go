@@ -320,6 +320,14 @@ type (
 		// GitLab API docs:
 		// https://docs.gitlab.com/api/project_starring/#list-users-who-starred-a-project
 		ListProjectStarrers(pid any, opts *ListProjectStarrersOptions, options ...RequestOptionFunc) ([]*ProjectStarrer, *Response, error)
+		// PlanProjectState compares the desired state of a project with its
+		// live state and returns the changes needed to reconcile them. Nothing
+		// is changed until the plan is applied.
+		PlanProjectState(pid any, state *ProjectState, options ...RequestOptionFunc) (*ProjectPlan, error)
+		// ReconcileProjectState plans the changes of a project like
+		// PlanProjectState and applies them. The plan is returned even if
+		// applying it fails.
+		ReconcileProjectState(pid any, state *ProjectState, options ...RequestOptionFunc) (*ProjectPlan, error)
 	}
 
 	// ProjectsService handles communication with the repositories related methods
@@ -0,0 +1,215 @@
+package gitlab
+
+import (
+	"bytes"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type (
+	// PyPIPackagesServiceInterface defines all the API methods for the PyPIPackagesService
+	PyPIPackagesServiceInterface interface {
+		// UploadPackage uploads a PyPI package file, like a wheel or a source
+		// distribution, to a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/pypi/#upload-a-package
+		UploadPackage(pid any, fileName string, content io.Reader, opt *UploadPyPIPackageOptions, options ...RequestOptionFunc) (*Response, error)
+
+		// DownloadPackageFile downloads a PyPI package file of a project,
+		// identified by its SHA256 checksum and file name. The file is streamed
+		// from the server, so the caller must close the returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/pypi/#download-a-package-file-from-a-project
+		DownloadPackageFile(pid any, sha256, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+
+		// ListSimpleIndex lists the packages in the simple index of a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/pypi/#project-level-simple-api-index
+		ListSimpleIndex(pid any, options ...RequestOptionFunc) ([]*PyPISimplePackage, *Response, error)
+
+		// ListGroupSimpleIndex lists the packages in the simple index of a
+		// group.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/pypi/#group-level-simple-api-index
+		ListGroupSimpleIndex(gid any, options ...RequestOptionFunc) ([]*PyPISimplePackage, *Response, error)
+
+		// GetSimplePackage lists the files of a package in the simple index of
+		// a project.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/pypi/#project-level-simple-api-entry-point
+		GetSimplePackage(pid any, packageName string, options ...RequestOptionFunc) ([]*PyPIPackageFile, *Response, error)
+
+		// GetGroupSimplePackage lists the files of a package in the simple
+		// index of a group.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/pypi/#group-level-simple-api-entry-point
+		GetGroupSimplePackage(gid any, packageName string, options ...RequestOptionFunc) ([]*PyPIPackageFile, *Response, error)
+	}
+
+	// PyPIPackagesService handles communication with the PyPI package
+	// registry related methods of the GitLab API.
+	//
+	// The PyPI API authenticates using HTTP basic authentication, with any
+	// token as password. Use WithBasicAuth for clients which don't use a
+	// personal access token.
+	//
+	// GitLab API docs:
+	// https://docs.gitlab.com/api/packages/pypi/
+	PyPIPackagesService struct {
+		client *Client
+	}
+)
+
+var _ PyPIPackagesServiceInterface = (*PyPIPackagesService)(nil)
+
+// PyPISimplePackage represents a package in a PyPI simple index.
+type PyPISimplePackage struct {
+	Name           string
+	URL            string
+	RequiresPython string
+}
+
+// PyPIPackageFile represents a file of a package in a PyPI simple index.
+type PyPIPackageFile struct {
+	FileName       string
+	URL            string
+	SHA256         string
+	RequiresPython string
+}
+
+// UploadPyPIPackageOptions represents the available UploadPackage() options.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/packages/pypi/#upload-a-package
+type UploadPyPIPackageOptions struct {
+	Name            *string `url:"name,omitempty" json:"name,omitempty"`
+	Version         *string `url:"version,omitempty" json:"version,omitempty"`
+	RequiresPython  *string `url:"requires_python,omitempty" json:"requires_python,omitempty"`
+	MetadataVersion *string `url:"metadata_version,omitempty" json:"metadata_version,omitempty"`
+	Summary         *string `url:"summary,omitempty" json:"summary,omitempty"`
+	Keywords        *string `url:"keywords,omitempty" json:"keywords,omitempty"`
+	AuthorEmail     *string `url:"author_email,omitempty" json:"author_email,omitempty"`
+	SHA256Digest    *string `url:"sha256_digest,omitempty" json:"sha256_digest,omitempty"`
+	MD5Digest       *string `url:"md5_digest,omitempty" json:"md5_digest,omitempty"`
+}
+
+func (s *PyPIPackagesService) UploadPackage(pid any, fileName string, content io.Reader, opt *UploadPyPIPackageOptions, options ...RequestOptionFunc) (*Response, error) {
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodPost),
+		withPath("projects/%s/packages/pypi", ProjectID{pid}),
+		withUpload(content, fileName, UploadContent),
+		withAPIOpts(opt),
+		withRequestOpts(options...),
+	)
+	return resp, err
+}
+
+func (s *PyPIPackagesService) DownloadPackageFile(pid any, sha256, fileName string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("projects/%s/packages/pypi/files/%s/%s", ProjectID{pid}, sha256, fileName),
+		nil,
+		options,
+	)
+}
+
+func (s *PyPIPackagesService) ListSimpleIndex(pid any, options ...RequestOptionFunc) ([]*PyPISimplePackage, *Response, error) {
+	return getPyPISimpleIndex(s.client, withPath("projects/%s/packages/pypi/simple", ProjectID{pid}), options)
+}
+
+func (s *PyPIPackagesService) ListGroupSimpleIndex(gid any, options ...RequestOptionFunc) ([]*PyPISimplePackage, *Response, error) {
+	return getPyPISimpleIndex(s.client, withPath("groups/%s/-/packages/pypi/simple", GroupID{gid}), options)
+}
+
+func (s *PyPIPackagesService) GetSimplePackage(pid any, packageName string, options ...RequestOptionFunc) ([]*PyPIPackageFile, *Response, error) {
+	return getPyPISimplePackage(s.client, withPath("projects/%s/packages/pypi/simple/%s", ProjectID{pid}, packageName), options)
+}
+
+func (s *PyPIPackagesService) GetGroupSimplePackage(gid any, packageName string, options ...RequestOptionFunc) ([]*PyPIPackageFile, *Response, error) {
+	return getPyPISimplePackage(s.client, withPath("groups/%s/-/packages/pypi/simple/%s", GroupID{gid}, packageName), options)
+}
+
+func getPyPISimpleIndex(client *Client, path doOption, options []RequestOptionFunc) ([]*PyPISimplePackage, *Response, error) {
+	links, resp, err := getPyPISimpleLinks(client, path, options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	packages := make([]*PyPISimplePackage, 0, len(links))
+	for _, l := range links {
+		packages = append(packages, &PyPISimplePackage{
+			Name:           l.text,
+			URL:            l.attrs["href"],
+			RequiresPython: l.attrs["data-requires-python"],
+		})
+	}
+
+	return packages, resp, nil
+}
+
+func getPyPISimplePackage(client *Client, path doOption, options []RequestOptionFunc) ([]*PyPIPackageFile, *Response, error) {
+	links, resp, err := getPyPISimpleLinks(client, path, options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	files := make([]*PyPIPackageFile, 0, len(links))
+	for _, l := range links {
+		u, fragment, _ := strings.Cut(l.attrs["href"], "#")
+		f := &PyPIPackageFile{
+			FileName:       l.text,
+			URL:            u,
+			RequiresPython: l.attrs["data-requires-python"],
+		}
+		if sum, ok := strings.CutPrefix(fragment, "sha256="); ok {
+			f.SHA256 = sum
+		}
+		files = append(files, f)
+	}
+
+	return files, resp, nil
+}
+
+var (
+	pypiSimpleLinkRegex = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+	pypiSimpleAttrRegex = regexp.MustCompile(`(?s)([a-zA-Z-]+)\s*=\s*"([^"]*)"`)
+)
+
+type pypiSimpleLink struct {
+	text  string
+	attrs map[string]string
+}
+
+// getPyPISimpleLinks gets a page of the PyPI simple API (PEP 503) and returns
+// the anchors it contains.
+func getPyPISimpleLinks(client *Client, path doOption, options []RequestOptionFunc) ([]pypiSimpleLink, *Response, error) {
+	buf, resp, err := do[bytes.Buffer](client,
+		path,
+		withRequestOpts(append(options[:len(options):len(options)], WithHeader("Accept", "text/html"))...),
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var links []pypiSimpleLink
+	for _, m := range pypiSimpleLinkRegex.FindAllStringSubmatch(buf.String(), -1) {
+		l := pypiSimpleLink{
+			text:  strings.TrimSpace(html.UnescapeString(m[2])),
+			attrs: make(map[string]string),
+		}
+		for _, a := range pypiSimpleAttrRegex.FindAllStringSubmatch(m[1], -1) {
+			l.attrs[strings.ToLower(a[1])] = html.UnescapeString(a[2])
+		}
+		links = append(links, l)
+	}
+
+	return links, resp, nil
+}
@@ -0,0 +1,130 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPyPIPackages_UploadPackage(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/pypi", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "gitlab-ci-token", user)
+		assert.Equal(t, "job-token", password)
+
+		assert.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "my.package", r.FormValue("name"))
+		assert.Equal(t, "0.0.1", r.FormValue("version"))
+		assert.Equal(t, ">=3.8", r.FormValue("requires_python"))
+
+		f, h, err := r.FormFile("content")
+		require.NoError(t, err)
+		defer f.Close()
+		assert.Equal(t, "my.package-0.0.1-py3-none-any.whl", h.Filename)
+		b, _ := io.ReadAll(f)
+		assert.Equal(t, "wheel", string(b))
+
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	resp, err := client.PyPIPackages.UploadPackage(1, "my.package-0.0.1-py3-none-any.whl", strings.NewReader("wheel"),
+		&UploadPyPIPackageOptions{
+			Name:           Ptr("my.package"),
+			Version:        Ptr("0.0.1"),
+			RequiresPython: Ptr(">=3.8"),
+		},
+		WithBasicAuth("gitlab-ci-token", "job-token"),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestPyPIPackages_DownloadPackageFile(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/pypi/files/abc123/my.package-0.0.1.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "sdist")
+	})
+
+	r, _, err := client.PyPIPackages.DownloadPackageFile(1, "abc123", "my.package-0.0.1.tar.gz")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "sdist", string(b))
+}
+
+func TestPyPIPackages_ListSimpleIndex(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/groups/2/-/packages/pypi/simple", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		assert.Equal(t, "text/html", r.Header.Get("Accept"))
+		fmt.Fprint(w, `<!DOCTYPE html>
+<html>
+  <head><title>Links for Group</title></head>
+  <body>
+    <h1>Links for Group</h1>
+    <a href="https://gitlab.example.com/api/v4/groups/2/-/packages/pypi/simple/my-package" data-requires-python="&gt;=3.8">my.package</a><br>
+    <a href="https://gitlab.example.com/api/v4/groups/2/-/packages/pypi/simple/other" data-requires-python="">other</a><br>
+  </body>
+</html>`)
+	})
+
+	packages, _, err := client.PyPIPackages.ListGroupSimpleIndex(2)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*PyPISimplePackage{
+		{
+			Name:           "my.package",
+			URL:            "https://gitlab.example.com/api/v4/groups/2/-/packages/pypi/simple/my-package",
+			RequiresPython: ">=3.8",
+		},
+		{
+			Name: "other",
+			URL:  "https://gitlab.example.com/api/v4/groups/2/-/packages/pypi/simple/other",
+		},
+	}, packages)
+}
+
+func TestPyPIPackages_GetSimplePackage(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/pypi/simple/my-package", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `<!DOCTYPE html><html><head><title>Links for my.package</title></head><body><h1>Links for my.package</h1>`+
+			`<a href="https://gitlab.example.com/api/v4/projects/1/packages/pypi/files/5y57017232013c8ac80647f4ca153119/my.package-0.0.1-py3-none-any.whl#sha256=5y57017232013c8ac80647f4ca153119" data-requires-python="&gt;=3.6">my.package-0.0.1-py3-none-any.whl</a><br>`+
+			`<a href="https://gitlab.example.com/api/v4/projects/1/packages/pypi/files/9s9w01b0bcd52b709ec052084e33a5517ffca96f7728ddd9f8866a30cdf76f2/my.package-0.0.1.tar.gz#sha256=9s9w01b0bcd52b709ec052084e33a5517ffca96f7728ddd9f8866a30cdf76f2">my.package-0.0.1.tar.gz</a><br>`+
+			`</body></html>`)
+	})
+
+	files, _, err := client.PyPIPackages.GetSimplePackage(1, "my-package")
+	require.NoError(t, err)
+
+	require.Len(t, files, 2)
+	assert.Equal(t, &PyPIPackageFile{
+		FileName:       "my.package-0.0.1-py3-none-any.whl",
+		URL:            "https://gitlab.example.com/api/v4/projects/1/packages/pypi/files/5y57017232013c8ac80647f4ca153119/my.package-0.0.1-py3-none-any.whl",
+		SHA256:         "5y57017232013c8ac80647f4ca153119",
+		RequiresPython: ">=3.6",
+	}, files[0])
+	assert.Equal(t, "my.package-0.0.1.tar.gz", files[1].FileName)
+	assert.Equal(t, "9s9w01b0bcd52b709ec052084e33a5517ffca96f7728ddd9f8866a30cdf76f2", files[1].SHA256)
+	assert.Empty(t, files[1].RequiresPython)
+}
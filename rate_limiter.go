@@ -0,0 +1,292 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	headerRateRemaining = "Ratelimit-Remaining"
+	headerRetryAfter    = "Retry-After"
+)
+
+// RateLimitObserver is an optional interface a RateLimiter can implement to
+// be notified of every HTTP response received by the client, including the
+// responses of retried attempts. This allows the limiter to adapt to the rate
+// limit headers returned by GitLab.
+type RateLimitObserver interface {
+	ObserveResponse(resp *http.Response)
+}
+
+// RateLimitState is the rate limit state reported by GitLab in the headers of
+// a response.
+//
+// GitLab docs:
+// https://docs.gitlab.com/administration/settings/user_and_ip_rate_limits/#response-headers
+type RateLimitState struct {
+	// Remaining is the number of requests left until Reset. It is only
+	// meaningful if Reset is set.
+	Remaining int
+
+	// Reset is the time the rate limit window resets, or the zero time if
+	// the response contained no rate limit headers.
+	Reset time.Time
+
+	// RetryAfter is the time until which no requests should be sent, as
+	// requested by the Retry-After header, or the zero time.
+	RetryAfter time.Time
+}
+
+// parseRateLimitState parses the rate limit headers of resp. It returns false
+// if resp contains none of them.
+func parseRateLimitState(resp *http.Response, now time.Time) (RateLimitState, bool) {
+	var state RateLimitState
+
+	remaining, errRemaining := strconv.Atoi(resp.Header.Get(headerRateRemaining))
+	reset, errReset := strconv.ParseInt(resp.Header.Get(headerRateReset), 10, 64)
+	if errRemaining == nil && errReset == nil && reset > 0 {
+		state.Remaining = remaining
+		state.Reset = time.Unix(reset, 0)
+	}
+
+	if v := resp.Header.Get(headerRetryAfter); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			state.RetryAfter = now.Add(time.Duration(seconds) * time.Second)
+		} else if t, err := http.ParseTime(v); err == nil {
+			state.RetryAfter = t
+		}
+	}
+
+	return state, !state.Reset.IsZero() || !state.RetryAfter.IsZero()
+}
+
+// RateLimitBackend stores the rate limit state shared by AdaptiveRateLimiters.
+// Implementations that store the state outside of the process allow the
+// limiters of multiple processes, using the same credentials, to share a
+// single rate limit budget.
+type RateLimitBackend interface {
+	// Reserve takes a request from the budget of key, unless no more than
+	// reserve requests are left. It returns how long to wait before trying
+	// again, or 0 if the request can be sent.
+	Reserve(ctx context.Context, key string, reserve int) (time.Duration, error)
+
+	// Update records the state reported by GitLab for key.
+	Update(ctx context.Context, key string, state RateLimitState) error
+}
+
+// AdaptiveRateLimiterOptions represents the available options for
+// NewAdaptiveRateLimiter.
+type AdaptiveRateLimiterOptions struct {
+	// Key identifies the rate limit budget in the backend. All limiters
+	// using the same credentials against the same instance should use the
+	// same key. Defaults to "default".
+	Key string
+
+	// Backend stores the shared state. Defaults to a new
+	// MemoryRateLimitBackend, which only shares the state between the
+	// clients using this limiter.
+	Backend RateLimitBackend
+
+	// Reserve is the number of requests of each rate limit window that are
+	// never used, which leaves room for requests that are in flight or made
+	// by other tools using the same credentials.
+	Reserve int
+}
+
+// AdaptiveRateLimiter is a RateLimiter that continuously tracks the
+// RateLimit-Remaining, RateLimit-Reset and Retry-After headers returned by
+// GitLab. Once the budget of a rate limit window is used up, requests wait
+// until the window resets, instead of running into 429 Too Many Requests.
+//
+// A single AdaptiveRateLimiter can be shared by multiple clients using the
+// same credentials with WithCustomLimiter. To share the budget between
+// processes, use a backend like FileRateLimitBackend or
+// RedisRateLimitBackend.
+type AdaptiveRateLimiter struct {
+	key     string
+	backend RateLimitBackend
+	reserve int
+}
+
+var (
+	_ RateLimiter       = (*AdaptiveRateLimiter)(nil)
+	_ RateLimitObserver = (*AdaptiveRateLimiter)(nil)
+)
+
+// NewAdaptiveRateLimiter returns a new AdaptiveRateLimiter. The options can
+// be nil to use the defaults.
+func NewAdaptiveRateLimiter(opt *AdaptiveRateLimiterOptions) *AdaptiveRateLimiter {
+	l := &AdaptiveRateLimiter{key: "default"}
+	if opt != nil {
+		if opt.Key != "" {
+			l.key = opt.Key
+		}
+		l.backend = opt.Backend
+		l.reserve = opt.Reserve
+	}
+	if l.backend == nil {
+		l.backend = NewMemoryRateLimitBackend()
+	}
+	return l
+}
+
+// Wait blocks until a request can be sent without exceeding the rate limit,
+// or until ctx is done.
+func (l *AdaptiveRateLimiter) Wait(ctx context.Context) error {
+	for {
+		d, err := l.backend.Reserve(ctx, l.key, l.reserve)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return nil
+		}
+
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// ObserveResponse records the rate limit state of resp in the backend. As
+// failing to do so must not fail the request, backend errors are ignored.
+func (l *AdaptiveRateLimiter) ObserveResponse(resp *http.Response) {
+	state, ok := parseRateLimitState(resp, time.Now())
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	l.backend.Update(ctx, l.key, state)
+}
+
+// rateLimitWindow is the state of a rate limit budget, as kept by the
+// in-memory and file backends.
+type rateLimitWindow struct {
+	Remaining    int       `json:"remaining"`
+	Reset        time.Time `json:"reset"`
+	BlockedUntil time.Time `json:"blocked_until"`
+}
+
+// reserve implements RateLimitBackend.Reserve for a single window.
+func (w *rateLimitWindow) reserve(now time.Time, reserve int) time.Duration {
+	if now.Before(w.BlockedUntil) {
+		return w.BlockedUntil.Sub(now)
+	}
+
+	// Without a known budget for the current window, requests are not
+	// limited until GitLab reports a new state.
+	if !now.Before(w.Reset) {
+		return 0
+	}
+
+	if w.Remaining <= reserve {
+		return w.Reset.Sub(now)
+	}
+	w.Remaining--
+
+	return 0
+}
+
+// update implements RateLimitBackend.Update for a single window.
+func (w *rateLimitWindow) update(state RateLimitState) {
+	if state.RetryAfter.After(w.BlockedUntil) {
+		w.BlockedUntil = state.RetryAfter
+	}
+
+	if state.Reset.IsZero() {
+		return
+	}
+
+	// Responses of the same window can arrive out of order, and requests
+	// reserved locally might not be counted by GitLab yet, so keep the
+	// lowest number of remaining requests.
+	if state.Reset.Equal(w.Reset) {
+		w.Remaining = min(w.Remaining, state.Remaining)
+		return
+	}
+	if state.Reset.After(w.Reset) {
+		w.Remaining = state.Remaining
+		w.Reset = state.Reset
+	}
+}
+
+// MemoryRateLimitBackend is a RateLimitBackend keeping the state in memory,
+// so it is shared by all limiters in the process using it.
+type MemoryRateLimitBackend struct {
+	mu      sync.Mutex
+	windows map[string]*rateLimitWindow
+}
+
+var _ RateLimitBackend = (*MemoryRateLimitBackend)(nil)
+
+// NewMemoryRateLimitBackend returns a new MemoryRateLimitBackend.
+func NewMemoryRateLimitBackend() *MemoryRateLimitBackend {
+	return &MemoryRateLimitBackend{windows: make(map[string]*rateLimitWindow)}
+}
+
+// Reserve implements the RateLimitBackend interface.
+func (b *MemoryRateLimitBackend) Reserve(ctx context.Context, key string, reserve int) (time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.window(key).reserve(time.Now(), reserve), nil
+}
+
+// Update implements the RateLimitBackend interface.
+func (b *MemoryRateLimitBackend) Update(ctx context.Context, key string, state RateLimitState) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.window(key).update(state)
+	return nil
+}
+
+func (b *MemoryRateLimitBackend) window(key string) *rateLimitWindow {
+	w, ok := b.windows[key]
+	if !ok {
+		w = new(rateLimitWindow)
+		b.windows[key] = w
+	}
+	return w
+}
+
+// rateLimitObserverTransport reports every HTTP response to a
+// RateLimitObserver.
+type rateLimitObserverTransport struct {
+	next     http.RoundTripper
+	observer RateLimitObserver
+}
+
+func (t *rateLimitObserverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if resp != nil {
+		t.observer.ObserveResponse(resp)
+	}
+	return resp, err
+}
+
+// decorateHTTPClientTransportWithRateLimitObserver wraps the transport of the
+// HTTP client, if the limiter of the client observes the responses.
+func decorateHTTPClientTransportWithRateLimitObserver(c *Client) {
+	observer, ok := c.limiter.(RateLimitObserver)
+	if !ok {
+		return
+	}
+
+	next := c.client.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.client.HTTPClient.Transport = &rateLimitObserverTransport{next: next, observer: observer}
+}
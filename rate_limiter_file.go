@@ -0,0 +1,100 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// FileRateLimitBackend is a RateLimitBackend keeping the state in a local
+// file, which is locked for every access. This allows processes on the same
+// host to share a single rate limit budget.
+type FileRateLimitBackend struct {
+	path string
+}
+
+var _ RateLimitBackend = (*FileRateLimitBackend)(nil)
+
+// NewFileRateLimitBackend returns a new FileRateLimitBackend keeping the state
+// in the file at path. The file is created if it doesn't exist yet.
+func NewFileRateLimitBackend(path string) (*FileRateLimitBackend, error) {
+	if path == "" {
+		return nil, errors.New("rate limit state file path cannot be empty")
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return &FileRateLimitBackend{path: path}, nil
+}
+
+// Reserve implements the RateLimitBackend interface.
+func (b *FileRateLimitBackend) Reserve(ctx context.Context, key string, reserve int) (time.Duration, error) {
+	var d time.Duration
+	err := b.update(ctx, key, func(w *rateLimitWindow) {
+		d = w.reserve(time.Now(), reserve)
+	})
+	return d, err
+}
+
+// Update implements the RateLimitBackend interface.
+func (b *FileRateLimitBackend) Update(ctx context.Context, key string, state RateLimitState) error {
+	return b.update(ctx, key, func(w *rateLimitWindow) {
+		w.update(state)
+	})
+}
+
+// update applies fn to the window of key while holding the file lock.
+func (b *FileRateLimitBackend) update(ctx context.Context, key string, fn func(w *rateLimitWindow)) error {
+	f, err := os.OpenFile(b.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	unlock, err := lockFile(ctx, f)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	// A corrupt state file is reset, as the state is rebuilt from the next
+	// responses anyway.
+	windows := make(map[string]*rateLimitWindow)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &windows); err != nil {
+			windows = make(map[string]*rateLimitWindow)
+		}
+	}
+
+	w, ok := windows[key]
+	if !ok {
+		w = new(rateLimitWindow)
+		windows[key] = w
+	}
+	fn(w)
+
+	data, err = json.Marshal(windows)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt(data, 0)
+
+	return err
+}
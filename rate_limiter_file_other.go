@@ -0,0 +1,42 @@
+//go:build !unix
+
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// staleLockFileAge is the age after which a lock file is considered to be
+// left behind by a crashed process.
+const staleLockFileAge = 10 * time.Second
+
+// lockFile acquires an exclusive lock on f by creating a lock file next to
+// it, polling until the lock is acquired or ctx is done.
+func lockFile(ctx context.Context, f *os.File) (func(), error) {
+	path := f.Name() + ".lock"
+	for {
+		l, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			l.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockFileAge {
+			os.Remove(path)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
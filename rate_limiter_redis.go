@@ -0,0 +1,171 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RateLimitRedisClient is the subset of Redis commands used by
+// RedisRateLimitBackend. Clients of popular Redis libraries can be adapted
+// to it with a few lines of code, for example for go-redis:
+//
+//	type redisAdapter struct{ c *redis.Client }
+//
+//	func (a redisAdapter) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//		return a.c.Eval(ctx, script, keys, args...).Result()
+//	}
+type RateLimitRedisClient interface {
+	// Eval runs a Lua script with the given keys and arguments atomically.
+	Eval(ctx context.Context, script string, keys []string, args ...any) (any, error)
+}
+
+// RedisRateLimitBackend is a RateLimitBackend keeping the state in Redis, or
+// any other store implementing RateLimitRedisClient. This allows processes on
+// different hosts to share a single rate limit budget.
+//
+// The number of remaining requests and the reset time of the rate limit
+// window are stored in keys expiring when the window resets, and a
+// Retry-After is stored in a key expiring when requests can be sent again.
+// Like the other backends, updates only lower the number of remaining
+// requests within a window, as responses can arrive out of order.
+// Reservations and updates are applied atomically by Lua scripts, which
+// require Redis 6.0 or later.
+type RedisRateLimitBackend struct {
+	client RateLimitRedisClient
+	prefix string
+}
+
+var _ RateLimitBackend = (*RedisRateLimitBackend)(nil)
+
+// NewRedisRateLimitBackend returns a new RedisRateLimitBackend. All keys used
+// by the backend start with prefix.
+func NewRedisRateLimitBackend(client RateLimitRedisClient, prefix string) (*RedisRateLimitBackend, error) {
+	if client == nil {
+		return nil, errors.New("redis client cannot be nil")
+	}
+	return &RedisRateLimitBackend{client: client, prefix: prefix}, nil
+}
+
+// redisRateLimitReserveScript implements RedisRateLimitBackend.Reserve like
+// rateLimitWindow.reserve. A request is only granted, and the remaining
+// requests decremented, if it leaves at least the reserve for other
+// requests. Otherwise the time until the window resets is returned without
+// touching the budget, so waiting callers can't drain it.
+//
+// KEYS: remaining requests, blocked
+// ARGV: reserved requests
+const redisRateLimitReserveScript = `
+local blocked = redis.call('PTTL', KEYS[2])
+if blocked > 0 then
+	return blocked
+end
+
+local remaining = tonumber(redis.call('GET', KEYS[1]))
+if not remaining then
+	return 0
+end
+if remaining > tonumber(ARGV[1]) then
+	redis.call('DECR', KEYS[1])
+	return 0
+end
+
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl > 0 then
+	return ttl
+end
+return 0
+`
+
+// Reserve implements the RateLimitBackend interface.
+func (b *RedisRateLimitBackend) Reserve(ctx context.Context, key string, reserve int) (time.Duration, error) {
+	res, err := b.client.Eval(ctx, redisRateLimitReserveScript,
+		[]string{b.remainingKey(key), b.blockedKey(key)},
+		reserve,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	wait, ok := res.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result of rate limit script: %v", res)
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// redisRateLimitUpdateScript implements RedisRateLimitBackend.Update like
+// rateLimitWindow.update. The block is only extended, and the remaining
+// requests are replaced by a newer window, or lowered within the same window
+// without changing its expiry.
+//
+// KEYS: remaining requests, reset time, blocked
+// ARGV: remaining requests, reset time in Unix milliseconds, time until the
+// reset in milliseconds, time until the block ends in milliseconds
+const redisRateLimitUpdateScript = `
+local block = tonumber(ARGV[4])
+if block > 0 and redis.call('PTTL', KEYS[3]) < block then
+	redis.call('SET', KEYS[3], '1', 'PX', ARGV[4])
+end
+
+local ttl = tonumber(ARGV[3])
+if ttl <= 0 then
+	return 0
+end
+
+local reset = tonumber(redis.call('GET', KEYS[2]))
+local newReset = tonumber(ARGV[2])
+if reset and newReset < reset then
+	return 0
+end
+if reset and newReset == reset then
+	local remaining = tonumber(redis.call('GET', KEYS[1]))
+	if remaining and remaining <= tonumber(ARGV[1]) then
+		return 0
+	end
+	if remaining then
+		redis.call('SET', KEYS[1], ARGV[1], 'KEEPTTL')
+		return 1
+	end
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+return 1
+`
+
+// Update implements the RateLimitBackend interface.
+func (b *RedisRateLimitBackend) Update(ctx context.Context, key string, state RateLimitState) error {
+	now := time.Now()
+
+	var block, ttl, reset int64
+	if d := state.RetryAfter.Sub(now); d > 0 {
+		block = d.Milliseconds()
+	}
+	if d := state.Reset.Sub(now); d > 0 {
+		ttl = d.Milliseconds()
+		reset = state.Reset.UnixMilli()
+	}
+	if block <= 0 && ttl <= 0 {
+		return nil
+	}
+
+	_, err := b.client.Eval(ctx, redisRateLimitUpdateScript,
+		[]string{b.remainingKey(key), b.resetKey(key), b.blockedKey(key)},
+		state.Remaining, reset, ttl, block,
+	)
+	return err
+}
+
+func (b *RedisRateLimitBackend) remainingKey(key string) string {
+	return b.prefix + key + ":remaining"
+}
+
+func (b *RedisRateLimitBackend) resetKey(key string) string {
+	return b.prefix + key + ":reset"
+}
+
+func (b *RedisRateLimitBackend) blockedKey(key string) string {
+	return b.prefix + key + ":blocked"
+}
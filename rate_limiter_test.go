@@ -0,0 +1,404 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimitState(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+
+	state, ok := parseRateLimitState(&http.Response{Header: http.Header{
+		"Ratelimit-Remaining": {"42"},
+		"Ratelimit-Reset":     {"1700000030"},
+	}}, now)
+	require.True(t, ok)
+	assert.Equal(t, RateLimitState{Remaining: 42, Reset: time.Unix(1700000030, 0)}, state)
+
+	state, ok = parseRateLimitState(&http.Response{Header: http.Header{
+		"Retry-After": {"5"},
+	}}, now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(5*time.Second), state.RetryAfter)
+
+	state, ok = parseRateLimitState(&http.Response{Header: http.Header{
+		"Retry-After": {"Tue, 14 Nov 2023 22:13:40 GMT"},
+	}}, now)
+	require.True(t, ok)
+	assert.True(t, time.Unix(1700000020, 0).Equal(state.RetryAfter))
+
+	_, ok = parseRateLimitState(&http.Response{Header: http.Header{}}, now)
+	assert.False(t, ok)
+}
+
+func TestRateLimitWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	w := new(rateLimitWindow)
+
+	// Without any state, requests are not limited.
+	assert.Zero(t, w.reserve(now, 1))
+
+	w.update(RateLimitState{Remaining: 3, Reset: now.Add(time.Minute)})
+	assert.Zero(t, w.reserve(now, 1))
+	assert.Zero(t, w.reserve(now, 1))
+	assert.Equal(t, time.Minute, w.reserve(now, 1))
+
+	// A stale response of the same window doesn't increase the budget.
+	w.update(RateLimitState{Remaining: 3, Reset: now.Add(time.Minute)})
+	assert.Equal(t, 1, w.Remaining)
+
+	// Once the window is over, requests are allowed again.
+	assert.Zero(t, w.reserve(now.Add(time.Minute), 1))
+
+	// Retry-After blocks requests regardless of the budget.
+	w.update(RateLimitState{Remaining: 100, Reset: now.Add(2 * time.Minute), RetryAfter: now.Add(10 * time.Second)})
+	assert.Equal(t, 10*time.Second, w.reserve(now, 1))
+	assert.Zero(t, w.reserve(now.Add(10*time.Second), 1))
+	assert.Equal(t, 99, w.Remaining)
+}
+
+func TestAdaptiveRateLimiter_SharedBetweenClients(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/version", func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"version": "18.0.0"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	limiter := NewAdaptiveRateLimiter(nil)
+
+	first, err := NewClient("", WithBaseURL(server.URL), WithCustomLimiter(limiter), WithoutRetries())
+	require.NoError(t, err)
+	second, err := NewClient("", WithBaseURL(server.URL), WithCustomLimiter(limiter), WithoutRetries())
+	require.NoError(t, err)
+
+	_, resp, err := first.Version.GetVersion()
+	require.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	start := time.Now()
+	_, _, err = second.Version.GetVersion()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestAdaptiveRateLimiter_WaitCanceled(t *testing.T) {
+	t.Parallel()
+
+	backend := NewMemoryRateLimitBackend()
+	require.NoError(t, backend.Update(context.Background(), "default", RateLimitState{RetryAfter: time.Now().Add(time.Hour)}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := NewAdaptiveRateLimiter(&AdaptiveRateLimiterOptions{Backend: backend}).Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFileRateLimitBackend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	first, err := NewFileRateLimitBackend(path)
+	require.NoError(t, err)
+	second, err := NewFileRateLimitBackend(path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, first.Update(ctx, "token", RateLimitState{Remaining: 20, Reset: time.Now().Add(time.Hour)}))
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int32
+	)
+	for i := range 30 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			backend := first
+			if i%2 == 0 {
+				backend = second
+			}
+			d, err := backend.Reserve(ctx, "token", 0)
+			assert.NoError(t, err)
+			if d == 0 {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(20), allowed.Load())
+
+	// Other keys have their own budget.
+	d, err := second.Reserve(ctx, "other", 0)
+	require.NoError(t, err)
+	assert.Zero(t, d)
+
+	_, err = NewFileRateLimitBackend("")
+	assert.Error(t, err)
+}
+
+// fakeRedis is an in-memory stand-in for Redis implementing
+// RateLimitRedisClient.
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: make(map[string]string), expires: make(map[string]time.Time)}
+}
+
+func (r *fakeRedis) expire(key string) {
+	if e, ok := r.expires[key]; ok && !time.Now().Before(e) {
+		delete(r.values, key)
+		delete(r.expires, key)
+	}
+}
+
+func (r *fakeRedis) pttl(key string) int64 {
+	if e, ok := r.expires[key]; ok {
+		return time.Until(e).Milliseconds()
+	}
+	return -1
+}
+
+// Eval runs the scripts of RedisRateLimitBackend, emulating them in Go.
+func (r *fakeRedis) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		r.expire(key)
+	}
+
+	switch script {
+	case redisRateLimitReserveScript:
+		return r.reserve(keys, args[0].(int)), nil
+	case redisRateLimitUpdateScript:
+	default:
+		return nil, fmt.Errorf("unexpected script: %s", script)
+	}
+
+	remaining, reset, ttl, block := args[0].(int), args[1].(int64), args[2].(int64), args[3].(int64)
+	now := time.Now()
+
+	if e, ok := r.expires[keys[2]]; block > 0 && (!ok || e.Sub(now).Milliseconds() < block) {
+		r.values[keys[2]] = "1"
+		r.expires[keys[2]] = now.Add(time.Duration(block) * time.Millisecond)
+	}
+	if ttl <= 0 {
+		return int64(0), nil
+	}
+
+	if v, ok := r.values[keys[1]]; ok {
+		current, _ := strconv.ParseInt(v, 10, 64)
+		if reset < current {
+			return int64(0), nil
+		}
+		if v, ok := r.values[keys[0]]; ok && reset == current {
+			if n, _ := strconv.Atoi(v); n <= remaining {
+				return int64(0), nil
+			}
+			r.values[keys[0]] = strconv.Itoa(remaining)
+			return int64(1), nil
+		}
+	}
+
+	r.values[keys[0]] = strconv.Itoa(remaining)
+	r.values[keys[1]] = strconv.FormatInt(reset, 10)
+	r.expires[keys[0]] = now.Add(time.Duration(ttl) * time.Millisecond)
+	r.expires[keys[1]] = r.expires[keys[0]]
+	return int64(1), nil
+}
+
+func (r *fakeRedis) reserve(keys []string, reserve int) int64 {
+	if blocked := r.pttl(keys[1]); blocked > 0 {
+		return blocked
+	}
+
+	v, ok := r.values[keys[0]]
+	if !ok {
+		return 0
+	}
+	if n, _ := strconv.Atoi(v); n > reserve {
+		r.values[keys[0]] = strconv.Itoa(n - 1)
+		return 0
+	}
+	return max(r.pttl(keys[0]), 0)
+}
+
+func TestRedisRateLimitBackend(t *testing.T) {
+	t.Parallel()
+
+	redis := newFakeRedis()
+	backend, err := NewRedisRateLimitBackend(redis, "gitlab:")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Without any state, requests are not limited and no budget is left
+	// behind.
+	d, err := backend.Reserve(ctx, "token", 1)
+	require.NoError(t, err)
+	assert.Zero(t, d)
+	assert.Empty(t, redis.values)
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 5, Reset: reset}))
+	assert.Equal(t, "5", redis.values["gitlab:token:remaining"])
+	expires := redis.expires["gitlab:token:remaining"]
+
+	// Within a window, the remaining requests are only lowered and the
+	// expiry is kept.
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 3, Reset: reset}))
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 4, Reset: reset}))
+	assert.Equal(t, "3", redis.values["gitlab:token:remaining"])
+	assert.Equal(t, expires, redis.expires["gitlab:token:remaining"])
+
+	// Responses of an older window are ignored.
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 100, Reset: reset.Add(-time.Minute)}))
+	assert.Equal(t, "3", redis.values["gitlab:token:remaining"])
+
+	for range 2 {
+		d, err = backend.Reserve(ctx, "token", 1)
+		require.NoError(t, err)
+		assert.Zero(t, d)
+	}
+
+	// Waiting requests don't consume the budget.
+	for range 2 {
+		d, err = backend.Reserve(ctx, "token", 1)
+		require.NoError(t, err)
+		assert.Greater(t, d, 59*time.Minute)
+	}
+	assert.Equal(t, "1", redis.values["gitlab:token:remaining"])
+
+	require.NoError(t, backend.Update(ctx, "other", RateLimitState{RetryAfter: time.Now().Add(time.Minute)}))
+	d, err = backend.Reserve(ctx, "other", 1)
+	require.NoError(t, err)
+	assert.Greater(t, d, 59*time.Second)
+
+	// A shorter Retry-After doesn't shorten the block.
+	require.NoError(t, backend.Update(ctx, "other", RateLimitState{RetryAfter: time.Now().Add(time.Second)}))
+	d, err = backend.Reserve(ctx, "other", 1)
+	require.NoError(t, err)
+	assert.Greater(t, d, 59*time.Second)
+
+	// A new window replaces the remaining requests.
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 10, Reset: reset.Add(time.Hour)}))
+	assert.Equal(t, "10", redis.values["gitlab:token:remaining"])
+	assert.Greater(t, redis.expires["gitlab:token:remaining"], expires)
+
+	_, err = NewRedisRateLimitBackend(nil, "")
+	assert.Error(t, err)
+}
+
+// goRedisClient adapts a go-redis client to RateLimitRedisClient.
+type goRedisClient struct {
+	client *redis.Client
+}
+
+func (c goRedisClient) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	return c.client.Eval(ctx, script, keys, args...).Result()
+}
+
+func TestRedisRateLimitBackend_Redis(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	backend, err := NewRedisRateLimitBackend(goRedisClient{client}, "gitlab:")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	d, err := backend.Reserve(ctx, "token", 1)
+	require.NoError(t, err)
+	assert.Zero(t, d)
+	assert.Empty(t, server.Keys())
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 5, Reset: reset}))
+	remaining, err := server.Get("gitlab:token:remaining")
+	require.NoError(t, err)
+	assert.Equal(t, "5", remaining)
+	assert.Greater(t, server.TTL("gitlab:token:remaining"), 59*time.Minute)
+
+	// Lowering the remaining requests within a window keeps the expiry.
+	server.FastForward(10 * time.Minute)
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 3, Reset: reset}))
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 4, Reset: reset}))
+	server.CheckGet(t, "gitlab:token:remaining", "3")
+	assert.LessOrEqual(t, server.TTL("gitlab:token:remaining"), 50*time.Minute)
+
+	for range 2 {
+		d, err = backend.Reserve(ctx, "token", 1)
+		require.NoError(t, err)
+		assert.Zero(t, d)
+	}
+
+	// Waiting requests don't consume the budget.
+	for range 2 {
+		d, err = backend.Reserve(ctx, "token", 1)
+		require.NoError(t, err)
+		assert.Greater(t, d, 49*time.Minute)
+	}
+	server.CheckGet(t, "gitlab:token:remaining", "1")
+
+	require.NoError(t, backend.Update(ctx, "token", RateLimitState{Remaining: 10, Reset: reset, RetryAfter: time.Now().Add(time.Minute)}))
+	d, err = backend.Reserve(ctx, "token", 1)
+	require.NoError(t, err)
+	assert.Greater(t, d, 59*time.Second)
+	server.CheckGet(t, "gitlab:token:remaining", "1")
+
+	// Concurrent reservations never exceed the budget.
+	require.NoError(t, backend.Update(ctx, "other", RateLimitState{Remaining: 20, Reset: reset}))
+	var granted atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d, err := backend.Reserve(ctx, "other", 5)
+			assert.NoError(t, err)
+			if d == 0 {
+				granted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(15), granted.Load())
+	server.CheckGet(t, "gitlab:other:remaining", "5")
+}
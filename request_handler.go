@@ -93,6 +93,7 @@ func (n NoEscape) forPath() (string, error) {
 type doConfig struct {
 	method      string
 	path        string
+	pathFormat  string
 	apiOpts     any
 	requestOpts []RequestOptionFunc
 	upload      *uploadConfig
@@ -131,6 +132,7 @@ func withPath(path string, args ...any) doOption {
 			}
 		}
 		c.path = fmt.Sprintf(path, as...)
+		c.pathFormat = path
 
 		return nil
 	}
@@ -257,6 +259,10 @@ func do[T any](client *Client, opts ...doOption) (T, *Response, error) {
 		return z, nil, err
 	}
 
+	if (client.instrumentation != nil || client.retryPolicies != nil) && config.pathFormat != "" {
+		req = req.WithContext(contextWithRoute(req.Context(), config.pathFormat))
+	}
+
 	var (
 		as   T
 		resp *Response
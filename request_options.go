@@ -47,6 +47,10 @@ func copyContextValues(oldCtx context.Context, newCtx context.Context) context.C
 		newCtx = contextWithCheckRetry(newCtx, checkRetry)
 	}
 
+	if policy, ok := oldCtx.Value(retryPolicyKey{}).(*RetryPolicy); ok {
+		newCtx = context.WithValue(newCtx, retryPolicyKey{}, policy)
+	}
+
 	return newCtx
 }
 
@@ -197,6 +201,17 @@ func WithToken(authType AuthType, token string) RequestOptionFunc {
 	}
 }
 
+// WithBasicAuth takes a username and password which are then used to
+// authenticate this one request using HTTP basic authentication. This is
+// required by some package registry endpoints, like the PyPI API, which
+// expect the token as password.
+func WithBasicAuth(username, password string) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	}
+}
+
 // WithRequestRetry takes a `retryablehttp.CheckRetry` which is then used when making this one request.
 func WithRequestRetry(checkRetry retryablehttp.CheckRetry) RequestOptionFunc {
 	return func(req *retryablehttp.Request) error {
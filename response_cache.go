@@ -0,0 +1,295 @@
+package gitlab
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// ResponseCache stores API responses for conditional requests.
+//
+// When a ResponseCache is configured with WithResponseCache, the client sends
+// the ETag of a cached response in the If-None-Match header of GET requests.
+// If GitLab responds with 304 Not Modified, the cached response is returned
+// instead, and Response.FromCache is set. This saves bandwidth and, for
+// endpoints that support it, rate limit budget when polling data that rarely
+// changes.
+//
+// Cache keys are derived from the request URL, the Accept header and the
+// credentials of the request, so responses are never shared between
+// different users. Only decoded API responses are cached, streamed and raw
+// downloads never are, and neither are bodies larger than
+// DefaultResponseCacheMaxBodySize or the size set with
+// WithResponseCacheMaxBodySize. Implementations must be safe for concurrent
+// use.
+type ResponseCache interface {
+	// Get returns the cached response for key, if any.
+	Get(key string) (*CachedResponse, bool)
+
+	// Set stores the response for key. Errors should be handled by the
+	// implementation, as failing to cache a response is not fatal.
+	Set(key string, response *CachedResponse)
+}
+
+// DefaultResponseCacheMaxBodySize is the default size of the largest response
+// body stored in a ResponseCache.
+const DefaultResponseCacheMaxBodySize = 1 << 20
+
+// CachedResponse is a response stored in a ResponseCache.
+type CachedResponse struct {
+	ETag       string      `json:"etag"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// MemoryResponseCache is an in-memory ResponseCache, which evicts the least
+// recently used responses once it holds the maximum number of responses.
+type MemoryResponseCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryResponseCacheEntry struct {
+	key      string
+	response *CachedResponse
+}
+
+var _ ResponseCache = (*MemoryResponseCache)(nil)
+
+// NewMemoryResponseCache returns an in-memory ResponseCache holding at most
+// size responses. A size of 0 or less means the cache is unbounded. As the
+// client only caches bodies up to its maximum body size, the memory used by
+// the cache is bounded by size times that maximum.
+func NewMemoryResponseCache(size int) *MemoryResponseCache {
+	return &MemoryResponseCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get implements the ResponseCache interface.
+func (c *MemoryResponseCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+
+	return e.Value.(*memoryResponseCacheEntry).response, true
+}
+
+// Set implements the ResponseCache interface.
+func (c *MemoryResponseCache) Set(key string, response *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryResponseCacheEntry).response = response
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryResponseCacheEntry{key: key, response: response})
+
+	if c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryResponseCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (c *MemoryResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// DiskResponseCache is a ResponseCache storing each response as a file in a
+// directory, so cached responses survive restarts of the process.
+type DiskResponseCache struct {
+	dir string
+}
+
+var _ ResponseCache = (*DiskResponseCache)(nil)
+
+// NewDiskResponseCache returns a ResponseCache storing the responses in dir.
+// The directory is created if it doesn't exist yet.
+func NewDiskResponseCache(dir string) (*DiskResponseCache, error) {
+	if dir == "" {
+		return nil, errors.New("response cache directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskResponseCache{dir: dir}, nil
+}
+
+// Get implements the ResponseCache interface. Unreadable or corrupt cache
+// files are treated as a cache miss.
+func (c *DiskResponseCache) Get(key string) (*CachedResponse, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	response := new(CachedResponse)
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, false
+	}
+
+	return response, true
+}
+
+// Set implements the ResponseCache interface. The file is written
+// atomically, so concurrent readers never see a partially written response.
+// Failures to write the file are ignored.
+func (c *DiskResponseCache) Set(key string, response *CachedResponse) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return
+	}
+	if err := f.Close(); err != nil {
+		return
+	}
+
+	os.Rename(f.Name(), c.path(key))
+}
+
+// path returns the file of key. Keys are hashed, so they are always valid
+// file names.
+func (c *DiskResponseCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// responseCacheCredentialHeaders are the request headers identifying the
+// user a response belongs to. Sudo is included because an administrator
+// token impersonating different users gets different responses.
+var responseCacheCredentialHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Sudo",
+	AccessTokenHeaderName,
+	JobTokenHeaderName,
+	DeployTokenHeaderName,
+}
+
+// responseCacheKey returns the cache key for req, or an empty string if the
+// response of req must not be cached. Credentials are hashed, so they are
+// not stored in clear text by the cache.
+func responseCacheKey(req *retryablehttp.Request) string {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || req.Header.Get("If-None-Match") != "" {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, name := range responseCacheCredentialHeaders {
+		for _, v := range req.Header.Values(name) {
+			io.WriteString(h, name+": "+v+"\n")
+		}
+	}
+
+	return req.URL.String() + " " + req.Header.Get("Accept") + " " + hex.EncodeToString(h.Sum(nil))
+}
+
+// responseCacheStaleHeaders are the headers of a 304 Not Modified response
+// that must not replace the headers of the cached response.
+var responseCacheStaleHeaders = map[string]bool{
+	"Content-Encoding":  true,
+	"Content-Length":    true,
+	"Content-Type":      true,
+	"Transfer-Encoding": true,
+}
+
+// replayCachedResponse turns a 304 Not Modified response into the cached
+// response, updated with the headers of the 304 response.
+func replayCachedResponse(resp *http.Response, cached *CachedResponse) *http.Response {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	header := cached.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for k, v := range resp.Header {
+		if !responseCacheStaleHeaders[k] {
+			header[k] = v
+		}
+	}
+
+	replayed := *resp
+	replayed.StatusCode = cached.StatusCode
+	replayed.Status = fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode))
+	replayed.Header = header
+	replayed.Body = io.NopCloser(bytes.NewReader(cached.Body))
+	replayed.ContentLength = int64(len(cached.Body))
+
+	return &replayed
+}
+
+// storeCachedResponse stores resp in the cache if it has an ETag and its body
+// is at most maxBodySize bytes, and returns a response that can still be read
+// by the caller.
+func storeCachedResponse(cache ResponseCache, key string, resp *http.Response, maxBodySize int64) (*http.Response, error) {
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultResponseCacheMaxBodySize
+	}
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" || resp.StatusCode != http.StatusOK || resp.ContentLength > maxBodySize {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(body)) > maxBodySize {
+		// Pass the body through without caching it.
+		resp.Body = limitedReadCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cache.Set(key, &CachedResponse{
+		ETag:       etag,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+	})
+
+	return resp, nil
+}
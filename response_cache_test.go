@@ -0,0 +1,254 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/pipelines", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		requests.Add(1)
+
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.Header().Set("RateLimit-Remaining", "99")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `W/"v1"`)
+		w.Header().Set("X-Total", "1")
+		w.Header().Set("RateLimit-Remaining", "100")
+		fmt.Fprint(w, `[{"id": 1, "status": "success"}]`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cache := NewMemoryResponseCache(10)
+	client, err := NewClient("token", WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	pipelines, resp, err := client.Pipelines.ListProjectPipelines(1, nil)
+	require.NoError(t, err)
+	assert.False(t, resp.FromCache)
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, 1, cache.Len())
+
+	pipelines, resp, err = client.Pipelines.ListProjectPipelines(1, nil)
+	require.NoError(t, err)
+	assert.True(t, resp.FromCache)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(1), resp.TotalItems)
+	assert.Equal(t, "99", resp.Header.Get("RateLimit-Remaining"))
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, int64(1), pipelines[0].ID)
+	assert.Equal(t, "success", pipelines[0].Status)
+
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Responses are never shared between credentials.
+	other, err := NewClient("other-token", WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	_, resp, err = other.Pipelines.ListProjectPipelines(1, nil)
+	require.NoError(t, err)
+	assert.False(t, resp.FromCache)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestResponseCache_Sudo(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		requests.Add(1)
+
+		user := r.Header.Get("Sudo")
+		etag := fmt.Sprintf(`W/"%s"`, user)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"id": 1, "username": %q}`, user)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cache := NewMemoryResponseCache(10)
+	client, err := NewClient("admin-token", WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	alice, resp, err := client.Users.CurrentUser(WithSudo("alice"))
+	require.NoError(t, err)
+	assert.False(t, resp.FromCache)
+	assert.Equal(t, "alice", alice.Username)
+
+	bob, resp, err := client.Users.CurrentUser(WithSudo("bob"))
+	require.NoError(t, err)
+	assert.False(t, resp.FromCache)
+	assert.Equal(t, "bob", bob.Username)
+	assert.Equal(t, 2, cache.Len())
+
+	alice, resp, err = client.Users.CurrentUser(WithSudo("alice"))
+	require.NoError(t, err)
+	assert.True(t, resp.FromCache)
+	assert.Equal(t, "alice", alice.Username)
+
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestResponseCache_SkipsNonCacheableRequests(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1/pipeline", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `W/"v1"`)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/jobs/2/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `W/"v1"`)
+		fmt.Fprint(w, "artifacts")
+	})
+	mux.HandleFunc("/api/v4/projects/1/packages/helm/stable/charts/mychart-0.1.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `W/"v1"`)
+		fmt.Fprint(w, "chart")
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cache := NewMemoryResponseCache(10)
+	client, err := NewClient("token", WithBaseURL(server.URL), WithResponseCache(cache))
+	require.NoError(t, err)
+
+	_, _, err = client.Pipelines.CreatePipeline(1, &CreatePipelineOptions{Ref: Ptr("main")})
+	require.NoError(t, err)
+
+	// Streamed and raw downloads are never cached.
+	r, _, err := client.HelmCharts.DownloadChart(1, "stable", "mychart-0.1.0.tgz")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	_, _, err = client.Jobs.GetJobArtifacts(1, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestResponseCache_MaxBodySize(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `W/"v1"`)
+		fmt.Fprint(w, `{"id": 1, "description": "a long description"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/2", func(w http.ResponseWriter, r *http.Request) {
+		// Flushing before writing omits the Content-Length.
+		w.Header().Set("ETag", `W/"v1"`)
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, `{"id": 2, "description": "a long description"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/3", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `W/"v1"`)
+		fmt.Fprint(w, `{"id": 3}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cache := NewMemoryResponseCache(10)
+	client, err := NewClient("token",
+		WithBaseURL(server.URL),
+		WithResponseCache(cache),
+		WithResponseCacheMaxBodySize(16),
+	)
+	require.NoError(t, err)
+
+	for _, id := range []int64{1, 2} {
+		p, _, err := client.Projects.GetProject(id, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "a long description", p.Description)
+	}
+	assert.Equal(t, 0, cache.Len())
+
+	_, _, err = client.Projects.GetProject(3, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cache.Len())
+
+	_, err = NewClient("token", WithResponseCacheMaxBodySize(0))
+	assert.Error(t, err)
+}
+
+func TestMemoryResponseCache_Evicts(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMemoryResponseCache(2)
+	cache.Set("a", &CachedResponse{ETag: "a"})
+	cache.Set("b", &CachedResponse{ETag: "b"})
+
+	// Use "a", so "b" is the least recently used response.
+	_, ok := cache.Get("a")
+	require.True(t, ok)
+
+	cache.Set("c", &CachedResponse{ETag: "c"})
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+
+	r, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, "a", r.ETag)
+
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+}
+
+func TestDiskResponseCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cache, err := NewDiskResponseCache(dir)
+	require.NoError(t, err)
+
+	_, ok := cache.Get("key")
+	assert.False(t, ok)
+
+	want := &CachedResponse{
+		ETag:       `W/"v1"`,
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Total": {"1"}},
+		Body:       []byte(`[{"id": 1}]`),
+	}
+	cache.Set("key", want)
+
+	// A new cache on the same directory sees the stored response.
+	reopened, err := NewDiskResponseCache(dir)
+	require.NoError(t, err)
+
+	got, ok := reopened.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, want, got)
+
+	_, err = NewDiskResponseCache("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,357 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// IdempotencyKeyHeaderName is the header marking a request as safe to retry,
+// because the server or a proxy in front of it deduplicates requests with the
+// same key.
+const IdempotencyKeyHeaderName = "Idempotency-Key"
+
+// RetryPolicy declares how failed requests are retried. Which requests are
+// retried is still decided by the retry check of the client, but POST and
+// PATCH requests are only retried after a server error if they carry an
+// Idempotency-Key header, see WithIdempotencyKey.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. Defaults to the number of attempts configured for the client.
+	// Use 1 to disable retries.
+	MaxAttempts int
+
+	// WaitMin and WaitMax bound the wait between attempts, when using the
+	// default backoff. Default to the waits configured for the client.
+	WaitMin time.Duration
+	WaitMax time.Duration
+
+	// Backoff optionally replaces the backoff of the client.
+	Backoff retryablehttp.Backoff
+}
+
+// RouteRetryPolicy applies a RetryPolicy to the requests whose templated
+// route, like "projects/:id/merge_requests/:iid", matches Pattern. Patterns
+// use the syntax of path.Match, and also match all routes below them, so
+// "projects/*/merge_requests" matches all merge request endpoints of a
+// project.
+type RouteRetryPolicy struct {
+	Pattern string
+	Policy  *RetryPolicy
+}
+
+// RetryPolicies declares the retry policies of a client, see
+// WithRetryPolicies.
+//
+// The policy of a request is the first one found in this order: the policy
+// passed with WithRetryPolicy, the first matching route policy, the policy of
+// the request method, and the default policy.
+type RetryPolicies struct {
+	// Default is the policy of requests without a more specific policy.
+	Default *RetryPolicy
+
+	// Methods contains the policies per HTTP method.
+	Methods map[string]*RetryPolicy
+
+	// Routes contains the policies per endpoint class. The first matching
+	// policy is used.
+	Routes []RouteRetryPolicy
+
+	// Budget optionally limits the retries of the client, see
+	// NewRetryBudget.
+	Budget *RetryBudget
+}
+
+// RetryBudget limits the number of retries of a client relative to the number
+// of requests, so an outage of the server doesn't multiply the load it has to
+// handle.
+type RetryBudget struct {
+	ratio float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+// NewRetryBudget returns a RetryBudget earning ratio retries with every
+// request. For example, a ratio of 0.2 allows one retry per five requests.
+// The burst is the number of retries available initially, and the maximum
+// number of retries that can be saved up.
+func NewRetryBudget(ratio float64, burst int) *RetryBudget {
+	return &RetryBudget{
+		ratio:  ratio,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// deposit earns the retries of a request.
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, b.burst)
+}
+
+// withdraw takes a retry from the budget, and reports whether one was left.
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RetryAttempt describes a single attempt of a request.
+type RetryAttempt struct {
+	// StatusCode is the HTTP status code of the attempt, or 0 if no
+	// response was received.
+	StatusCode int
+
+	// Err is the transport error of the attempt, if any.
+	Err error
+
+	// Wait is the time waited before the next attempt.
+	Wait time.Duration
+}
+
+// RetryExhaustedError is returned when a request governed by a RetryPolicy
+// still failed after all its attempts, or when the RetryBudget didn't allow
+// another attempt.
+type RetryExhaustedError struct {
+	// Attempts lists all attempts of the request.
+	Attempts []RetryAttempt
+
+	// BudgetExhausted reports whether the request was not retried any
+	// further because the RetryBudget was used up.
+	BudgetExhausted bool
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+func (e *RetryExhaustedError) Error() string {
+	reason := "retries exhausted"
+	if e.BudgetExhausted {
+		reason = "retry budget exhausted"
+	}
+	return fmt.Sprintf("%s after %d attempt(s): %v", reason, len(e.Attempts), e.Err)
+}
+
+func (e *RetryExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// retryPolicyKey is the context key of the *RetryPolicy of a request.
+type retryPolicyKey struct{}
+
+// WithRetryPolicy sets the RetryPolicy of a single request, overriding the
+// policies of the client.
+func WithRetryPolicy(policy *RetryPolicy) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), retryPolicyKey{}, policy))
+		return nil
+	}
+}
+
+// WithIdempotencyKey marks a request as safe to retry by attaching an
+// Idempotency-Key header. If key is empty, a random key is generated for
+// every request the option is applied to, which is the same for all attempts
+// of that request.
+func WithIdempotencyKey(key string) RequestOptionFunc {
+	return func(req *retryablehttp.Request) error {
+		k := key
+		if k == "" {
+			b := make([]byte, 16)
+			if _, err := rand.Read(b); err != nil {
+				return err
+			}
+			k = hex.EncodeToString(b)
+		}
+		req.Header.Set(IdempotencyKeyHeaderName, k)
+		return nil
+	}
+}
+
+// retryPolicy returns the RetryPolicy of req, or nil if neither the client
+// nor the request declare one.
+func (c *Client) retryPolicy(req *retryablehttp.Request) *RetryPolicy {
+	if p, ok := req.Context().Value(retryPolicyKey{}).(*RetryPolicy); ok && p != nil {
+		return p
+	}
+
+	policies := c.retryPolicies
+	if policies == nil {
+		return nil
+	}
+
+	if len(policies.Routes) > 0 {
+		route := requestRoute(c, req)
+		for _, r := range policies.Routes {
+			if r.Policy != nil && matchRoute(r.Pattern, route) {
+				return r.Policy
+			}
+		}
+	}
+	if p := policies.Methods[req.Method]; p != nil {
+		return p
+	}
+	if policies.Default != nil {
+		return policies.Default
+	}
+
+	return &RetryPolicy{}
+}
+
+// matchRoute reports whether pattern matches route or one of its leading
+// segments.
+func matchRoute(pattern, route string) bool {
+	for {
+		if ok, _ := path.Match(pattern, route); ok {
+			return true
+		}
+		i := strings.LastIndexByte(route, '/')
+		if i < 0 {
+			return false
+		}
+		route = route[:i]
+	}
+}
+
+// retryState tracks the attempts of a request governed by a RetryPolicy.
+type retryState struct {
+	idempotent      bool
+	attempts        []RetryAttempt
+	wantedRetry     bool
+	budgetExhausted bool
+}
+
+// exhausted reports whether the request failed because no more attempts were
+// allowed.
+func (s *retryState) exhausted() bool {
+	return s.wantedRetry || s.budgetExhausted
+}
+
+// error wraps err into a RetryExhaustedError.
+func (s *retryState) error(err error) error {
+	return &RetryExhaustedError{
+		Attempts:        s.attempts,
+		BudgetExhausted: s.budgetExhausted,
+		Err:             err,
+	}
+}
+
+// newRetryableHTTPClientWithRetryPolicy returns a `retryablehttp.Client`
+// clone of itself, applying policy and recording the attempts in state.
+func (c *Client) newRetryableHTTPClientWithRetryPolicy(policy *RetryPolicy, check retryablehttp.CheckRetry, state *retryState) *retryablehttp.Client {
+	client := &retryablehttp.Client{
+		HTTPClient:      c.client.HTTPClient,
+		Logger:          c.client.Logger,
+		RetryWaitMin:    c.client.RetryWaitMin,
+		RetryWaitMax:    c.client.RetryWaitMax,
+		RetryMax:        c.client.RetryMax,
+		RequestLogHook:  c.client.RequestLogHook,
+		ResponseLogHook: c.client.ResponseLogHook,
+		ErrorHandler:    c.client.ErrorHandler,
+		PrepareRetry:    c.client.PrepareRetry,
+	}
+	if policy.MaxAttempts > 0 {
+		client.RetryMax = policy.MaxAttempts - 1
+	}
+	if policy.WaitMin > 0 {
+		client.RetryWaitMin = policy.WaitMin
+	}
+	if policy.WaitMax > 0 {
+		client.RetryWaitMax = policy.WaitMax
+	}
+
+	var budget *RetryBudget
+	if c.retryPolicies != nil {
+		budget = c.retryPolicies.Budget
+	}
+
+	client.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		attempt := RetryAttempt{Err: err}
+		if resp != nil {
+			attempt.StatusCode = resp.StatusCode
+		}
+		state.attempts = append(state.attempts, attempt)
+
+		retry, checkErr := check(ctx, resp, err)
+		if retry && !state.idempotent && mightHaveBeenProcessed(resp, err) {
+			retry = false
+		}
+		if retry && len(state.attempts) <= client.RetryMax && budget != nil && !budget.withdraw() {
+			retry = false
+			state.budgetExhausted = true
+		}
+		state.wantedRetry = retry
+
+		return retry, checkErr
+	}
+
+	backoff := policy.Backoff
+	switch {
+	case backoff != nil:
+	case policy.WaitMin > 0 || policy.WaitMax > 0:
+		backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				return rateLimitBackoff(min, max, attemptNum, resp)
+			}
+			return retryablehttp.LinearJitterBackoff(min, max, attemptNum, resp)
+		}
+	default:
+		backoff = c.client.Backoff
+	}
+	client.Backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		wait := backoff(min, max, attemptNum, resp)
+		if n := len(state.attempts); n > 0 {
+			state.attempts[n-1].Wait = wait
+		}
+		return wait
+	}
+
+	return client
+}
+
+// mightHaveBeenProcessed reports whether the server might have processed a
+// request that failed with resp or err. Without a response, this is the case
+// unless the connection was never established.
+func mightHaveBeenProcessed(resp *http.Response, err error) bool {
+	if resp != nil {
+		return resp.StatusCode >= 500
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return false
+	}
+	var opErr *net.OpError
+	return !errors.As(err, &opErr) || opErr.Op != "dial"
+}
+
+// isIdempotentRequest reports whether req can be sent again after the server
+// might have processed it.
+func isIdempotentRequest(req *http.Request) bool {
+	if req == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return req.Header.Get(IdempotencyKeyHeaderName) != ""
+	}
+	return true
+}
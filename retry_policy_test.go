@@ -0,0 +1,319 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRetryPolicy returns a client with the given options, talking to a
+// server that fails the first failures requests of every path with status.
+func setupRetryPolicy(t *testing.T, status, failures int, options ...ClientOptionFunc) (*Client, *atomic.Int32, *[]string) {
+	t.Helper()
+
+	var (
+		requests atomic.Int32
+		keys     []string
+	)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeaderName))
+		if int(requests.Add(1)) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "iid": 1}`)
+	})
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	options = append([]ClientOptionFunc{WithBaseURL(server.URL)}, options...)
+	client, err := NewClient("", options...)
+	require.NoError(t, err)
+
+	return client, &requests, &keys
+}
+
+var fastRetryPolicy = &RetryPolicy{WaitMin: time.Millisecond, WaitMax: 2 * time.Millisecond}
+
+func TestRetryPolicy_PostWithoutIdempotencyKeyIsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	client, requests, _ := setupRetryPolicy(t, http.StatusBadGateway, 1,
+		WithRetryPolicies(RetryPolicies{Default: fastRetryPolicy}),
+	)
+
+	_, resp, err := client.MergeRequests.CreateMergeRequest(1, &CreateMergeRequestOptions{Title: Ptr("Title")})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+
+	var exhausted *RetryExhaustedError
+	assert.False(t, errors.As(err, &exhausted))
+}
+
+func TestRetryPolicy_PostWithIdempotencyKeyIsRetried(t *testing.T) {
+	t.Parallel()
+
+	client, requests, keys := setupRetryPolicy(t, http.StatusBadGateway, 2,
+		WithRetryPolicies(RetryPolicies{Default: fastRetryPolicy}),
+	)
+
+	mr, _, err := client.MergeRequests.CreateMergeRequest(1, &CreateMergeRequestOptions{Title: Ptr("Title")}, WithIdempotencyKey(""))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), mr.IID)
+	assert.Equal(t, int32(3), requests.Load())
+
+	require.Len(t, *keys, 3)
+	assert.NotEmpty(t, (*keys)[0])
+	assert.Equal(t, (*keys)[0], (*keys)[1])
+	assert.Equal(t, (*keys)[0], (*keys)[2])
+}
+
+func TestWithIdempotencyKey_GeneratedPerRequest(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient("")
+	require.NoError(t, err)
+
+	option := WithIdempotencyKey("")
+	var keys []string
+	for range 2 {
+		req, err := client.NewRequest(http.MethodPost, "projects", nil, []RequestOptionFunc{option})
+		require.NoError(t, err)
+		keys = append(keys, req.Header.Get(IdempotencyKeyHeaderName))
+	}
+	assert.NotEmpty(t, keys[0])
+	assert.NotEqual(t, keys[0], keys[1])
+
+	req, err := client.NewRequest(http.MethodPost, "projects", nil, []RequestOptionFunc{WithIdempotencyKey("fixed")})
+	require.NoError(t, err)
+	assert.Equal(t, "fixed", req.Header.Get(IdempotencyKeyHeaderName))
+}
+
+// failAfterReadTransport reads the request body and fails the first failures
+// requests with a read timeout, as if the server had received the request
+// but the response got lost.
+type failAfterReadTransport struct {
+	failures int32
+	requests atomic.Int32
+}
+
+func (t *failAfterReadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	if t.requests.Add(1) <= t.failures {
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ETIMEDOUT}
+	}
+	return &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"id": 1, "iid": 1}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestRetryPolicy_TransportErrorAfterWrite(t *testing.T) {
+	t.Parallel()
+
+	newClient := func(t *testing.T, transport http.RoundTripper) *Client {
+		client, err := NewClient("",
+			WithBaseURL("https://gitlab.example.com"),
+			WithHTTPClient(&http.Client{Transport: transport}),
+			WithRetryPolicies(RetryPolicies{Default: fastRetryPolicy}),
+		)
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("post without idempotency key is not retried", func(t *testing.T) {
+		t.Parallel()
+
+		transport := &failAfterReadTransport{failures: 1}
+		client := newClient(t, transport)
+
+		_, _, err := client.MergeRequests.CreateMergeRequest(1, &CreateMergeRequestOptions{Title: Ptr("Title")})
+		require.ErrorIs(t, err, syscall.ETIMEDOUT)
+		assert.Equal(t, int32(1), transport.requests.Load())
+
+		var exhausted *RetryExhaustedError
+		assert.False(t, errors.As(err, &exhausted))
+	})
+
+	t.Run("post with idempotency key is retried", func(t *testing.T) {
+		t.Parallel()
+
+		transport := &failAfterReadTransport{failures: 1}
+		client := newClient(t, transport)
+
+		mr, _, err := client.MergeRequests.CreateMergeRequest(1, &CreateMergeRequestOptions{Title: Ptr("Title")}, WithIdempotencyKey(""))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), mr.IID)
+		assert.Equal(t, int32(2), transport.requests.Load())
+	})
+
+	t.Run("get is retried", func(t *testing.T) {
+		t.Parallel()
+
+		transport := &failAfterReadTransport{failures: 1}
+		client := newClient(t, transport)
+
+		_, _, err := client.MergeRequests.GetMergeRequest(1, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), transport.requests.Load())
+	})
+}
+
+func TestMightHaveBeenProcessed(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, mightHaveBeenProcessed(nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}))
+	assert.False(t, mightHaveBeenProcessed(nil, &net.DNSError{Err: "timeout", IsTimeout: true}))
+	assert.True(t, mightHaveBeenProcessed(nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}))
+	assert.True(t, mightHaveBeenProcessed(nil, io.ErrUnexpectedEOF))
+	assert.True(t, mightHaveBeenProcessed(&http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.False(t, mightHaveBeenProcessed(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+}
+
+func TestRetryPolicy_RetryExhaustedError(t *testing.T) {
+	t.Parallel()
+
+	client, requests, _ := setupRetryPolicy(t, http.StatusServiceUnavailable, 10,
+		WithRetryPolicies(RetryPolicies{
+			Methods: map[string]*RetryPolicy{
+				http.MethodGet: {MaxAttempts: 2, WaitMin: 5 * time.Millisecond, WaitMax: 5 * time.Millisecond},
+			},
+		}),
+	)
+
+	_, resp, err := client.MergeRequests.GetMergeRequest(1, 1, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+
+	var exhausted *RetryExhaustedError
+	require.ErrorAs(t, err, &exhausted)
+	assert.False(t, exhausted.BudgetExhausted)
+	require.Len(t, exhausted.Attempts, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, exhausted.Attempts[0].StatusCode)
+	assert.Equal(t, 5*time.Millisecond, exhausted.Attempts[0].Wait)
+	assert.Equal(t, http.StatusServiceUnavailable, exhausted.Attempts[1].StatusCode)
+	assert.Zero(t, exhausted.Attempts[1].Wait)
+
+	var errResp *ErrorResponse
+	assert.ErrorAs(t, err, &errResp)
+}
+
+func TestRetryPolicy_RoutePolicy(t *testing.T) {
+	t.Parallel()
+
+	client, requests, _ := setupRetryPolicy(t, http.StatusServiceUnavailable, 10,
+		WithRetryPolicies(RetryPolicies{
+			Default: fastRetryPolicy,
+			Routes: []RouteRetryPolicy{
+				{Pattern: "projects/*/merge_requests", Policy: &RetryPolicy{MaxAttempts: 1}},
+			},
+		}),
+	)
+
+	_, _, err := client.MergeRequests.GetMergeRequest(1, 1, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+
+	requests.Store(0)
+	_, _, err = client.Projects.GetProject(1, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(6), requests.Load())
+}
+
+func TestRetryPolicy_Budget(t *testing.T) {
+	t.Parallel()
+
+	client, requests, _ := setupRetryPolicy(t, http.StatusServiceUnavailable, 10,
+		WithRetryPolicies(RetryPolicies{
+			Default: &RetryPolicy{MaxAttempts: 3, WaitMin: time.Millisecond, WaitMax: time.Millisecond},
+			Budget:  NewRetryBudget(0, 1),
+		}),
+	)
+
+	_, _, err := client.Projects.GetProject(1, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	var exhausted *RetryExhaustedError
+	require.ErrorAs(t, err, &exhausted)
+	assert.True(t, exhausted.BudgetExhausted)
+	assert.Len(t, exhausted.Attempts, 2)
+}
+
+func TestRetryPolicy_PerRequest(t *testing.T) {
+	t.Parallel()
+
+	client, requests, _ := setupRetryPolicy(t, http.StatusServiceUnavailable, 10)
+
+	_, _, err := client.Projects.GetProject(1, nil, WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, WaitMin: time.Millisecond, WaitMax: time.Millisecond}))
+	require.Error(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	var exhausted *RetryExhaustedError
+	assert.ErrorAs(t, err, &exhausted)
+}
+
+func TestRetryPolicy_PerRequestWithContext(t *testing.T) {
+	t.Parallel()
+
+	client, requests, _ := setupRetryPolicy(t, http.StatusServiceUnavailable, 10)
+
+	_, _, err := client.Projects.GetProject(1, nil,
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 1}),
+		WithContext(context.Background()),
+	)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestMatchRoute(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, matchRoute("projects/*/merge_requests", "projects/:id/merge_requests"))
+	assert.True(t, matchRoute("projects/*/merge_requests", "projects/:id/merge_requests/:iid/notes"))
+	assert.True(t, matchRoute("projects/:id/jobs/*/trace", "projects/:id/jobs/:id/trace"))
+	assert.False(t, matchRoute("projects/*/merge_requests", "projects/:id/merge_requests_stats"))
+	assert.False(t, matchRoute("projects/*/merge_requests", "groups/:id/merge_requests"))
+}
+
+func TestRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	b := NewRetryBudget(0.5, 2)
+	assert.True(t, b.withdraw())
+	assert.True(t, b.withdraw())
+	assert.False(t, b.withdraw())
+
+	b.deposit()
+	assert.False(t, b.withdraw())
+	b.deposit()
+	assert.True(t, b.withdraw())
+
+	for range 10 {
+		b.deposit()
+	}
+	assert.True(t, b.withdraw())
+	assert.True(t, b.withdraw())
+	assert.False(t, b.withdraw())
+}
@@ -0,0 +1,392 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// RunnerJobsServiceInterface handles communication with the job related
+	// methods of the runner API, which GitLab Runner uses to pick up and run
+	// jobs. It can be used to implement custom runners.
+	//
+	// Jobs are requested with the authentication token of a runner. All other
+	// methods authenticate with the token of the job, which is part of the
+	// RunnerJob payload.
+	RunnerJobsServiceInterface interface {
+		// RequestJob requests a new job for a runner. If no job is available,
+		// a nil job is returned.
+		//
+		// GitLab holds the request open until a job is available, or the
+		// queue changed since LastUpdate. Set LastUpdate to the
+		// X-GitLab-Last-Update header of the previous response to
+		// long-poll for jobs.
+		RequestJob(opt *RequestRunnerJobOptions, options ...RequestOptionFunc) (*RunnerJob, *Response, error)
+		// UpdateJob updates the state of a job. Without a state, it only
+		// signals that the job is still running.
+		//
+		// GitLab answers with 202 Accepted while it is still verifying the
+		// trace of a finished job. The update should be repeated after the
+		// returned UpdateInterval then.
+		UpdateJob(jobID int64, opt *UpdateRunnerJobOptions, options ...RequestOptionFunc) (*RunnerJobStatus, *Response, error)
+		// AppendJobTrace appends content to the trace of a job, starting at
+		// the given byte offset.
+		//
+		// If offset doesn't match the length of the trace stored by GitLab,
+		// a 416 Range Not Satisfiable error is returned together with the
+		// status. Its TraceOffset is the stored length to continue from.
+		AppendJobTrace(jobID int64, token string, offset int64, content []byte, options ...RequestOptionFunc) (*RunnerJobStatus, *Response, error)
+		// UploadJobArtifacts uploads an artifacts archive of a job.
+		UploadJobArtifacts(jobID int64, token string, content io.Reader, filename string, opt *UploadRunnerJobArtifactsOptions, options ...RequestOptionFunc) (*Response, error)
+		// DownloadJobArtifacts downloads the artifacts archive of a job, like
+		// the artifacts of a RunnerJobDependency. The caller must close the
+		// returned reader.
+		DownloadJobArtifacts(jobID int64, token string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+	}
+
+	// RunnerJobsService handles communication with the job related methods
+	// of the runner API.
+	RunnerJobsService struct {
+		client *Client
+	}
+)
+
+var _ RunnerJobsServiceInterface = (*RunnerJobsService)(nil)
+
+// RunnerJob represents a job picked up by a runner.
+type RunnerJob struct {
+	ID            int64                   `json:"id"`
+	Token         string                  `json:"token"`
+	AllowGitFetch bool                    `json:"allow_git_fetch"`
+	JobInfo       RunnerJobInfo           `json:"job_info"`
+	GitInfo       RunnerJobGitInfo        `json:"git_info"`
+	RunnerInfo    RunnerJobRunnerInfo     `json:"runner_info"`
+	Variables     []*RunnerJobVariable    `json:"variables"`
+	Steps         []*RunnerJobStep        `json:"steps"`
+	Image         *RunnerJobImage         `json:"image"`
+	Services      []*RunnerJobImage       `json:"services"`
+	Artifacts     []*RunnerJobArtifact    `json:"artifacts"`
+	Cache         []*RunnerJobCache       `json:"cache"`
+	Credentials   []*RunnerJobCredentials `json:"credentials"`
+	Dependencies  []*RunnerJobDependency  `json:"dependencies"`
+	Features      RunnerJobFeatures       `json:"features"`
+}
+
+// RunnerJobInfo represents the job_info of a RunnerJob.
+type RunnerJobInfo struct {
+	ID                 int64   `json:"id"`
+	Name               string  `json:"name"`
+	Stage              string  `json:"stage"`
+	ProjectID          int64   `json:"project_id"`
+	ProjectName        string  `json:"project_name"`
+	TimeInQueueSeconds float64 `json:"time_in_queue_seconds"`
+}
+
+// RunnerJobGitInfo represents the git_info of a RunnerJob.
+type RunnerJobGitInfo struct {
+	RepoURL          string   `json:"repo_url"`
+	Ref              string   `json:"ref"`
+	Sha              string   `json:"sha"`
+	BeforeSha        string   `json:"before_sha"`
+	RefType          string   `json:"ref_type"`
+	Refspecs         []string `json:"refspecs"`
+	Depth            int64    `json:"depth"`
+	RepoObjectFormat string   `json:"repo_object_format"`
+}
+
+// RunnerJobRunnerInfo represents the runner_info of a RunnerJob. The timeout
+// is in seconds.
+type RunnerJobRunnerInfo struct {
+	Timeout          int64  `json:"timeout"`
+	RunnerSessionURL string `json:"runner_session_url"`
+}
+
+// RunnerJobVariable represents a CI/CD variable of a RunnerJob.
+type RunnerJobVariable struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Public bool   `json:"public"`
+	File   bool   `json:"file"`
+	Masked bool   `json:"masked"`
+	Raw    bool   `json:"raw"`
+}
+
+// RunnerJobStep represents a step of a RunnerJob, like the script or the
+// after_script. The timeout is in seconds.
+type RunnerJobStep struct {
+	Name         string   `json:"name"`
+	Script       []string `json:"script"`
+	Timeout      int64    `json:"timeout"`
+	When         string   `json:"when"`
+	AllowFailure bool     `json:"allow_failure"`
+}
+
+// RunnerJobImage represents the image or a service of a RunnerJob.
+type RunnerJobImage struct {
+	Name       string               `json:"name"`
+	Alias      string               `json:"alias"`
+	Command    []string             `json:"command"`
+	Entrypoint []string             `json:"entrypoint"`
+	Ports      []*RunnerJobPort     `json:"ports"`
+	PullPolicy []string             `json:"pull_policy"`
+	Variables  []*RunnerJobVariable `json:"variables"`
+}
+
+// RunnerJobPort represents an exposed port of a RunnerJobImage.
+type RunnerJobPort struct {
+	Number   int64  `json:"number"`
+	Protocol string `json:"protocol"`
+	Name     string `json:"name"`
+}
+
+// RunnerJobArtifact represents the artifacts a RunnerJob must upload.
+type RunnerJobArtifact struct {
+	Name           string   `json:"name"`
+	Untracked      bool     `json:"untracked"`
+	Paths          []string `json:"paths"`
+	Exclude        []string `json:"exclude"`
+	When           string   `json:"when"`
+	ArtifactType   string   `json:"artifact_type"`
+	ArtifactFormat string   `json:"artifact_format"`
+	ExpireIn       string   `json:"expire_in"`
+}
+
+// RunnerJobCache represents a cache of a RunnerJob.
+type RunnerJobCache struct {
+	Key          string   `json:"key"`
+	Untracked    bool     `json:"untracked"`
+	Policy       string   `json:"policy"`
+	Paths        []string `json:"paths"`
+	When         string   `json:"when"`
+	FallbackKeys []string `json:"fallback_keys"`
+}
+
+// RunnerJobCredentials represents credentials of a RunnerJob, like the
+// credentials of the container registry.
+type RunnerJobCredentials struct {
+	Type     string `json:"type"`
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RunnerJobDependency represents a job whose artifacts a RunnerJob depends
+// on. The artifacts can be downloaded with DownloadJobArtifacts using the ID
+// and token of the dependency.
+type RunnerJobDependency struct {
+	ID            int64                   `json:"id"`
+	Token         string                  `json:"token"`
+	Name          string                  `json:"name"`
+	ArtifactsFile *RunnerJobArtifactsFile `json:"artifacts_file"`
+}
+
+// RunnerJobArtifactsFile represents the artifacts archive of a
+// RunnerJobDependency.
+type RunnerJobArtifactsFile struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+}
+
+// RunnerJobFeatures represents the GitLab features a RunnerJob relies on.
+type RunnerJobFeatures struct {
+	TraceSections     bool     `json:"trace_sections"`
+	TokenMaskPrefixes []string `json:"token_mask_prefixes"`
+	FailureReasons    []string `json:"failure_reasons"`
+}
+
+// RunnerJobStatus represents the status of a job reported by GitLab in
+// response to UpdateJob and AppendJobTrace.
+type RunnerJobStatus struct {
+	// Status is the status of the job, like "running" or "canceled".
+	Status string
+
+	// UpdateInterval is the interval in which GitLab expects updates of the
+	// job, if reported.
+	UpdateInterval time.Duration
+
+	// TraceOffset is the length of the trace stored by GitLab. It is only
+	// set by AppendJobTrace.
+	TraceOffset int64
+}
+
+// RequestRunnerJobOptions represents the available RequestJob() options.
+type RequestRunnerJobOptions struct {
+	Token      *string                      `url:"token,omitempty" json:"token,omitempty"`
+	SystemID   *string                      `url:"system_id,omitempty" json:"system_id,omitempty"`
+	LastUpdate *string                      `url:"last_update,omitempty" json:"last_update,omitempty"`
+	Info       *RequestRunnerJobInfoOptions `url:"info,omitempty" json:"info,omitempty"`
+	Session    *RunnerJobSessionOptions     `url:"session,omitempty" json:"session,omitempty"`
+}
+
+// RequestRunnerJobInfoOptions represents the info of the runner in
+// RequestRunnerJobOptions.
+//
+// Features lists the features the runner supports, like "variables",
+// "image", "services", "artifacts", "cache", "upload_multiple_artifacts",
+// "refspecs", "masking", "raw_variables" or "multi_build_steps". GitLab only
+// assigns jobs whose requirements are met by the features.
+type RequestRunnerJobInfoOptions struct {
+	Name         *string                        `url:"name,omitempty" json:"name,omitempty"`
+	Version      *string                        `url:"version,omitempty" json:"version,omitempty"`
+	Revision     *string                        `url:"revision,omitempty" json:"revision,omitempty"`
+	Platform     *string                        `url:"platform,omitempty" json:"platform,omitempty"`
+	Architecture *string                        `url:"architecture,omitempty" json:"architecture,omitempty"`
+	Executor     *string                        `url:"executor,omitempty" json:"executor,omitempty"`
+	Shell        *string                        `url:"shell,omitempty" json:"shell,omitempty"`
+	Features     *map[string]bool               `url:"features,omitempty" json:"features,omitempty"`
+	Config       *RequestRunnerJobConfigOptions `url:"config,omitempty" json:"config,omitempty"`
+}
+
+// RequestRunnerJobConfigOptions represents the config of the runner in
+// RequestRunnerJobInfoOptions.
+type RequestRunnerJobConfigOptions struct {
+	GPUs *string `url:"gpus,omitempty" json:"gpus,omitempty"`
+}
+
+// RunnerJobSessionOptions represents the interactive web terminal session of
+// a runner in RequestRunnerJobOptions.
+type RunnerJobSessionOptions struct {
+	URL           *string `url:"url,omitempty" json:"url,omitempty"`
+	Certificate   *string `url:"certificate,omitempty" json:"certificate,omitempty"`
+	Authorization *string `url:"authorization,omitempty" json:"authorization,omitempty"`
+}
+
+func (s *RunnerJobsService) RequestJob(opt *RequestRunnerJobOptions, options ...RequestOptionFunc) (*RunnerJob, *Response, error) {
+	// No body is returned with 204 No Content if there is no job.
+	buf, resp, err := do[bytes.Buffer](s.client,
+		withMethod(http.MethodPost),
+		withPath("jobs/request"),
+		withAPIOpts(opt),
+		withRequestOpts(options...),
+	)
+	if err != nil || resp.StatusCode == http.StatusNoContent {
+		return nil, resp, err
+	}
+
+	job := new(RunnerJob)
+	if err := json.Unmarshal(buf.Bytes(), job); err != nil {
+		return nil, resp, err
+	}
+
+	return job, resp, nil
+}
+
+// UpdateRunnerJobOptions represents the available UpdateJob() options.
+//
+// Token is the token of the job. State is one of "running", "success" or
+// "failed".
+type UpdateRunnerJobOptions struct {
+	Token         *string                       `url:"token,omitempty" json:"token,omitempty"`
+	State         *string                       `url:"state,omitempty" json:"state,omitempty"`
+	FailureReason *string                       `url:"failure_reason,omitempty" json:"failure_reason,omitempty"`
+	ExitCode      *int64                        `url:"exit_code,omitempty" json:"exit_code,omitempty"`
+	Checksum      *string                       `url:"checksum,omitempty" json:"checksum,omitempty"`
+	Output        *UpdateRunnerJobOutputOptions `url:"output,omitempty" json:"output,omitempty"`
+}
+
+// UpdateRunnerJobOutputOptions represents the trace of a finished job in
+// UpdateRunnerJobOptions, which GitLab uses to verify the stored trace.
+type UpdateRunnerJobOutputOptions struct {
+	Checksum *string `url:"checksum,omitempty" json:"checksum,omitempty"`
+	Bytesize *int64  `url:"bytesize,omitempty" json:"bytesize,omitempty"`
+}
+
+func (s *RunnerJobsService) UpdateJob(jobID int64, opt *UpdateRunnerJobOptions, options ...RequestOptionFunc) (*RunnerJobStatus, *Response, error) {
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodPut),
+		withPath("jobs/%d", jobID),
+		withAPIOpts(opt),
+		withRequestOpts(options...),
+	)
+	if resp == nil {
+		return nil, resp, err
+	}
+
+	return newRunnerJobStatus(resp), resp, err
+}
+
+func (s *RunnerJobsService) AppendJobTrace(jobID int64, token string, offset int64, content []byte, options ...RequestOptionFunc) (*RunnerJobStatus, *Response, error) {
+	if len(content) == 0 {
+		return nil, nil, errors.New("trace content cannot be empty")
+	}
+
+	options = append(options[:len(options):len(options)], WithToken(JobToken, token))
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("jobs/%d/trace", jobID), nil, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The range is inclusive.
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset, offset+int64(len(content))-1))
+	if err := req.SetBody(content); err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if resp == nil {
+		return nil, resp, err
+	}
+
+	status := newRunnerJobStatus(resp)
+	status.TraceOffset = parseRunnerTraceRange(resp.Header.Get("Range"))
+
+	return status, resp, err
+}
+
+// UploadRunnerJobArtifactsOptions represents the available
+// UploadJobArtifacts() options.
+type UploadRunnerJobArtifactsOptions struct {
+	ArtifactType   *string `url:"artifact_type,omitempty" json:"artifact_type,omitempty"`
+	ArtifactFormat *string `url:"artifact_format,omitempty" json:"artifact_format,omitempty"`
+	ExpireIn       *string `url:"expire_in,omitempty" json:"expire_in,omitempty"`
+}
+
+func (s *RunnerJobsService) UploadJobArtifacts(jobID int64, token string, content io.Reader, filename string, opt *UploadRunnerJobArtifactsOptions, options ...RequestOptionFunc) (*Response, error) {
+	_, resp, err := do[none](s.client,
+		withMethod(http.MethodPost),
+		withPath("jobs/%d/artifacts", jobID),
+		withUpload(content, filename, UploadFile),
+		withAPIOpts(opt),
+		withRequestOpts(append(options[:len(options):len(options)], WithToken(JobToken, token))...),
+	)
+	return resp, err
+}
+
+func (s *RunnerJobsService) DownloadJobArtifacts(jobID int64, token string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("jobs/%d/artifacts", jobID),
+		nil,
+		append(options[:len(options):len(options)], WithToken(JobToken, token)),
+	)
+}
+
+// newRunnerJobStatus returns the job status reported in the headers of resp.
+func newRunnerJobStatus(resp *Response) *RunnerJobStatus {
+	status := &RunnerJobStatus{Status: resp.Header.Get("Job-Status")}
+	if s, err := strconv.Atoi(resp.Header.Get("X-GitLab-Trace-Update-Interval")); err == nil {
+		status.UpdateInterval = time.Duration(s) * time.Second
+	}
+	return status
+}
+
+// parseRunnerTraceRange returns the end of a trace range like "0-1024", which
+// is the length of the stored trace.
+func parseRunnerTraceRange(v string) int64 {
+	_, end, ok := strings.Cut(v, "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(end, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
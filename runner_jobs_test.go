@@ -0,0 +1,200 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunnerJobsService_RequestJob(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("POST /api/v4/jobs/request", func(w http.ResponseWriter, r *http.Request) {
+		testBodyJSON(t, r, map[string]any{
+			"token":       "glrt-token",
+			"last_update": "abc",
+			"info": map[string]any{
+				"name":     "custom-runner",
+				"executor": "custom",
+				"features": map[string]any{"variables": true, "artifacts": true},
+			},
+		})
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+			"id": 42,
+			"token": "job-token",
+			"allow_git_fetch": true,
+			"job_info": {"id": 42, "name": "build", "stage": "test", "project_id": 1, "project_name": "project"},
+			"git_info": {"repo_url": "https://gitlab.example.com/group/project.git", "ref": "main", "sha": "abc123", "ref_type": "branch", "refspecs": ["+refs/heads/main:refs/remotes/origin/main"], "depth": 20},
+			"runner_info": {"timeout": 3600},
+			"variables": [{"key": "CI_JOB_ID", "value": "42", "public": true, "masked": false}],
+			"steps": [{"name": "script", "script": ["make test"], "timeout": 3600, "when": "on_success", "allow_failure": false}],
+			"image": {"name": "golang:1.25", "entrypoint": [""]},
+			"services": [{"name": "postgres:16", "alias": "db", "ports": [{"number": 5432}]}],
+			"artifacts": [{"name": "coverage", "paths": ["cover.out"], "when": "on_success", "artifact_type": "archive", "artifact_format": "zip", "expire_in": "1 week"}],
+			"cache": [{"key": "go", "policy": "pull-push", "paths": [".cache"], "when": "on_success"}],
+			"credentials": [{"type": "registry", "url": "registry.example.com", "username": "gitlab-ci-token", "password": "job-token"}],
+			"dependencies": [{"id": 41, "token": "dep-token", "name": "compile", "artifacts_file": {"filename": "artifacts.zip", "size": 1024}}],
+			"features": {"trace_sections": true, "failure_reasons": ["script_failure"]}
+		}`)
+	})
+
+	job, _, err := client.RunnerJobs.RequestJob(&RequestRunnerJobOptions{
+		Token:      Ptr("glrt-token"),
+		LastUpdate: Ptr("abc"),
+		Info: &RequestRunnerJobInfoOptions{
+			Name:     Ptr("custom-runner"),
+			Executor: Ptr("custom"),
+			Features: &map[string]bool{"variables": true, "artifacts": true},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(42), job.ID)
+	assert.Equal(t, "job-token", job.Token)
+	assert.Equal(t, RunnerJobInfo{ID: 42, Name: "build", Stage: "test", ProjectID: 1, ProjectName: "project"}, job.JobInfo)
+	assert.Equal(t, "abc123", job.GitInfo.Sha)
+	assert.Equal(t, int64(3600), job.RunnerInfo.Timeout)
+	assert.Equal(t, []*RunnerJobVariable{{Key: "CI_JOB_ID", Value: "42", Public: true}}, job.Variables)
+	assert.Equal(t, []string{"make test"}, job.Steps[0].Script)
+	assert.Equal(t, "golang:1.25", job.Image.Name)
+	assert.Equal(t, []*RunnerJobPort{{Number: 5432}}, job.Services[0].Ports)
+	assert.Equal(t, "zip", job.Artifacts[0].ArtifactFormat)
+	assert.Equal(t, "pull-push", job.Cache[0].Policy)
+	assert.Equal(t, "registry", job.Credentials[0].Type)
+	assert.Equal(t, &RunnerJobArtifactsFile{Filename: "artifacts.zip", Size: 1024}, job.Dependencies[0].ArtifactsFile)
+	assert.Equal(t, RunnerJobFeatures{TraceSections: true, FailureReasons: []string{"script_failure"}}, job.Features)
+}
+
+func TestRunnerJobsService_RequestJob_NoJob(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("POST /api/v4/jobs/request", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitLab-Last-Update", "def")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	job, resp, err := client.RunnerJobs.RequestJob(&RequestRunnerJobOptions{Token: Ptr("glrt-token")})
+	require.NoError(t, err)
+	assert.Nil(t, job)
+	assert.Equal(t, "def", resp.Header.Get("X-GitLab-Last-Update"))
+}
+
+func TestRunnerJobsService_UpdateJob(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("PUT /api/v4/jobs/42", func(w http.ResponseWriter, r *http.Request) {
+		testBodyJSON(t, r, map[string]any{
+			"token":     "job-token",
+			"state":     "success",
+			"exit_code": float64(0),
+			"output":    map[string]any{"checksum": "crc32:abc", "bytesize": float64(11)},
+		})
+		w.Header().Set("Job-Status", "running")
+		w.Header().Set("X-GitLab-Trace-Update-Interval", "3")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	status, resp, err := client.RunnerJobs.UpdateJob(42, &UpdateRunnerJobOptions{
+		Token:    Ptr("job-token"),
+		State:    Ptr("success"),
+		ExitCode: Ptr(int64(0)),
+		Output: &UpdateRunnerJobOutputOptions{
+			Checksum: Ptr("crc32:abc"),
+			Bytesize: Ptr(int64(11)),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, &RunnerJobStatus{Status: "running", UpdateInterval: 3 * time.Second}, status)
+}
+
+func TestRunnerJobsService_AppendJobTrace(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("PATCH /api/v4/jobs/42/trace", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "job-token", r.Header.Get(JobTokenHeaderName))
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		assert.Equal(t, "5-10", r.Header.Get("Content-Range"))
+
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, " world", string(b))
+
+		w.Header().Set("Job-Status", "running")
+		w.Header().Set("Range", "0-11")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	status, _, err := client.RunnerJobs.AppendJobTrace(42, "job-token", 5, []byte(" world"))
+	require.NoError(t, err)
+	assert.Equal(t, &RunnerJobStatus{Status: "running", TraceOffset: 11}, status)
+}
+
+func TestRunnerJobsService_AppendJobTrace_RangeNotSatisfiable(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("PATCH /api/v4/jobs/42/trace", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Range", "0-3")
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		fmt.Fprint(w, `{"message": "416 Range Not Satisfiable"}`)
+	})
+
+	status, _, err := client.RunnerJobs.AppendJobTrace(42, "job-token", 5, []byte(" world"))
+	assert.True(t, HasStatusCode(err, http.StatusRequestedRangeNotSatisfiable))
+	require.NotNil(t, status)
+	assert.Equal(t, int64(3), status.TraceOffset)
+
+	_, _, err = client.RunnerJobs.AppendJobTrace(42, "job-token", 5, nil)
+	assert.Error(t, err)
+}
+
+func TestRunnerJobsService_Artifacts(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("POST /api/v4/jobs/42/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "job-token", r.Header.Get(JobTokenHeaderName))
+		assert.Equal(t, "zip", r.FormValue("artifact_format"))
+		assert.Equal(t, "archive", r.FormValue("artifact_type"))
+
+		f, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		assert.Equal(t, "artifacts.zip", header.Filename)
+		b, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "zip content", string(b))
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 42}`)
+	})
+	mux.HandleFunc("GET /api/v4/jobs/41/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "dep-token", r.Header.Get(JobTokenHeaderName))
+		fmt.Fprint(w, "dependency artifacts")
+	})
+
+	_, err := client.RunnerJobs.UploadJobArtifacts(42, "job-token", strings.NewReader("zip content"), "artifacts.zip", &UploadRunnerJobArtifactsOptions{
+		ArtifactType:   Ptr("archive"),
+		ArtifactFormat: Ptr("zip"),
+	})
+	require.NoError(t, err)
+
+	body, _, err := client.RunnerJobs.DownloadJobArtifacts(41, "dep-token")
+	require.NoError(t, err)
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "dependency artifacts", string(b))
+}
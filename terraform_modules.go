@@ -0,0 +1,224 @@
+package gitlab
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type (
+	// TerraformModulesServiceInterface defines all the API methods for the TerraformModulesService
+	TerraformModulesServiceInterface interface {
+		// PublishModule publishes a version of a Terraform module to a
+		// project. The content is the module archive, a gzipped tarball of
+		// the module sources.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/terraform-modules/#upload-module
+		PublishModule(pid any, moduleName, moduleSystem, moduleVersion string, content io.Reader, options ...RequestOptionFunc) (*Response, error)
+
+		// ListModuleVersions lists the available versions of a Terraform
+		// module. The namespace is the path of the top-level group of the
+		// project the module was published to.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/terraform-modules/#list-available-versions-for-a-specific-module
+		ListModuleVersions(namespace, moduleName, moduleSystem string, options ...RequestOptionFunc) ([]*TerraformModuleVersion, *Response, error)
+
+		// GetLatestModule gets the latest version of a Terraform module.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/terraform-modules/#get-latest-version-for-a-specific-module
+		GetLatestModule(namespace, moduleName, moduleSystem string, options ...RequestOptionFunc) (*TerraformModule, *Response, error)
+
+		// GetModule gets a specific version of a Terraform module.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/terraform-modules/#get-specific-version-for-a-specific-module
+		GetModule(namespace, moduleName, moduleSystem, moduleVersion string, options ...RequestOptionFunc) (*TerraformModule, *Response, error)
+
+		// GetModuleDownloadURL gets the URL to download a version of a
+		// Terraform module from, as returned in the X-Terraform-Get header. If
+		// moduleVersion is empty, the URL of the latest version is returned.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/terraform-modules/#get-url-for-downloading-latest-module-version
+		GetModuleDownloadURL(namespace, moduleName, moduleSystem, moduleVersion string, options ...RequestOptionFunc) (string, *Response, error)
+
+		// DownloadModule downloads the archive of a version of a Terraform
+		// module. The file is streamed from the server, so the caller must
+		// close the returned reader.
+		//
+		// GitLab API docs:
+		// https://docs.gitlab.com/api/packages/terraform-modules/#download-module
+		DownloadModule(namespace, moduleName, moduleSystem, moduleVersion string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error)
+	}
+
+	// TerraformModulesService handles communication with the Terraform
+	// module registry related methods of the GitLab API.
+	//
+	// GitLab API docs:
+	// https://docs.gitlab.com/api/packages/terraform-modules/
+	TerraformModulesService struct {
+		client *Client
+	}
+)
+
+var _ TerraformModulesServiceInterface = (*TerraformModulesService)(nil)
+
+// terraformGetHeader is the header containing the location of a module
+// archive, as defined by the Terraform module registry protocol.
+const terraformGetHeader = "X-Terraform-Get"
+
+// TerraformModule represents a version of a Terraform module.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/packages/terraform-modules/#get-specific-version-for-a-specific-module
+type TerraformModule struct {
+	Name       string                      `json:"name"`
+	Provider   string                      `json:"provider"`
+	Providers  []string                    `json:"providers"`
+	Root       TerraformModuleRoot         `json:"root"`
+	Source     string                      `json:"source"`
+	Submodules []*TerraformModuleSubmodule `json:"submodules"`
+	Version    string                      `json:"version"`
+	Versions   []string                    `json:"versions"`
+}
+
+// TerraformModuleVersion represents an available version of a Terraform
+// module.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/packages/terraform-modules/#list-available-versions-for-a-specific-module
+type TerraformModuleVersion struct {
+	Version    string                      `json:"version"`
+	Root       TerraformModuleRoot         `json:"root"`
+	Submodules []*TerraformModuleSubmodule `json:"submodules"`
+}
+
+// TerraformModuleRoot represents the root module of a Terraform module.
+type TerraformModuleRoot struct {
+	Providers    []*TerraformModuleProvider   `json:"providers"`
+	Dependencies []*TerraformModuleDependency `json:"dependencies"`
+}
+
+// TerraformModuleSubmodule represents a submodule of a Terraform module.
+type TerraformModuleSubmodule struct {
+	Path         string                       `json:"path"`
+	Providers    []*TerraformModuleProvider   `json:"providers"`
+	Dependencies []*TerraformModuleDependency `json:"dependencies"`
+}
+
+// TerraformModuleProvider represents a provider required by a Terraform
+// module.
+type TerraformModuleProvider struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// TerraformModuleDependency represents a module dependency of a Terraform
+// module.
+type TerraformModuleDependency struct {
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Version string `json:"version"`
+}
+
+func (s *TerraformModulesService) PublishModule(pid any, moduleName, moduleSystem, moduleVersion string, content io.Reader, options ...RequestOptionFunc) (*Response, error) {
+	project, err := parseID(pid)
+	if err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf(
+		"projects/%s/packages/terraform/modules/%s/%s/%s/file",
+		PathEscape(project),
+		PathEscape(moduleName),
+		PathEscape(moduleSystem),
+		PathEscape(moduleVersion),
+	)
+
+	// We need to create the request as a GET request to make sure the options
+	// are set correctly. After the request is created we will overwrite both
+	// the method and the body.
+	req, err := s.client.NewRequest(http.MethodGet, u, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Overwrite the method and body.
+	req.Method = http.MethodPut
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if err := req.SetBody(content); err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+func (s *TerraformModulesService) ListModuleVersions(namespace, moduleName, moduleSystem string, options ...RequestOptionFunc) ([]*TerraformModuleVersion, *Response, error) {
+	type moduleVersions struct {
+		Modules []struct {
+			Versions []*TerraformModuleVersion `json:"versions"`
+		} `json:"modules"`
+	}
+
+	mv, resp, err := do[moduleVersions](s.client,
+		withPath("packages/terraform/modules/v1/%s/%s/%s/versions", namespace, moduleName, moduleSystem),
+		withRequestOpts(options...),
+	)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var versions []*TerraformModuleVersion
+	for _, m := range mv.Modules {
+		versions = append(versions, m.Versions...)
+	}
+
+	return versions, resp, nil
+}
+
+func (s *TerraformModulesService) GetLatestModule(namespace, moduleName, moduleSystem string, options ...RequestOptionFunc) (*TerraformModule, *Response, error) {
+	return do[*TerraformModule](s.client,
+		withPath("packages/terraform/modules/v1/%s/%s/%s", namespace, moduleName, moduleSystem),
+		withRequestOpts(options...),
+	)
+}
+
+func (s *TerraformModulesService) GetModule(namespace, moduleName, moduleSystem, moduleVersion string, options ...RequestOptionFunc) (*TerraformModule, *Response, error) {
+	return do[*TerraformModule](s.client,
+		withPath("packages/terraform/modules/v1/%s/%s/%s/%s", namespace, moduleName, moduleSystem, moduleVersion),
+		withRequestOpts(options...),
+	)
+}
+
+func (s *TerraformModulesService) GetModuleDownloadURL(namespace, moduleName, moduleSystem, moduleVersion string, options ...RequestOptionFunc) (string, *Response, error) {
+	path := withPath("packages/terraform/modules/v1/%s/%s/%s/%s/download", namespace, moduleName, moduleSystem, moduleVersion)
+	if moduleVersion == "" {
+		path = withPath("packages/terraform/modules/v1/%s/%s/%s/download", namespace, moduleName, moduleSystem)
+	}
+
+	_, resp, err := do[none](s.client,
+		path,
+		withRequestOpts(options...),
+	)
+	if err != nil {
+		return "", resp, err
+	}
+
+	u := resp.Header.Get(terraformGetHeader)
+	if u == "" {
+		return "", resp, errors.New("response is missing the " + terraformGetHeader + " header")
+	}
+
+	return u, resp, nil
+}
+
+func (s *TerraformModulesService) DownloadModule(namespace, moduleName, moduleSystem, moduleVersion string, options ...RequestOptionFunc) (io.ReadCloser, *Response, error) {
+	return doDownload(s.client,
+		withPath("packages/terraform/modules/v1/%s/%s/%s/%s/file", namespace, moduleName, moduleSystem, moduleVersion),
+		nil,
+		options,
+	)
+}
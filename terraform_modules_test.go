@@ -0,0 +1,158 @@
+package gitlab
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformModules_PublishModule(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/packages/terraform/modules/hello-world/local/1.0.0/file", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		assert.Equal(t, "job-token", r.Header.Get(JobTokenHeaderName))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"message": "201 Created"}`)
+	})
+
+	resp, err := client.TerraformModules.PublishModule(1, "hello-world", "local", "1.0.0", strings.NewReader("archive"), WithToken(JobToken, "job-token"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestTerraformModules_ListModuleVersions(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local/versions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{
+			"modules": [
+				{
+					"versions": [
+						{
+							"version": "1.0.0",
+							"submodules": [],
+							"root": {
+								"dependencies": [],
+								"providers": [{"name": "local", "version": ""}]
+							}
+						},
+						{
+							"version": "0.9.3",
+							"submodules": [{"path": "modules/network", "providers": [], "dependencies": []}],
+							"root": {
+								"dependencies": [],
+								"providers": [{"name": "local", "version": ""}]
+							}
+						}
+					]
+				}
+			]
+		}`)
+	})
+
+	versions, _, err := client.TerraformModules.ListModuleVersions("group", "hello-world", "local")
+	require.NoError(t, err)
+
+	require.Len(t, versions, 2)
+	assert.Equal(t, "1.0.0", versions[0].Version)
+	assert.Equal(t, []*TerraformModuleProvider{{Name: "local"}}, versions[0].Root.Providers)
+	assert.Equal(t, "0.9.3", versions[1].Version)
+	require.Len(t, versions[1].Submodules, 1)
+	assert.Equal(t, "modules/network", versions[1].Submodules[0].Path)
+}
+
+func TestTerraformModules_GetModule(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	module := `{
+		"name": "hello-world/local",
+		"provider": "local",
+		"providers": ["local"],
+		"root": {"dependencies": []},
+		"source": "https://gitlab.example.com/group/hello-world",
+		"submodules": [],
+		"version": "%s",
+		"versions": ["1.0.0", "1.1.0"]
+	}`
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, module, "1.1.0")
+	})
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, module, "1.0.0")
+	})
+
+	latest, _, err := client.TerraformModules.GetLatestModule("group", "hello-world", "local")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", latest.Version)
+	assert.Equal(t, []string{"1.0.0", "1.1.0"}, latest.Versions)
+	assert.Equal(t, "https://gitlab.example.com/group/hello-world", latest.Source)
+
+	m, _, err := client.TerraformModules.GetModule("group", "hello-world", "local", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world/local", m.Name)
+	assert.Equal(t, "1.0.0", m.Version)
+}
+
+func TestTerraformModules_GetModuleDownloadURL(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local/download", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		w.Header().Set("X-Terraform-Get", "/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.1.0/file?token=&archive=tgz")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.0.0/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Terraform-Get", "/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.0.0/file?token=&archive=tgz")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local/2.0.0/download", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	u, _, err := client.TerraformModules.GetModuleDownloadURL("group", "hello-world", "local", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.1.0/file?token=&archive=tgz", u)
+
+	u, _, err = client.TerraformModules.GetModuleDownloadURL("group", "hello-world", "local", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.0.0/file?token=&archive=tgz", u)
+
+	_, _, err = client.TerraformModules.GetModuleDownloadURL("group", "hello-world", "local", "2.0.0")
+	assert.ErrorContains(t, err, "X-Terraform-Get")
+}
+
+func TestTerraformModules_DownloadModule(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/packages/terraform/modules/v1/group/hello-world/local/1.0.0/file", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, "archive")
+	})
+
+	r, _, err := client.TerraformModules.DownloadModule("group", "hello-world", "local", "1.0.0")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(b))
+}
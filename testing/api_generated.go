@@ -73,6 +73,7 @@ package testing
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=group_variables_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 GroupVariablesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=group_wikis_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 GroupWikisServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=groups_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 GroupsServiceInterface
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=helm_charts_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 HelmChartsServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=import_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 ImportServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=instance_clusters_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 InstanceClustersServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=instance_variables_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 InstanceVariablesServiceInterface
@@ -88,6 +89,7 @@ package testing
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=license_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 LicenseServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=license_templates_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 LicenseTemplatesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=markdown_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 MarkdownServiceInterface
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=maven_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 MavenPackagesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=member_roles_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 MemberRolesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=merge_request_approval_settings_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 MergeRequestApprovalSettingsServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=merge_request_approvals_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 MergeRequestApprovalsServiceInterface
@@ -100,6 +102,7 @@ package testing
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=namespaces_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 NamespacesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=notes_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 NotesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=notifications_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 NotificationSettingsServiceInterface
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=npm_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 NPMPackagesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 PackagesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=pages_domains_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 PagesDomainsServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=pages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 PagesServiceInterface
@@ -130,6 +133,7 @@ package testing
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=protected_environments_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 ProtectedEnvironmentsServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=protected_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 ProtectedPackagesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=protected_tags_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 ProtectedTagsServiceInterface
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=pypi_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 PyPIPackagesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=releaselinks_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 ReleaseLinksServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=releases_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 ReleasesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=repositories_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RepositoriesServiceInterface
@@ -144,6 +148,7 @@ package testing
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=runner_controller_scopes_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RunnerControllerScopesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=runner_controller_tokens_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RunnerControllerTokensServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=runner_controllers_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RunnerControllersServiceInterface
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=runner_jobs_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RunnerJobsServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=runners_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RunnersServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=search_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 SearchServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=secure_files_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 SecureFilesServiceInterface
@@ -154,6 +159,7 @@ package testing
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=snippets_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 SnippetsServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=system_hooks_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 SystemHooksServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=tags_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 TagsServiceInterface
+//go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=terraform_modules_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 TerraformModulesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=terraform_states_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 TerraformStatesServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=todos_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 TodosServiceInterface
 //go:generate go run go.uber.org/mock/mockgen@v0.6.0 -typed -destination=topics_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 TopicsServiceInterface
@@ -0,0 +1,466 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+// RedactedHeaderValue is the value that replaces the value of redacted
+// headers in recorded cassettes.
+const RedactedHeaderValue = "REDACTED"
+
+// ErrNoMatchingInteraction is returned by a replaying Recorder when a request
+// does not match any of the remaining interactions in the cassette.
+var ErrNoMatchingInteraction = errors.New("no matching interaction found in cassette")
+
+// RecorderMode defines whether a Recorder records or replays interactions.
+type RecorderMode int
+
+const (
+	// RecorderModeReplay serves previously recorded interactions from the
+	// cassette and never sends requests over the network.
+	RecorderModeReplay RecorderMode = iota
+	// RecorderModeRecord sends requests to the server and records every
+	// request and response pair into the cassette.
+	RecorderModeRecord
+)
+
+// String implements fmt.Stringer.
+func (m RecorderMode) String() string {
+	switch m {
+	case RecorderModeReplay:
+		return "replay"
+	case RecorderModeRecord:
+		return "record"
+	default:
+		return "RecorderMode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// Cassette holds a list of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []*CassetteInteraction `json:"interactions"`
+}
+
+// CassetteInteraction represents a single recorded request and response pair.
+type CassetteInteraction struct {
+	Request  *CassetteRequest  `json:"request"`
+	Response *CassetteResponse `json:"response"`
+}
+
+// CassetteRequest represents a recorded HTTP request.
+type CassetteRequest struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// CassetteResponse represents a recorded HTTP response.
+type CassetteResponse struct {
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// RequestMatcher reports whether an incoming request matches a recorded one.
+// Both requests are passed with their headers already redacted.
+type RequestMatcher func(req, recorded *CassetteRequest) bool
+
+// MatchMethod is a RequestMatcher that compares the HTTP methods.
+func MatchMethod(req, recorded *CassetteRequest) bool {
+	return req.Method == recorded.Method
+}
+
+// MatchURL is a RequestMatcher that compares the escaped paths and the query
+// parameters of the URLs. The scheme and host are ignored, so cassettes
+// recorded against one instance can be replayed against any base URL.
+func MatchURL(req, recorded *CassetteRequest) bool {
+	u1, err := url.Parse(req.URL)
+	if err != nil {
+		return false
+	}
+	u2, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	return u1.EscapedPath() == u2.EscapedPath() && u1.Query().Encode() == u2.Query().Encode()
+}
+
+// MatchBody is a RequestMatcher that compares the request bodies. JSON bodies
+// are compared semantically, all other bodies byte by byte.
+func MatchBody(req, recorded *CassetteRequest) bool {
+	b1, err := req.body()
+	if err != nil {
+		return false
+	}
+	b2, err := recorded.body()
+	if err != nil {
+		return false
+	}
+
+	var v1, v2 any
+	if json.Unmarshal(b1, &v1) == nil && json.Unmarshal(b2, &v2) == nil {
+		return reflect.DeepEqual(v1, v2)
+	}
+	return bytes.Equal(b1, b2)
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithRequestMatchers replaces the request matchers used to find a recorded
+// interaction while replaying. A request matches an interaction if all
+// matchers return true. The default matchers are MatchMethod and MatchURL.
+func WithRequestMatchers(matchers ...RequestMatcher) RecorderOption {
+	return func(r *Recorder) {
+		r.matchers = matchers
+	}
+}
+
+// WithRedactedHeaders replaces the request and response headers whose
+// values are redacted before an interaction is written to the cassette. The
+// default headers are PRIVATE-TOKEN, JOB-TOKEN, Authorization, Cookie and
+// Set-Cookie.
+func WithRedactedHeaders(headers ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.redactedHeaders = headers
+	}
+}
+
+// BodyScrubber rewrites a request or response body before it is written to
+// the cassette, for example to remove secrets. It must return the body
+// unchanged if there is nothing to scrub.
+type BodyScrubber func(body []byte) []byte
+
+// WithBodyScrubbers sets the scrubbers applied, in order, to the request and
+// response bodies of every interaction. Incoming requests are scrubbed the
+// same way while replaying, so MatchBody compares scrubbed bodies.
+func WithBodyScrubbers(scrubbers ...BodyScrubber) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbers = scrubbers
+	}
+}
+
+// ScrubJSONFields returns a BodyScrubber replacing the values of the given
+// fields of JSON bodies with RedactedHeaderValue, at any depth. Bodies that
+// are not JSON, or don't contain any of the fields, are returned unchanged.
+func ScrubJSONFields(fields ...string) BodyScrubber {
+	return func(body []byte) []byte {
+		var v any
+		if json.Unmarshal(body, &v) != nil {
+			return body
+		}
+		if !scrubJSONFields(v, fields) {
+			return body
+		}
+		scrubbed, err := json.Marshal(v)
+		if err != nil {
+			return body
+		}
+		return scrubbed
+	}
+}
+
+// scrubJSONFields redacts the given fields in v and reports whether any
+// field was found.
+func scrubJSONFields(v any, fields []string) bool {
+	var found bool
+	switch v := v.(type) {
+	case map[string]any:
+		for k, value := range v {
+			if slices.Contains(fields, k) {
+				v[k] = RedactedHeaderValue
+				found = true
+				continue
+			}
+			found = scrubJSONFields(value, fields) || found
+		}
+	case []any:
+		for _, value := range v {
+			found = scrubJSONFields(value, fields) || found
+		}
+	}
+	return found
+}
+
+// Recorder records HTTP interactions of a *gitlab.Client into a cassette
+// file, or replays them from it.
+//
+// The Recorder is hooked into the client using the gitlab.Interceptor
+// returned by Interceptor. In RecorderModeRecord, the recorded interactions
+// are written to the cassette when Save is called. In RecorderModeReplay,
+// requests are answered from the cassette in the order they were recorded,
+// each interaction being served at most once.
+//
+// Example:
+//
+//	func TestMyApp(t *testing.T) {
+//	    rec, err := testing.NewRecorder("testdata/my_app.json", testing.RecorderModeReplay)
+//	    require.NoError(t, err)
+//	    t.Cleanup(func() { require.NoError(t, rec.Save()) })
+//
+//	    client, err := gitlab.NewClient("token", gitlab.WithInterceptor(rec.Interceptor()))
+//	    require.NoError(t, err)
+//
+//	    // Use the client in your test
+//	}
+type Recorder struct {
+	path            string
+	mode            RecorderMode
+	matchers        []RequestMatcher
+	redactedHeaders []string
+	scrubbers       []BodyScrubber
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewRecorder creates a new Recorder for the cassette at the given path. In
+// RecorderModeReplay the cassette is loaded immediately and an error is
+// returned if it cannot be read.
+func NewRecorder(path string, mode RecorderMode, options ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:            path,
+		mode:            mode,
+		matchers:        []RequestMatcher{MatchMethod, MatchURL},
+		redactedHeaders: []string{"PRIVATE-TOKEN", "JOB-TOKEN", "Authorization", "Cookie", "Set-Cookie"},
+		cassette:        &Cassette{},
+	}
+	for _, opt := range options {
+		opt(r)
+	}
+
+	switch mode {
+	case RecorderModeRecord:
+	case RecorderModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid recorder mode %s", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Interceptor returns a gitlab.Interceptor that records or replays the
+// requests of the client it is registered with.
+func (r *Recorder) Interceptor() gitlab.Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if r.mode == RecorderModeReplay {
+				return r.replay(req)
+			}
+			return r.record(next, req)
+		})
+	}
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// parent directories if needed. It is a no-op in RecorderModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != RecorderModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	recorded, err := r.newCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := &CassetteInteraction{
+		Request: recorded,
+		Response: &CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     r.redactHeader(resp.Header),
+		},
+	}
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeCassetteBody(r.scrub(body))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	incoming, err := r.newCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matches(incoming, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		body, err := interaction.Response.body()
+		if err != nil {
+			return nil, fmt.Errorf("decoding recorded response body: %w", err)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoMatchingInteraction, req.Method, req.URL)
+}
+
+func (r *Recorder) matches(req, recorded *CassetteRequest) bool {
+	for _, m := range r.matchers {
+		if !m(req, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+// newCassetteRequest converts req into a CassetteRequest with its headers
+// redacted and its body scrubbed. The body of req is restored, so it can still be sent.
+func (r *Recorder) newCassetteRequest(req *http.Request) (*CassetteRequest, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := &CassetteRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: r.redactHeader(req.Header),
+	}
+	recorded.Body, recorded.BodyEncoding = encodeCassetteBody(r.scrub(body))
+
+	return recorded, nil
+}
+
+// redactHeader returns a copy of header with the values of the redacted
+// headers replaced.
+func (r *Recorder) redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, h := range r.redactedHeaders {
+		if header.Get(h) != "" {
+			header.Set(h, RedactedHeaderValue)
+		}
+	}
+	return header
+}
+
+// scrub applies the body scrubbers to body.
+func (r *Recorder) scrub(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	for _, scrub := range r.scrubbers {
+		body = scrub(body)
+	}
+	return body
+}
+
+func (cr *CassetteRequest) body() ([]byte, error) {
+	return decodeCassetteBody(cr.Body, cr.BodyEncoding)
+}
+
+func (cr *CassetteResponse) body() ([]byte, error) {
+	return decodeCassetteBody(cr.Body, cr.BodyEncoding)
+}
+
+// encodeCassetteBody returns the body as a string, base64 encoding it if it
+// is not valid UTF-8 so binary payloads survive the JSON roundtrip.
+func encodeCassetteBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeCassetteBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	default:
+		return nil, fmt.Errorf("unsupported body encoding %q", encoding)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,172 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	path := filepath.Join(t.TempDir(), "cassettes", "projects.json")
+	srv := NewFakeServer(t, WithFakeServerToken("glpat-secret"))
+
+	rec, err := NewRecorder(path, RecorderModeRecord)
+	require.NoError(t, err)
+	client, err := srv.NewClient(gitlab.WithInterceptor(rec.Interceptor()))
+	require.NoError(t, err)
+
+	created, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("recorded")})
+	require.NoError(t, err)
+	_, _, err = client.Projects.GetProject(created.ID, nil)
+	require.NoError(t, err)
+	_, _, err = client.Projects.GetProject(999, nil)
+	require.ErrorIs(t, err, gitlab.ErrNotFound)
+
+	// WHEN
+	require.NoError(t, rec.Save())
+
+	// THEN
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "glpat-secret")
+	assert.Contains(t, string(data), RedactedHeaderValue)
+
+	replay, err := NewRecorder(path, RecorderModeReplay)
+	require.NoError(t, err)
+	client, err = gitlab.NewClient("other-token",
+		gitlab.WithBaseURL("http://gitlab.invalid/api/v4"),
+		gitlab.WithInterceptor(replay.Interceptor()),
+	)
+	require.NoError(t, err)
+
+	replayed, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("recorded")})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, replayed.ID)
+
+	got, _, err := client.Projects.GetProject(created.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "recorded", got.Name)
+
+	_, _, err = client.Projects.GetProject(999, nil)
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+
+	_, _, err = client.Projects.GetProject(created.ID, nil)
+	assert.ErrorIs(t, err, ErrNoMatchingInteraction)
+}
+
+func TestRecorder_Matchers(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := `{
+  "interactions": [
+    {
+      "request": {"method": "POST", "url": "https://gitlab.example.com/api/v4/projects", "body": "{\"name\":\"first\"}"},
+      "response": {"status_code": 201, "header": {"Content-Type": ["application/json"]}, "body": "{\"id\":1,\"name\":\"first\"}"}
+    },
+    {
+      "request": {"method": "POST", "url": "https://gitlab.example.com/api/v4/projects", "body": "{\"name\":\"second\"}"},
+      "response": {"status_code": 201, "header": {"Content-Type": ["application/json"]}, "body": "{\"id\":2,\"name\":\"second\"}"}
+    }
+  ]
+}`
+	require.NoError(t, os.WriteFile(path, []byte(cassette), 0o644))
+
+	rec, err := NewRecorder(path, RecorderModeReplay, WithRequestMatchers(MatchMethod, MatchURL, MatchBody))
+	require.NoError(t, err)
+	client, err := gitlab.NewClient("", gitlab.WithInterceptor(rec.Interceptor()))
+	require.NoError(t, err)
+
+	// WHEN
+	second, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("second")})
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), second.ID)
+
+	_, _, err = client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("third")})
+	assert.ErrorIs(t, err, ErrNoMatchingInteraction)
+
+	first, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("first")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.ID)
+}
+
+func TestRecorder_ReplayMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	// WHEN
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderModeReplay)
+
+	// THEN
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRecorder_Redaction(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "_gitlab_session", Value: "session-secret"})
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 1, "name": "ci", "token": "glrt-secret", "runners": [{"token": "glrt-other"}]}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	scrubber := WithBodyScrubbers(ScrubJSONFields("token"))
+	rec, err := NewRecorder(path, RecorderModeRecord, scrubber)
+	require.NoError(t, err)
+	client, err := gitlab.NewClient("glpat-secret",
+		gitlab.WithBaseURL(srv.URL+"/api/v4"),
+		gitlab.WithInterceptor(rec.Interceptor()),
+	)
+	require.NoError(t, err)
+
+	req, err := client.NewRequest(http.MethodPost, "runners", map[string]string{"token": "glrt-registration"}, nil)
+	require.NoError(t, err)
+	var runner struct {
+		Token string `json:"token"`
+	}
+	_, err = client.Do(req, &runner)
+	require.NoError(t, err)
+	assert.Equal(t, "glrt-secret", runner.Token)
+
+	// WHEN
+	require.NoError(t, rec.Save())
+
+	// THEN
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"glpat-secret", "session-secret", "glrt-secret", "glrt-other", "glrt-registration"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Contains(t, string(data), `\"name\":\"ci\"`)
+
+	replay, err := NewRecorder(path, RecorderModeReplay, scrubber,
+		WithRequestMatchers(MatchMethod, MatchURL, MatchBody),
+	)
+	require.NoError(t, err)
+	client, err = gitlab.NewClient("other-token",
+		gitlab.WithBaseURL("http://gitlab.invalid/api/v4"),
+		gitlab.WithInterceptor(replay.Interceptor()),
+	)
+	require.NoError(t, err)
+
+	req, err = client.NewRequest(http.MethodPost, "runners", map[string]string{"token": "glrt-another"}, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req, &runner)
+	require.NoError(t, err)
+	assert.Equal(t, RedactedHeaderValue, runner.Token)
+}
@@ -83,6 +83,7 @@ type testClientMocks struct {
 	MockGroupVariables                   *MockGroupVariablesServiceInterface
 	MockGroupWikis                       *MockGroupWikisServiceInterface
 	MockGroups                           *MockGroupsServiceInterface
+	MockHelmCharts                       *MockHelmChartsServiceInterface
 	MockImport                           *MockImportServiceInterface
 	MockInstanceCluster                  *MockInstanceClustersServiceInterface
 	MockInstanceVariables                *MockInstanceVariablesServiceInterface
@@ -97,6 +98,7 @@ type testClientMocks struct {
 	MockLicense                          *MockLicenseServiceInterface
 	MockLicenseTemplates                 *MockLicenseTemplatesServiceInterface
 	MockMarkdown                         *MockMarkdownServiceInterface
+	MockMavenPackages                    *MockMavenPackagesServiceInterface
 	MockMemberRolesService               *MockMemberRolesServiceInterface
 	MockMergeRequestApprovals            *MockMergeRequestApprovalsServiceInterface
 	MockMergeRequestApprovalSettings     *MockMergeRequestApprovalSettingsServiceInterface
@@ -109,6 +111,7 @@ type testClientMocks struct {
 	MockNamespaces                       *MockNamespacesServiceInterface
 	MockNotes                            *MockNotesServiceInterface
 	MockNotificationSettings             *MockNotificationSettingsServiceInterface
+	MockNPMPackages                      *MockNPMPackagesServiceInterface
 	MockPackages                         *MockPackagesServiceInterface
 	MockPages                            *MockPagesServiceInterface
 	MockPagesDomains                     *MockPagesDomainsServiceInterface
@@ -139,6 +142,7 @@ type testClientMocks struct {
 	MockProtectedEnvironments            *MockProtectedEnvironmentsServiceInterface
 	MockProtectedPackages                *MockProtectedPackagesServiceInterface
 	MockProtectedTags                    *MockProtectedTagsServiceInterface
+	MockPyPIPackages                     *MockPyPIPackagesServiceInterface
 	MockReleaseLinks                     *MockReleaseLinksServiceInterface
 	MockReleases                         *MockReleasesServiceInterface
 	MockRepositories                     *MockRepositoriesServiceInterface
@@ -153,6 +157,7 @@ type testClientMocks struct {
 	MockRunnerControllers                *MockRunnerControllersServiceInterface
 	MockRunnerControllerScopes           *MockRunnerControllerScopesServiceInterface
 	MockRunnerControllerTokens           *MockRunnerControllerTokensServiceInterface
+	MockRunnerJobs                       *MockRunnerJobsServiceInterface
 	MockRunners                          *MockRunnersServiceInterface
 	MockSearch                           *MockSearchServiceInterface
 	MockSecureFiles                      *MockSecureFilesServiceInterface
@@ -163,6 +168,7 @@ type testClientMocks struct {
 	MockSnippets                         *MockSnippetsServiceInterface
 	MockSystemHooks                      *MockSystemHooksServiceInterface
 	MockTags                             *MockTagsServiceInterface
+	MockTerraformModules                 *MockTerraformModulesServiceInterface
 	MockTerraformStates                  *MockTerraformStatesServiceInterface
 	MockTodos                            *MockTodosServiceInterface
 	MockTopics                           *MockTopicsServiceInterface
@@ -247,6 +253,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 	mockGroupVariables := NewMockGroupVariablesServiceInterface(ctrl)
 	mockGroupWikis := NewMockGroupWikisServiceInterface(ctrl)
 	mockGroups := NewMockGroupsServiceInterface(ctrl)
+	mockHelmCharts := NewMockHelmChartsServiceInterface(ctrl)
 	mockImport := NewMockImportServiceInterface(ctrl)
 	mockInstanceCluster := NewMockInstanceClustersServiceInterface(ctrl)
 	mockInstanceVariables := NewMockInstanceVariablesServiceInterface(ctrl)
@@ -261,6 +268,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 	mockLicense := NewMockLicenseServiceInterface(ctrl)
 	mockLicenseTemplates := NewMockLicenseTemplatesServiceInterface(ctrl)
 	mockMarkdown := NewMockMarkdownServiceInterface(ctrl)
+	mockMavenPackages := NewMockMavenPackagesServiceInterface(ctrl)
 	mockMemberRolesService := NewMockMemberRolesServiceInterface(ctrl)
 	mockMergeRequestApprovals := NewMockMergeRequestApprovalsServiceInterface(ctrl)
 	mockMergeRequestApprovalSettings := NewMockMergeRequestApprovalSettingsServiceInterface(ctrl)
@@ -273,6 +281,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 	mockNamespaces := NewMockNamespacesServiceInterface(ctrl)
 	mockNotes := NewMockNotesServiceInterface(ctrl)
 	mockNotificationSettings := NewMockNotificationSettingsServiceInterface(ctrl)
+	mockNPMPackages := NewMockNPMPackagesServiceInterface(ctrl)
 	mockPackages := NewMockPackagesServiceInterface(ctrl)
 	mockPages := NewMockPagesServiceInterface(ctrl)
 	mockPagesDomains := NewMockPagesDomainsServiceInterface(ctrl)
@@ -303,6 +312,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 	mockProtectedEnvironments := NewMockProtectedEnvironmentsServiceInterface(ctrl)
 	mockProtectedPackages := NewMockProtectedPackagesServiceInterface(ctrl)
 	mockProtectedTags := NewMockProtectedTagsServiceInterface(ctrl)
+	mockPyPIPackages := NewMockPyPIPackagesServiceInterface(ctrl)
 	mockReleaseLinks := NewMockReleaseLinksServiceInterface(ctrl)
 	mockReleases := NewMockReleasesServiceInterface(ctrl)
 	mockRepositories := NewMockRepositoriesServiceInterface(ctrl)
@@ -317,6 +327,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 	mockRunnerControllers := NewMockRunnerControllersServiceInterface(ctrl)
 	mockRunnerControllerScopes := NewMockRunnerControllerScopesServiceInterface(ctrl)
 	mockRunnerControllerTokens := NewMockRunnerControllerTokensServiceInterface(ctrl)
+	mockRunnerJobs := NewMockRunnerJobsServiceInterface(ctrl)
 	mockRunners := NewMockRunnersServiceInterface(ctrl)
 	mockSearch := NewMockSearchServiceInterface(ctrl)
 	mockSecureFiles := NewMockSecureFilesServiceInterface(ctrl)
@@ -327,6 +338,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 	mockSnippets := NewMockSnippetsServiceInterface(ctrl)
 	mockSystemHooks := NewMockSystemHooksServiceInterface(ctrl)
 	mockTags := NewMockTagsServiceInterface(ctrl)
+	mockTerraformModules := NewMockTerraformModulesServiceInterface(ctrl)
 	mockTerraformStates := NewMockTerraformStatesServiceInterface(ctrl)
 	mockTodos := NewMockTodosServiceInterface(ctrl)
 	mockTopics := NewMockTopicsServiceInterface(ctrl)
@@ -410,6 +422,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 		GroupVariables:                   mockGroupVariables,
 		GroupWikis:                       mockGroupWikis,
 		Groups:                           mockGroups,
+		HelmCharts:                       mockHelmCharts,
 		Import:                           mockImport,
 		InstanceCluster:                  mockInstanceCluster,
 		InstanceVariables:                mockInstanceVariables,
@@ -424,6 +437,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 		License:                          mockLicense,
 		LicenseTemplates:                 mockLicenseTemplates,
 		Markdown:                         mockMarkdown,
+		MavenPackages:                    mockMavenPackages,
 		MemberRolesService:               mockMemberRolesService,
 		MergeRequestApprovals:            mockMergeRequestApprovals,
 		MergeRequestApprovalSettings:     mockMergeRequestApprovalSettings,
@@ -436,6 +450,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 		Namespaces:                       mockNamespaces,
 		Notes:                            mockNotes,
 		NotificationSettings:             mockNotificationSettings,
+		NPMPackages:                      mockNPMPackages,
 		Packages:                         mockPackages,
 		Pages:                            mockPages,
 		PagesDomains:                     mockPagesDomains,
@@ -466,6 +481,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 		ProtectedEnvironments:            mockProtectedEnvironments,
 		ProtectedPackages:                mockProtectedPackages,
 		ProtectedTags:                    mockProtectedTags,
+		PyPIPackages:                     mockPyPIPackages,
 		ReleaseLinks:                     mockReleaseLinks,
 		Releases:                         mockReleases,
 		Repositories:                     mockRepositories,
@@ -480,6 +496,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 		RunnerControllers:                mockRunnerControllers,
 		RunnerControllerScopes:           mockRunnerControllerScopes,
 		RunnerControllerTokens:           mockRunnerControllerTokens,
+		RunnerJobs:                       mockRunnerJobs,
 		Runners:                          mockRunners,
 		Search:                           mockSearch,
 		SecureFiles:                      mockSecureFiles,
@@ -490,6 +507,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 		Snippets:                         mockSnippets,
 		SystemHooks:                      mockSystemHooks,
 		Tags:                             mockTags,
+		TerraformModules:                 mockTerraformModules,
 		TerraformStates:                  mockTerraformStates,
 		Todos:                            mockTodos,
 		Topics:                           mockTopics,
@@ -585,6 +603,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 			MockGroupVariables:                   mockGroupVariables,
 			MockGroupWikis:                       mockGroupWikis,
 			MockGroups:                           mockGroups,
+			MockHelmCharts:                       mockHelmCharts,
 			MockImport:                           mockImport,
 			MockInstanceCluster:                  mockInstanceCluster,
 			MockInstanceVariables:                mockInstanceVariables,
@@ -599,6 +618,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 			MockLicense:                          mockLicense,
 			MockLicenseTemplates:                 mockLicenseTemplates,
 			MockMarkdown:                         mockMarkdown,
+			MockMavenPackages:                    mockMavenPackages,
 			MockMemberRolesService:               mockMemberRolesService,
 			MockMergeRequestApprovals:            mockMergeRequestApprovals,
 			MockMergeRequestApprovalSettings:     mockMergeRequestApprovalSettings,
@@ -611,6 +631,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 			MockNamespaces:                       mockNamespaces,
 			MockNotes:                            mockNotes,
 			MockNotificationSettings:             mockNotificationSettings,
+			MockNPMPackages:                      mockNPMPackages,
 			MockPackages:                         mockPackages,
 			MockPages:                            mockPages,
 			MockPagesDomains:                     mockPagesDomains,
@@ -641,6 +662,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 			MockProtectedEnvironments:            mockProtectedEnvironments,
 			MockProtectedPackages:                mockProtectedPackages,
 			MockProtectedTags:                    mockProtectedTags,
+			MockPyPIPackages:                     mockPyPIPackages,
 			MockReleaseLinks:                     mockReleaseLinks,
 			MockReleases:                         mockReleases,
 			MockRepositories:                     mockRepositories,
@@ -655,6 +677,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 			MockRunnerControllers:                mockRunnerControllers,
 			MockRunnerControllerScopes:           mockRunnerControllerScopes,
 			MockRunnerControllerTokens:           mockRunnerControllerTokens,
+			MockRunnerJobs:                       mockRunnerJobs,
 			MockRunners:                          mockRunners,
 			MockSearch:                           mockSearch,
 			MockSecureFiles:                      mockSecureFiles,
@@ -665,6 +688,7 @@ func newTestClientWithCtrl(ctrl *gomock.Controller, options ...gitlab.ClientOpti
 			MockSnippets:                         mockSnippets,
 			MockSystemHooks:                      mockSystemHooks,
 			MockTags:                             mockTags,
+			MockTerraformModules:                 mockTerraformModules,
 			MockTerraformStates:                  mockTerraformStates,
 			MockTodos:                            mockTodos,
 			MockTopics:                           mockTopics,
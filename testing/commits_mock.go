@@ -9,6 +9,7 @@
 package testing
 
 import (
+	fs "io/fs"
 	reflect "reflect"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
@@ -623,3 +624,47 @@ func (c *MockCommitsServiceInterfaceSetCommitStatusCall) DoAndReturn(f func(any,
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// SyncFiles mocks base method.
+func (m *MockCommitsServiceInterface) SyncFiles(pid any, fsys fs.FS, opt *gitlab.SyncFilesOptions, options ...gitlab.RequestOptionFunc) (*gitlab.SyncFilesResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, fsys, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SyncFiles", varargs...)
+	ret0, _ := ret[0].(*gitlab.SyncFilesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncFiles indicates an expected call of SyncFiles.
+func (mr *MockCommitsServiceInterfaceMockRecorder) SyncFiles(pid, fsys, opt any, options ...any) *MockCommitsServiceInterfaceSyncFilesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, fsys, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncFiles", reflect.TypeOf((*MockCommitsServiceInterface)(nil).SyncFiles), varargs...)
+	return &MockCommitsServiceInterfaceSyncFilesCall{Call: call}
+}
+
+// MockCommitsServiceInterfaceSyncFilesCall wrap *gomock.Call
+type MockCommitsServiceInterfaceSyncFilesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockCommitsServiceInterfaceSyncFilesCall) Return(arg0 *gitlab.SyncFilesResult, arg1 error) *MockCommitsServiceInterfaceSyncFilesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockCommitsServiceInterfaceSyncFilesCall) Do(f func(any, fs.FS, *gitlab.SyncFilesOptions, ...gitlab.RequestOptionFunc) (*gitlab.SyncFilesResult, error)) *MockCommitsServiceInterfaceSyncFilesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockCommitsServiceInterfaceSyncFilesCall) DoAndReturn(f func(any, fs.FS, *gitlab.SyncFilesOptions, ...gitlab.RequestOptionFunc) (*gitlab.SyncFilesResult, error)) *MockCommitsServiceInterfaceSyncFilesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,491 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+// contractAPIPrefix is the prefix of the API paths described by a Contract.
+const contractAPIPrefix = "/api/v4"
+
+// ContractViolation describes a part of a request which is not described by
+// the API schema of a Contract.
+type ContractViolation struct {
+	// Method and Path are the method and escaped path of the request.
+	Method string
+	Path   string
+
+	// Template is the path template of the matched operation, like
+	// "/projects/{id}/merge_requests". It is empty if no path matched.
+	Template string
+
+	// Message describes the violation, like `unknown query parameter "x"`.
+	Message string
+}
+
+// String implements fmt.Stringer.
+func (v ContractViolation) String() string {
+	if v.Template == "" {
+		return fmt.Sprintf("%s %s: %s", v.Method, v.Path, v.Message)
+	}
+	return fmt.Sprintf("%s %s (%s): %s", v.Method, v.Path, v.Template, v.Message)
+}
+
+// ContractOption configures a Contract.
+type ContractOption func(*Contract)
+
+// WithUndocumentedOperations makes the Contract accept requests to paths and
+// methods which are not described by the schema. Only the parameters of
+// documented operations are validated then.
+func WithUndocumentedOperations() ContractOption {
+	return func(c *Contract) {
+		c.allowUndocumented = true
+	}
+}
+
+// Contract validates requests made by a *gitlab.Client against GitLab's
+// OpenAPI v2 (Swagger 2.0) description of the REST API.
+//
+// For every request it checks that the method and path template of the
+// request exist in the schema, and that the names of all query parameters
+// and JSON body fields are parameters of the matched operation. This catches
+// misspelled url and json tags of option structs, which mocks of the service
+// interfaces never exercise.
+//
+// The Contract is hooked into the client using the gitlab.Interceptor
+// mechanism, so it can be combined with a FakeServer or a Recorder.
+//
+// Example:
+//
+//	func TestMyApp(t *testing.T) {
+//	    contract, err := testing.LoadContract("testdata/openapi_v2.yaml")
+//	    require.NoError(t, err)
+//
+//	    srv := testing.NewFakeServer(t)
+//	    client, err := srv.NewClient(gitlab.WithInterceptor(contract.Interceptor(t)))
+//	    require.NoError(t, err)
+//
+//	    // Requests which don't match the schema fail the test.
+//	}
+type Contract struct {
+	routes            []*contractRoute
+	definitions       map[string]*contractSchema
+	allowUndocumented bool
+}
+
+// contractRoute holds the operations of a single path template.
+type contractRoute struct {
+	template   string
+	segments   []contractSegment
+	literals   int
+	operations map[string]*contractOperation
+}
+
+// contractSegment matches a single segment of a path. Segments with a
+// parameter match any value with the given prefix and suffix.
+type contractSegment struct {
+	prefix string
+	param  bool
+	suffix string
+}
+
+// contractOperation holds the parameters of a single operation.
+type contractOperation struct {
+	query map[string]bool
+	body  *contractSchema
+}
+
+// contractSchema is the subset of a Swagger schema object needed to validate
+// the fields of a JSON body.
+type contractSchema struct {
+	Ref        string                     `yaml:"$ref"`
+	Type       string                     `yaml:"type"`
+	Properties map[string]*contractSchema `yaml:"properties"`
+	Items      *contractSchema            `yaml:"items"`
+}
+
+type contractParameter struct {
+	Name   string          `yaml:"name"`
+	In     string          `yaml:"in"`
+	Schema *contractSchema `yaml:"schema"`
+}
+
+type contractOperationSpec struct {
+	Parameters []*contractParameter `yaml:"parameters"`
+}
+
+type contractPathSpec struct {
+	Parameters []*contractParameter   `yaml:"parameters"`
+	Get        *contractOperationSpec `yaml:"get"`
+	Head       *contractOperationSpec `yaml:"head"`
+	Post       *contractOperationSpec `yaml:"post"`
+	Put        *contractOperationSpec `yaml:"put"`
+	Patch      *contractOperationSpec `yaml:"patch"`
+	Delete     *contractOperationSpec `yaml:"delete"`
+	Options    *contractOperationSpec `yaml:"options"`
+}
+
+type contractSpec struct {
+	Swagger     string                       `yaml:"swagger"`
+	BasePath    string                       `yaml:"basePath"`
+	Paths       map[string]*contractPathSpec `yaml:"paths"`
+	Definitions map[string]*contractSchema   `yaml:"definitions"`
+}
+
+// LoadContract loads the OpenAPI v2 description at the given path. Both
+// YAML and JSON files are supported.
+func LoadContract(path string, options ...ContractOption) (*Contract, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading contract: %w", err)
+	}
+	return ParseContract(data, options...)
+}
+
+// ParseContract parses an OpenAPI v2 description in YAML or JSON format.
+func ParseContract(data []byte, options ...ContractOption) (*Contract, error) {
+	var spec contractSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing contract: %w", err)
+	}
+	if spec.Swagger != "2.0" {
+		return nil, fmt.Errorf("parsing contract: unsupported swagger version %q", spec.Swagger)
+	}
+
+	c := &Contract{definitions: spec.Definitions}
+	for _, fn := range options {
+		if fn != nil {
+			fn(c)
+		}
+	}
+
+	for p, item := range spec.Paths {
+		template, ok := contractAPIPath(p)
+		if !ok {
+			template, ok = contractAPIPath(path.Join(spec.BasePath, p))
+		}
+		if !ok || item == nil {
+			continue
+		}
+
+		route := &contractRoute{
+			template:   template,
+			operations: make(map[string]*contractOperation),
+		}
+		for _, s := range strings.Split(strings.TrimPrefix(template, "/"), "/") {
+			seg := newContractSegment(s)
+			if !seg.param {
+				route.literals++
+			}
+			route.segments = append(route.segments, seg)
+		}
+
+		for method, op := range map[string]*contractOperationSpec{
+			http.MethodGet:     item.Get,
+			http.MethodHead:    item.Head,
+			http.MethodPost:    item.Post,
+			http.MethodPut:     item.Put,
+			http.MethodPatch:   item.Patch,
+			http.MethodDelete:  item.Delete,
+			http.MethodOptions: item.Options,
+		} {
+			if op != nil {
+				route.operations[method] = c.newOperation(append(slices.Clone(item.Parameters), op.Parameters...))
+			}
+		}
+		c.routes = append(c.routes, route)
+	}
+
+	// Prefer the most specific template, like /projects/{id}/merge_requests/count
+	// over /projects/{id}/merge_requests/{merge_request_iid}.
+	sort.Slice(c.routes, func(i, j int) bool {
+		if c.routes[i].literals != c.routes[j].literals {
+			return c.routes[i].literals > c.routes[j].literals
+		}
+		return c.routes[i].template < c.routes[j].template
+	})
+
+	return c, nil
+}
+
+// newOperation collects the known parameters of an operation. GitLab accepts
+// every parameter in the query string as well as in the body, so all of them
+// are allowed as top-level body fields.
+func (c *Contract) newOperation(params []*contractParameter) *contractOperation {
+	op := &contractOperation{
+		query: make(map[string]bool),
+		body:  &contractSchema{Type: "object", Properties: make(map[string]*contractSchema)},
+	}
+
+	open := false
+	for _, p := range params {
+		switch p.In {
+		case "query", "formData":
+			name := strings.TrimSuffix(p.Name, "[]")
+			op.query[name] = true
+			if _, ok := op.body.Properties[name]; !ok {
+				op.body.Properties[name] = &contractSchema{}
+			}
+		case "body":
+			schema := c.resolve(p.Schema)
+			if schema == nil {
+				continue
+			}
+			// A body without described properties accepts any field.
+			open = open || len(schema.Properties) == 0
+			maps.Copy(op.body.Properties, schema.Properties)
+		}
+	}
+	if open {
+		op.body.Properties = nil
+	}
+
+	return op
+}
+
+// knowsQuery reports whether key is a query parameter of the operation. Keys
+// of arrays and hashes, like iids[] and custom_attributes[key], are looked up
+// without their brackets, unless the brackets are part of a documented name,
+// like not[labels].
+func (op *contractOperation) knowsQuery(key string) bool {
+	key = strings.TrimSuffix(key, "[]")
+	if op.query[key] {
+		return true
+	}
+	name, _, nested := strings.Cut(key, "[")
+	return nested && op.query[name]
+}
+
+// resolve follows the reference of a schema to the definitions of the
+// contract.
+func (c *Contract) resolve(schema *contractSchema) *contractSchema {
+	for seen := 0; schema != nil && schema.Ref != "" && seen < 32; seen++ {
+		schema = c.definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+	}
+	return schema
+}
+
+// Interceptor returns a gitlab.Interceptor that validates every request
+// against the contract and reports violations as errors of tb. The requests
+// are sent unchanged, whether they are valid or not.
+func (c *Contract) Interceptor(tb testing.TB) gitlab.Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			violations, err := c.Validate(req)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range violations {
+				tb.Errorf("contract violation: %s", v)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Validate returns the violations of the contract by req. Requests outside
+// of the REST API, like GraphQL requests, are not validated. The body of the
+// request is restored after it was read.
+func (c *Contract) Validate(req *http.Request) ([]ContractViolation, error) {
+	escaped := req.URL.EscapedPath()
+	apiPath, ok := contractAPIPath(escaped)
+	if !ok {
+		return nil, nil
+	}
+
+	var violations []ContractViolation
+	report := func(template, format string, args ...any) {
+		violations = append(violations, ContractViolation{
+			Method:   req.Method,
+			Path:     escaped,
+			Template: template,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	route, op := c.match(req.Method, apiPath)
+	switch {
+	case route == nil:
+		if !c.allowUndocumented {
+			report("", "undocumented path")
+		}
+		return violations, nil
+	case op == nil:
+		if !c.allowUndocumented {
+			report(route.template, "undocumented method")
+		}
+		return violations, nil
+	}
+
+	query := req.URL.Query()
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		if !op.knowsQuery(key) {
+			report(route.template, "unknown query parameter %q", key)
+		}
+	}
+
+	body, err := contractRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		unknown := make(map[string]bool)
+		c.validateFields(body, op.body, "", unknown)
+		for _, field := range slices.Sorted(maps.Keys(unknown)) {
+			report(route.template, "unknown body field %q", field)
+		}
+	}
+
+	return violations, nil
+}
+
+// match returns the route matching the API path and its operation for the
+// method, if any.
+func (c *Contract) match(method, apiPath string) (*contractRoute, *contractOperation) {
+	var segments []string
+	for _, s := range strings.Split(strings.TrimPrefix(apiPath, "/"), "/") {
+		if u, err := url.PathUnescape(s); err == nil {
+			s = u
+		}
+		segments = append(segments, s)
+	}
+
+	var matched *contractRoute
+	for _, route := range c.routes {
+		if !route.matches(segments) {
+			continue
+		}
+		op := route.operations[method]
+		if op == nil && method == http.MethodHead {
+			// GitLab answers HEAD requests of every GET endpoint.
+			op = route.operations[http.MethodGet]
+		}
+		if op != nil {
+			return route, op
+		}
+		if matched == nil {
+			matched = route
+		}
+	}
+	return matched, nil
+}
+
+func (r *contractRoute) matches(segments []string) bool {
+	if len(segments) != len(r.segments) {
+		return false
+	}
+	for i, seg := range r.segments {
+		if !seg.matches(segments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func newContractSegment(s string) contractSegment {
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start < 0 || end < start {
+		return contractSegment{prefix: s}
+	}
+	return contractSegment{prefix: s[:start], param: true, suffix: s[end+1:]}
+}
+
+func (s contractSegment) matches(v string) bool {
+	if !s.param {
+		return v == s.prefix
+	}
+	return len(v) > len(s.prefix)+len(s.suffix) &&
+		strings.HasPrefix(v, s.prefix) &&
+		strings.HasSuffix(v, s.suffix)
+}
+
+// validateFields records the fields of value which are not properties of
+// schema in unknown. Objects without described properties accept any field.
+func (c *Contract) validateFields(value any, schema *contractSchema, prefix string, unknown map[string]bool) {
+	schema = c.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if len(schema.Properties) == 0 {
+			return
+		}
+		for key, field := range v {
+			prop, ok := schema.Properties[key]
+			if !ok {
+				unknown[prefix+key] = true
+				continue
+			}
+			c.validateFields(field, prop, prefix+key+".", unknown)
+		}
+	case []any:
+		for _, item := range v {
+			c.validateFields(item, schema.Items, prefix, unknown)
+		}
+	}
+}
+
+// contractRequestBody returns the decoded JSON or form body of req, or nil if
+// the request has no body to validate.
+func contractRequestBody(req *http.Request) (any, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		var body any
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, fmt.Errorf("decoding request body: %w", err)
+		}
+		return body, nil
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("decoding request body: %w", err)
+		}
+		body := make(map[string]any, len(form))
+		for key := range form {
+			body[strings.TrimSuffix(key, "[]")] = nil
+		}
+		return body, nil
+	default:
+		return nil, nil
+	}
+}
+
+// contractAPIPath returns the part of p following the API prefix.
+func contractAPIPath(p string) (string, bool) {
+	i := strings.Index(p, contractAPIPrefix+"/")
+	if i < 0 {
+		return "", false
+	}
+	return p[i+len(contractAPIPrefix):], true
+}
@@ -0,0 +1,330 @@
+package testing
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+func loadTestContract(t *testing.T, options ...ContractOption) *Contract {
+	t.Helper()
+
+	contract, err := LoadContract("testdata/openapi_v2.yaml", options...)
+	require.NoError(t, err)
+	return contract
+}
+
+func TestContract_Interceptor(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	contract := loadTestContract(t)
+	srv := NewFakeServer(t)
+	client, err := srv.NewClient(gitlab.WithInterceptor(contract.Interceptor(t)))
+	require.NoError(t, err)
+
+	project, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+		Name:                 gitlab.Ptr("contract"),
+		InitializeWithReadme: gitlab.Ptr(true),
+		ContainerExpirationPolicyAttributes: &gitlab.ContainerExpirationPolicyAttributes{
+			Cadence: gitlab.Ptr("7d"),
+			KeepN:   gitlab.Ptr(int64(10)),
+		},
+	})
+	require.NoError(t, err)
+	srv.AddBranch(project.ID, "feature", "main")
+
+	// WHEN
+	mr, _, err := client.MergeRequests.CreateMergeRequest(project.ID, &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr("Add feature"),
+		SourceBranch: gitlab.Ptr("feature"),
+		TargetBranch: gitlab.Ptr("main"),
+		Labels:       &gitlab.LabelOptions{"feature"},
+		ReviewerIDs:  &[]int64{1},
+	})
+	require.NoError(t, err)
+
+	_, _, err = client.MergeRequests.UpdateMergeRequest(project.ID, mr.IID, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.Ptr("close"),
+		AddLabels:  &gitlab.LabelOptions{"closed"},
+	})
+	require.NoError(t, err)
+
+	// Every option of ListProjectMergeRequestsOptions must be a documented
+	// parameter.
+	now := time.Now()
+	_, _, err = client.MergeRequests.ListProjectMergeRequests(project.ID, &gitlab.ListProjectMergeRequestsOptions{
+		ListOptions:            gitlab.ListOptions{Page: 1, PerPage: 10},
+		IIDs:                   &[]int64{mr.IID},
+		State:                  gitlab.Ptr("all"),
+		OrderBy:                gitlab.Ptr("created_at"),
+		Sort:                   gitlab.Ptr("desc"),
+		Milestone:              gitlab.Ptr("v1"),
+		View:                   gitlab.Ptr("simple"),
+		Environment:            gitlab.Ptr("production"),
+		Labels:                 &gitlab.LabelOptions{"feature"},
+		NotLabels:              &gitlab.LabelOptions{"bug"},
+		WithLabelsDetails:      gitlab.Ptr(true),
+		WithMergeStatusRecheck: gitlab.Ptr(true),
+		CreatedAfter:           &now,
+		CreatedBefore:          &now,
+		UpdatedAfter:           &now,
+		UpdatedBefore:          &now,
+		DeployedBefore:         &now,
+		DeployedAfter:          &now,
+		Scope:                  gitlab.Ptr("all"),
+		AuthorID:               gitlab.Ptr(int64(1)),
+		AuthorUsername:         gitlab.Ptr("root"),
+		NotAuthorUsername:      gitlab.Ptr("ghost"),
+		AssigneeID:             gitlab.AssigneeID(gitlab.UserIDAny),
+		ApproverIDs:            gitlab.ApproverIDs([]int64{1}),
+		ApprovedByIDs:          gitlab.ApproverIDs(gitlab.UserIDNone),
+		ReviewerID:             gitlab.ReviewerID(int64(1)),
+		ReviewerUsername:       gitlab.Ptr("root"),
+		MyReactionEmoji:        gitlab.Ptr("thumbsup"),
+		SourceBranch:           gitlab.Ptr("feature"),
+		TargetBranch:           gitlab.Ptr("main"),
+		Search:                 gitlab.Ptr("feature"),
+		Draft:                  gitlab.Ptr(false),
+		WIP:                    gitlab.Ptr("no"),
+	})
+
+	// THEN
+	require.NoError(t, err)
+}
+
+func TestContract_Interceptor_Services(t *testing.T) {
+	t.Parallel()
+
+	contract := loadTestContract(t)
+	srv := NewFakeServer(t)
+	client, err := srv.NewClient(gitlab.WithInterceptor(contract.Interceptor(t)))
+	require.NoError(t, err)
+
+	tests := map[string]func() error{
+		"users": func() error {
+			users, _, err := client.Users.ListUsers(&gitlab.ListUsersOptions{
+				ListOptions:          gitlab.ListOptions{PerPage: 20},
+				Active:               gitlab.Ptr(true),
+				ExcludeInternal:      gitlab.Ptr(true),
+				Search:               gitlab.Ptr("root"),
+				OrderBy:              gitlab.Ptr("id"),
+				Sort:                 gitlab.Ptr("asc"),
+				WithoutProjectBots:   gitlab.Ptr(true),
+				WithCustomAttributes: gitlab.Ptr(true),
+			})
+			if err != nil {
+				return err
+			}
+			for _, u := range users {
+				if _, _, err := client.Users.GetUser(u.ID, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		"groups": func() error {
+			_, _, err := client.Groups.CreateGroup(&gitlab.CreateGroupOptions{
+				Name:        gitlab.Ptr("contract"),
+				Path:        gitlab.Ptr("contract"),
+				Description: gitlab.Ptr("Contract tests"),
+				Visibility:  gitlab.Ptr(gitlab.PrivateVisibility),
+			})
+			if err != nil {
+				return err
+			}
+			_, _, err = client.Groups.ListGroups(&gitlab.ListGroupsOptions{
+				AllAvailable: gitlab.Ptr(true),
+				Owned:        gitlab.Ptr(true),
+				OrderBy:      gitlab.Ptr("name"),
+				TopLevelOnly: gitlab.Ptr(true),
+			})
+			return err
+		},
+		"projects, issues and branches": func() error {
+			project, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+				Name:                 gitlab.Ptr("services"),
+				InitializeWithReadme: gitlab.Ptr(true),
+			})
+			if err != nil {
+				return err
+			}
+			if _, _, err := client.Projects.EditProject(project.ID, &gitlab.EditProjectOptions{
+				Description: gitlab.Ptr("Services"),
+				MergeMethod: gitlab.Ptr(gitlab.FastForwardMerge),
+			}); err != nil {
+				return err
+			}
+
+			issue, _, err := client.Issues.CreateIssue(project.ID, &gitlab.CreateIssueOptions{
+				Title:        gitlab.Ptr("Bug"),
+				Description:  gitlab.Ptr("Broken"),
+				Confidential: gitlab.Ptr(true),
+				Labels:       &gitlab.LabelOptions{"bug"},
+			})
+			if err != nil {
+				return err
+			}
+			if _, _, err := client.Issues.UpdateIssue(project.ID, issue.IID, &gitlab.UpdateIssueOptions{
+				StateEvent: gitlab.Ptr("close"),
+				AddLabels:  &gitlab.LabelOptions{"closed"},
+			}); err != nil {
+				return err
+			}
+			if _, _, err := client.Issues.ListProjectIssues(project.ID, &gitlab.ListProjectIssuesOptions{
+				IIDs:      &[]int64{issue.IID},
+				State:     gitlab.Ptr("closed"),
+				Labels:    &gitlab.LabelOptions{"bug"},
+				NotLabels: &gitlab.LabelOptions{"feature"},
+				Search:    gitlab.Ptr("Bug"),
+			}); err != nil {
+				return err
+			}
+
+			if _, _, err := client.Branches.CreateBranch(project.ID, &gitlab.CreateBranchOptions{
+				Branch: gitlab.Ptr("feature"),
+				Ref:    gitlab.Ptr("main"),
+			}); err != nil {
+				return err
+			}
+			if _, _, err := client.Branches.ListBranches(project.ID, &gitlab.ListBranchesOptions{
+				Search: gitlab.Ptr("feat"),
+			}); err != nil {
+				return err
+			}
+
+			if _, _, err := client.RepositoryFiles.CreateFile(project.ID, "docs/README.md", &gitlab.CreateFileOptions{
+				Branch:        gitlab.Ptr("feature"),
+				CommitMessage: gitlab.Ptr("Add docs"),
+				Content:       gitlab.Ptr("docs"),
+			}); err != nil {
+				return err
+			}
+			_, _, err = client.RepositoryFiles.GetFile(project.ID, "docs/README.md", &gitlab.GetFileOptions{
+				Ref: gitlab.Ptr("feature"),
+			})
+			return err
+		},
+	}
+
+	for name, run := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, run())
+		})
+	}
+}
+
+func TestContract_Validate(t *testing.T) {
+	t.Parallel()
+
+	contract := loadTestContract(t)
+
+	tests := map[string]struct {
+		method string
+		url    string
+		body   string
+		want   []string
+	}{
+		"valid request": {
+			method: http.MethodGet,
+			url:    "/api/v4/projects/group%2Fproject/merge_requests?state=opened&iids[]=1&iids[]=2&not[labels]=bug",
+		},
+		"nested query parameter": {
+			method: http.MethodGet,
+			url:    "/api/v4/projects?custom_attributes[team]=platform&custom_attributes[tier]=1",
+		},
+		"misspelled nested query parameter": {
+			method: http.MethodGet,
+			url:    "/api/v4/projects?custom_atributes[team]=platform",
+			want:   []string{`GET /api/v4/projects (/projects): unknown query parameter "custom_atributes[team]"`},
+		},
+		"misspelled query parameter": {
+			method: http.MethodGet,
+			url:    "/api/v4/projects/1/merge_requests?source_brnch=main&state=opened",
+			want:   []string{`GET /api/v4/projects/1/merge_requests (/projects/{id}/merge_requests): unknown query parameter "source_brnch"`},
+		},
+		"unknown body fields": {
+			method: http.MethodPost,
+			url:    "/api/v4/projects",
+			body:   `{"name": "x", "topics": ["a"], "titel": "x", "container_expiration_policy_attributes": {"keep_n": 1, "keep": 1}}`,
+			want: []string{
+				`POST /api/v4/projects (/projects): unknown body field "container_expiration_policy_attributes.keep"`,
+				`POST /api/v4/projects (/projects): unknown body field "titel"`,
+			},
+		},
+		"form data parameters": {
+			method: http.MethodPost,
+			url:    "/api/v4/projects/1/repository/files/docs%2FREADME.md",
+			body:   `{"branch": "main", "commit_message": "Add docs", "content": "docs"}`,
+		},
+		"undocumented path": {
+			method: http.MethodGet,
+			url:    "/api/v4/projects/1/pipelines",
+			want:   []string{`GET /api/v4/projects/1/pipelines: undocumented path`},
+		},
+		"undocumented method": {
+			method: http.MethodPatch,
+			url:    "/api/v4/projects/1",
+			want:   []string{`PATCH /api/v4/projects/1 (/projects/{id}): undocumented method`},
+		},
+		"HEAD of a GET endpoint": {
+			method: http.MethodHead,
+			url:    "/api/v4/projects/1?statistics=true",
+		},
+		"outside of the REST API": {
+			method: http.MethodPost,
+			url:    "/api/graphql",
+			body:   `{"query": "{}"}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(tt.method, "https://gitlab.example.com"+tt.url, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			violations, err := contract.Validate(req)
+			require.NoError(t, err)
+
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContract_UndocumentedOperations(t *testing.T) {
+	t.Parallel()
+
+	contract := loadTestContract(t, WithUndocumentedOperations())
+
+	req, err := http.NewRequest(http.MethodGet, "https://gitlab.example.com/api/v4/projects/1/pipelines?ref=main", nil)
+	require.NoError(t, err)
+	violations, err := contract.Validate(req)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	req, err = http.NewRequest(http.MethodGet, "https://gitlab.example.com/api/v4/projects/1?statistcs=true", nil)
+	require.NoError(t, err)
+	violations, err = contract.Validate(req)
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, `unknown query parameter "statistcs"`, violations[0].Message)
+}
+
+func TestParseContract_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseContract([]byte(`{"openapi": "3.0.0", "paths": {}}`))
+	assert.ErrorContains(t, err, "unsupported swagger version")
+}
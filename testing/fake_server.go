@@ -0,0 +1,891 @@
+package testing
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+const (
+	fakeAPIPrefix      = "/api/v4"
+	fakeDefaultPerPage = 20
+	fakeMaxPerPage     = 100
+)
+
+// FakeServer is a stateful, in-memory fake of a subset of the GitLab REST API.
+//
+// It runs on top of an httptest.Server, so a real *gitlab.Client can be
+// pointed at it with gitlab.WithBaseURL. Every request goes through the
+// complete client stack, including path escaping, query encoding, offset and
+// keyset pagination and error handling.
+//
+// The fake currently supports users, groups, projects, issues, merge
+// requests, branches and repository files. Requests to any other endpoint are
+// answered with a 404 Not Found, just like GitLab does for unknown routes.
+//
+// Example:
+//
+//	func TestMyApp(t *testing.T) {
+//	    srv := testing.NewFakeServer(t)
+//	    project := srv.AddProject(&gitlab.Project{Name: "example"})
+//
+//	    client, err := srv.NewClient()
+//	    require.NoError(t, err)
+//
+//	    // Use the client in your test
+//	    issue, _, err := client.Issues.CreateIssue(project.ID, &gitlab.CreateIssueOptions{
+//	        Title: gitlab.Ptr("Something is broken"),
+//	    })
+//	    require.NoError(t, err)
+//	    assert.Equal(t, int64(1), issue.IID)
+//	}
+type FakeServer struct {
+	server *httptest.Server
+	token  string
+
+	mu            sync.Mutex
+	lastIDs       map[string]int64
+	currentUser   *gitlab.User
+	users         []*gitlab.User
+	groups        []*gitlab.Group
+	projects      []*gitlab.Project
+	issues        map[int64][]*gitlab.Issue
+	mergeRequests map[int64][]*gitlab.MergeRequest
+	repositories  map[int64]map[string]*fakeBranch
+}
+
+// fakeBranch holds the head commit and the files of a single branch.
+type fakeBranch struct {
+	commit *gitlab.Commit
+	files  map[string]*fakeFile
+}
+
+// fakeFile holds the content of a single file in a fakeBranch.
+type fakeFile struct {
+	content      []byte
+	lastCommitID string
+	executable   bool
+}
+
+// FakeServerOption can be used to customize a new FakeServer.
+type FakeServerOption func(*FakeServer)
+
+// WithFakeServerToken makes the FakeServer require the given token on every
+// request. The token is accepted in the Private-Token and Job-Token headers
+// as well as an OAuth bearer token. Requests without a matching token are
+// rejected with 401 Unauthorized.
+func WithFakeServerToken(token string) FakeServerOption {
+	return func(s *FakeServer) {
+		s.token = token
+	}
+}
+
+// NewFakeServer starts a new FakeServer which is closed automatically when
+// the test finishes. The server is seeded with an administrator named "root"
+// who is used as the current user for all requests.
+func NewFakeServer(t testing.TB, options ...FakeServerOption) *FakeServer {
+	s := &FakeServer{
+		lastIDs:       make(map[string]int64),
+		issues:        make(map[int64][]*gitlab.Issue),
+		mergeRequests: make(map[int64][]*gitlab.MergeRequest),
+		repositories:  make(map[int64]map[string]*fakeBranch),
+	}
+
+	for _, fn := range options {
+		if fn != nil {
+			fn(s)
+		}
+	}
+
+	s.server = httptest.NewServer(s.routes())
+	t.Cleanup(s.server.Close)
+
+	root := s.AddUser(&gitlab.User{
+		Username: "root",
+		Name:     "Administrator",
+		Email:    "admin@example.com",
+		IsAdmin:  true,
+	})
+	s.currentUser = s.userByID(root.ID)
+
+	return s
+}
+
+// URL returns the base URL of the FakeServer, which can be passed to
+// gitlab.WithBaseURL.
+func (s *FakeServer) URL() string {
+	return s.server.URL
+}
+
+// NewClient returns a new *gitlab.Client which is configured to talk to the
+// FakeServer. Any additional options are applied after the base URL option.
+func (s *FakeServer) NewClient(options ...gitlab.ClientOptionFunc) (*gitlab.Client, error) {
+	opts := append([]gitlab.ClientOptionFunc{gitlab.WithBaseURL(s.server.URL)}, options...)
+	return gitlab.NewClient(s.token, opts...)
+}
+
+// CurrentUser returns the user that is authenticated for every request.
+func (s *FakeServer) CurrentUser() *gitlab.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := *s.currentUser
+	return &u
+}
+
+// AddUser adds a user to the FakeServer. The ID, state and web URL are set
+// if they are empty. It returns a copy of the stored user.
+func (s *FakeServer) AddUser(user *gitlab.User) *gitlab.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := *user
+	u.ID = s.assignID("users", u.ID)
+	if u.State == "" {
+		u.State = "active"
+	}
+	if u.WebURL == "" {
+		u.WebURL = s.webURL(u.Username)
+	}
+	if u.CreatedAt == nil {
+		u.CreatedAt = gitlab.Ptr(time.Now().UTC())
+	}
+	s.users = insertByID(s.users, &u, func(u *gitlab.User) int64 { return u.ID })
+
+	c := u
+	return &c
+}
+
+// AddGroup adds a group to the FakeServer. The ID, full path, full name and
+// web URL are derived from the name, path and parent ID if they are empty.
+// It returns a copy of the stored group.
+func (s *FakeServer) AddGroup(group *gitlab.Group) *gitlab.Group {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.storeGroup(group)
+	return &g
+}
+
+// AddProject adds a project to the FakeServer. The ID, namespace, paths and
+// URLs are derived from the name, path and namespace if they are empty. The
+// project's repository is initialized with its default branch, which is
+// "main" unless specified otherwise. It returns a copy of the stored project.
+func (s *FakeServer) AddProject(project *gitlab.Project) *gitlab.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.storeProject(project)
+	s.initRepository(&p)
+
+	return &p
+}
+
+// AddIssue adds an issue to the project with the given ID. The ID, IID,
+// state and web URL are set if they are empty. It returns a copy of the
+// stored issue, or nil if the project does not exist.
+func (s *FakeServer) AddIssue(pid int64, issue *gitlab.Issue) *gitlab.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.projectByID(pid)
+	if p == nil {
+		return nil
+	}
+
+	i := s.storeIssue(p, issue)
+	return &i
+}
+
+// AddMergeRequest adds a merge request to the project with the given ID.
+// The ID, IID, state and web URL are set if they are empty. It returns a copy
+// of the stored merge request, or nil if the project does not exist.
+func (s *FakeServer) AddMergeRequest(pid int64, mergeRequest *gitlab.MergeRequest) *gitlab.MergeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.projectByID(pid)
+	if p == nil {
+		return nil
+	}
+
+	mr := s.storeMergeRequest(p, mergeRequest)
+	return &mr
+}
+
+// AddBranch creates a branch named branch from ref in the repository of the
+// project with the given ID. It returns the new branch, or nil if either the
+// project or ref does not exist.
+func (s *FakeServer) AddBranch(pid int64, branch, ref string) *gitlab.Branch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.projectByID(pid)
+	if p == nil {
+		return nil
+	}
+
+	source := s.resolveRef(p.ID, ref)
+	if source == nil {
+		return nil
+	}
+
+	s.repositories[p.ID][branch] = source.clone()
+
+	return s.branchPayload(p, branch)
+}
+
+// AddFile commits a file to the given branch of the project with the given
+// ID, creating the branch if it does not exist yet. It returns false if the
+// project does not exist.
+func (s *FakeServer) AddFile(pid int64, branch, path string, content []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.projectByID(pid)
+	if p == nil {
+		return false
+	}
+
+	b := s.repositories[p.ID][branch]
+	if b == nil {
+		b = &fakeBranch{files: make(map[string]*fakeFile)}
+		s.repositories[p.ID][branch] = b
+	}
+
+	commit := s.newCommit(p, "Add "+path, b.commit)
+	b.commit = commit
+	b.files[path] = &fakeFile{content: content, lastCommitID: commit.ID}
+	p.EmptyRepo = false
+
+	return true
+}
+
+// nextID returns the next ID for the given kind of resource.
+func (s *FakeServer) nextID(kind string) int64 {
+	s.lastIDs[kind]++
+	return s.lastIDs[kind]
+}
+
+// assignID returns id, or the next ID for the given kind of resource if id is
+// zero. Explicit IDs move the sequence forward so later generated IDs never
+// collide with them.
+func (s *FakeServer) assignID(kind string, id int64) int64 {
+	if id == 0 {
+		return s.nextID(kind)
+	}
+	s.lastIDs[kind] = max(s.lastIDs[kind], id)
+	return id
+}
+
+// webURL returns the web URL for the given full path.
+func (s *FakeServer) webURL(fullPath string) string {
+	return s.server.URL + "/" + fullPath
+}
+
+func (s *FakeServer) storeGroup(group *gitlab.Group) gitlab.Group {
+	g := *group
+	g.ID = s.assignID("groups", g.ID)
+	if g.Path == "" {
+		g.Path = fakeSlug(g.Name)
+	}
+	if g.Name == "" {
+		g.Name = g.Path
+	}
+	if g.Visibility == "" {
+		g.Visibility = gitlab.PrivateVisibility
+	}
+	if g.FullPath == "" {
+		g.FullPath, g.FullName = g.Path, g.Name
+		if parent := s.groupByID(g.ParentID); parent != nil {
+			g.FullPath = parent.FullPath + "/" + g.Path
+			g.FullName = parent.FullName + " / " + g.Name
+		}
+	}
+	if g.WebURL == "" {
+		g.WebURL = s.webURL("groups/" + g.FullPath)
+	}
+	if g.CreatedAt == nil {
+		g.CreatedAt = gitlab.Ptr(time.Now().UTC())
+	}
+	s.groups = insertByID(s.groups, &g, func(g *gitlab.Group) int64 { return g.ID })
+
+	return g
+}
+
+func (s *FakeServer) storeProject(project *gitlab.Project) gitlab.Project {
+	p := *project
+	p.ID = s.assignID("projects", p.ID)
+	if p.Path == "" {
+		p.Path = fakeSlug(p.Name)
+	}
+	if p.Name == "" {
+		p.Name = p.Path
+	}
+	if p.DefaultBranch == "" {
+		p.DefaultBranch = "main"
+	}
+	if p.Visibility == "" {
+		p.Visibility = gitlab.PrivateVisibility
+	}
+	if p.Namespace == nil {
+		p.Namespace = s.userNamespace(s.currentUser)
+	}
+	if p.PathWithNamespace == "" {
+		p.PathWithNamespace = p.Namespace.FullPath + "/" + p.Path
+	}
+	if p.NameWithNamespace == "" {
+		p.NameWithNamespace = p.Namespace.Name + " / " + p.Name
+	}
+	if p.WebURL == "" {
+		p.WebURL = s.webURL(p.PathWithNamespace)
+	}
+	if p.HTTPURLToRepo == "" {
+		p.HTTPURLToRepo = p.WebURL + ".git"
+	}
+	if p.SSHURLToRepo == "" {
+		u, _ := url.Parse(s.server.URL)
+		p.SSHURLToRepo = fmt.Sprintf("git@%s:%s.git", u.Hostname(), p.PathWithNamespace)
+	}
+	if p.CreatorID == 0 && s.currentUser != nil {
+		p.CreatorID = s.currentUser.ID
+	}
+	if p.CreatedAt == nil {
+		p.CreatedAt = gitlab.Ptr(time.Now().UTC())
+	}
+	if p.LastActivityAt == nil {
+		p.LastActivityAt = p.CreatedAt
+	}
+	s.projects = insertByID(s.projects, &p, func(p *gitlab.Project) int64 { return p.ID })
+
+	if _, ok := s.repositories[p.ID]; !ok {
+		s.repositories[p.ID] = make(map[string]*fakeBranch)
+	}
+
+	return p
+}
+
+// initRepository creates the default branch of the project with an initial
+// commit, unless the repository already contains branches.
+func (s *FakeServer) initRepository(p *gitlab.Project) {
+	if len(s.repositories[p.ID]) > 0 {
+		return
+	}
+
+	s.repositories[p.ID][p.DefaultBranch] = &fakeBranch{
+		commit: s.newCommit(p, "Initial commit", nil),
+		files:  make(map[string]*fakeFile),
+	}
+
+	if stored := s.projectByID(p.ID); stored != nil {
+		stored.EmptyRepo = false
+	}
+	p.EmptyRepo = false
+}
+
+func (s *FakeServer) storeIssue(p *gitlab.Project, issue *gitlab.Issue) gitlab.Issue {
+	i := *issue
+	i.ID = s.assignID("issues", i.ID)
+	if i.IID == 0 {
+		for _, other := range s.issues[p.ID] {
+			i.IID = max(i.IID, other.IID)
+		}
+		i.IID++
+	}
+	i.ProjectID = p.ID
+	if i.State == "" {
+		i.State = "opened"
+	}
+	if i.Author == nil {
+		i.Author = &gitlab.IssueAuthor{
+			ID:       s.currentUser.ID,
+			State:    s.currentUser.State,
+			WebURL:   s.currentUser.WebURL,
+			Name:     s.currentUser.Name,
+			Username: s.currentUser.Username,
+		}
+	}
+	if i.Labels == nil {
+		i.Labels = gitlab.Labels{}
+	}
+	if i.WebURL == "" {
+		i.WebURL = fmt.Sprintf("%s/-/issues/%d", p.WebURL, i.IID)
+	}
+	if i.CreatedAt == nil {
+		i.CreatedAt = gitlab.Ptr(time.Now().UTC())
+	}
+	if i.UpdatedAt == nil {
+		i.UpdatedAt = i.CreatedAt
+	}
+	s.issues[p.ID] = insertByID(s.issues[p.ID], &i, func(i *gitlab.Issue) int64 { return i.ID })
+
+	return i
+}
+
+func (s *FakeServer) storeMergeRequest(p *gitlab.Project, mergeRequest *gitlab.MergeRequest) gitlab.MergeRequest {
+	mr := *mergeRequest
+	mr.ID = s.assignID("merge_requests", mr.ID)
+	if mr.IID == 0 {
+		for _, other := range s.mergeRequests[p.ID] {
+			mr.IID = max(mr.IID, other.IID)
+		}
+		mr.IID++
+	}
+	mr.ProjectID = p.ID
+	if mr.SourceProjectID == 0 {
+		mr.SourceProjectID = p.ID
+	}
+	if mr.TargetProjectID == 0 {
+		mr.TargetProjectID = p.ID
+	}
+	if mr.TargetBranch == "" {
+		mr.TargetBranch = p.DefaultBranch
+	}
+	if mr.State == "" {
+		mr.State = "opened"
+	}
+	if mr.DetailedMergeStatus == "" {
+		mr.DetailedMergeStatus = "mergeable"
+	}
+	if mr.Author == nil {
+		mr.Author = s.basicUser(s.currentUser)
+	}
+	if mr.Labels == nil {
+		mr.Labels = gitlab.Labels{}
+	}
+	if mr.SHA == "" {
+		if b := s.repositories[p.ID][mr.SourceBranch]; b != nil && b.commit != nil {
+			mr.SHA = b.commit.ID
+		}
+	}
+	if mr.WebURL == "" {
+		mr.WebURL = fmt.Sprintf("%s/-/merge_requests/%d", p.WebURL, mr.IID)
+	}
+	if mr.CreatedAt == nil {
+		mr.CreatedAt = gitlab.Ptr(time.Now().UTC())
+	}
+	if mr.UpdatedAt == nil {
+		mr.UpdatedAt = mr.CreatedAt
+	}
+	s.mergeRequests[p.ID] = insertByID(s.mergeRequests[p.ID], &mr, func(mr *gitlab.MergeRequest) int64 { return mr.ID })
+
+	return mr
+}
+
+// newCommit creates a new commit in the given project with the given parent.
+func (s *FakeServer) newCommit(p *gitlab.Project, message string, parent *gitlab.Commit) *gitlab.Commit {
+	seq := s.nextID("commits")
+	sum := sha1.Sum(fmt.Appendf(nil, "%d\x00%d\x00%s", p.ID, seq, message))
+	id := hex.EncodeToString(sum[:])
+	now := time.Now().UTC()
+
+	c := &gitlab.Commit{
+		ID:             id,
+		ShortID:        id[:8],
+		Title:          strings.SplitN(message, "\n", 2)[0],
+		Message:        message,
+		AuthorName:     s.currentUser.Name,
+		AuthorEmail:    s.currentUser.Email,
+		AuthoredDate:   &now,
+		CommitterName:  s.currentUser.Name,
+		CommitterEmail: s.currentUser.Email,
+		CommittedDate:  &now,
+		CreatedAt:      &now,
+		ParentIDs:      []string{},
+		ProjectID:      p.ID,
+		WebURL:         fmt.Sprintf("%s/-/commit/%s", p.WebURL, id),
+	}
+	if parent != nil {
+		c.ParentIDs = []string{parent.ID}
+	}
+
+	return c
+}
+
+// resolveRef returns the branch the given ref points to. The ref can either
+// be a branch name or the ID of the head commit of a branch.
+func (s *FakeServer) resolveRef(pid int64, ref string) *fakeBranch {
+	repo := s.repositories[pid]
+	if b, ok := repo[ref]; ok {
+		return b
+	}
+	for _, b := range repo {
+		if b.commit != nil && b.commit.ID == ref {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) userByID(id int64) *gitlab.User {
+	for _, u := range s.users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) groupByID(id int64) *gitlab.Group {
+	for _, g := range s.groups {
+		if g.ID == id {
+			return g
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) projectByID(id int64) *gitlab.Project {
+	for _, p := range s.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// findGroup looks up a group by its numeric ID or its full path.
+func (s *FakeServer) findGroup(id string) *gitlab.Group {
+	if n, err := strconv.ParseInt(id, 10, 64); err == nil {
+		return s.groupByID(n)
+	}
+	for _, g := range s.groups {
+		if strings.EqualFold(g.FullPath, id) {
+			return g
+		}
+	}
+	return nil
+}
+
+// findProject looks up a project by its numeric ID or its full path.
+func (s *FakeServer) findProject(id string) *gitlab.Project {
+	if n, err := strconv.ParseInt(id, 10, 64); err == nil {
+		return s.projectByID(n)
+	}
+	for _, p := range s.projects {
+		if strings.EqualFold(p.PathWithNamespace, id) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (s *FakeServer) userNamespace(u *gitlab.User) *gitlab.ProjectNamespace {
+	return &gitlab.ProjectNamespace{
+		ID:       u.ID,
+		Name:     u.Name,
+		Path:     u.Username,
+		Kind:     "user",
+		FullPath: u.Username,
+		WebURL:   u.WebURL,
+	}
+}
+
+func (s *FakeServer) groupNamespace(g *gitlab.Group) *gitlab.ProjectNamespace {
+	return &gitlab.ProjectNamespace{
+		ID:       g.ID,
+		Name:     g.Name,
+		Path:     g.Path,
+		Kind:     "group",
+		FullPath: g.FullPath,
+		ParentID: g.ParentID,
+		WebURL:   g.WebURL,
+	}
+}
+
+func (s *FakeServer) basicUser(u *gitlab.User) *gitlab.BasicUser {
+	return &gitlab.BasicUser{
+		ID:       u.ID,
+		Username: u.Username,
+		Name:     u.Name,
+		State:    u.State,
+		WebURL:   u.WebURL,
+	}
+}
+
+func (s *FakeServer) branchPayload(p *gitlab.Project, name string) *gitlab.Branch {
+	b := s.repositories[p.ID][name]
+	return &gitlab.Branch{
+		Commit:    b.commit,
+		Name:      name,
+		Default:   name == p.DefaultBranch,
+		Protected: name == p.DefaultBranch,
+		CanPush:   true,
+		WebURL:    fmt.Sprintf("%s/-/tree/%s", p.WebURL, name),
+	}
+}
+
+// clone returns a copy of the branch which shares the file contents, but not
+// the file map itself.
+func (b *fakeBranch) clone() *fakeBranch {
+	c := &fakeBranch{commit: b.commit, files: make(map[string]*fakeFile, len(b.files))}
+	for path, f := range b.files {
+		copied := *f
+		c.files[path] = &copied
+	}
+	return c
+}
+
+// blobID returns the git blob SHA of the file content, which is what GitLab
+// reports as blob_id.
+func (f *fakeFile) blobID() string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(f.content))
+	h.Write(f.content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (f *fakeFile) sha256() string {
+	sum := sha256.Sum256(f.content)
+	return hex.EncodeToString(sum[:])
+}
+
+// fakeSlug turns a name into a path the way GitLab does for simple names.
+func fakeSlug(name string) string {
+	return strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, name), "-")
+}
+
+// insertByID inserts or replaces item in items, keeping items sorted by ID.
+func insertByID[T any](items []T, item T, id func(T) int64) []T {
+	i, found := slices.BinarySearchFunc(items, id(item), func(e T, target int64) int {
+		switch {
+		case id(e) < target:
+			return -1
+		case id(e) > target:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if found {
+		items[i] = item
+		return items
+	}
+	return slices.Insert(items, i, item)
+}
+
+// fakeError is an error response of the FakeServer. The body is encoded the
+// same way GitLab encodes its errors, so the client can parse it into a
+// *gitlab.ErrorResponse.
+type fakeError struct {
+	status int
+	body   map[string]any
+}
+
+func (e *fakeError) Error() string {
+	return fmt.Sprintf("%d %v", e.status, e.body)
+}
+
+// errFakeNotFound returns a 404 error for the given resource, for example
+// "404 Project Not Found".
+func errFakeNotFound(resource string) error {
+	return &fakeError{
+		status: http.StatusNotFound,
+		body:   map[string]any{"message": fmt.Sprintf("404 %s Not Found", resource)},
+	}
+}
+
+// errFakeMissing returns the error GitLab responds with when required
+// parameters are missing.
+func errFakeMissing(params ...string) error {
+	return &fakeError{
+		status: http.StatusBadRequest,
+		body:   map[string]any{"error": strings.Join(params, ", ") + " is missing"},
+	}
+}
+
+// errFakeBadRequest returns a 400 error with the given message.
+func errFakeBadRequest(message any) error {
+	return &fakeError{
+		status: http.StatusBadRequest,
+		body:   map[string]any{"message": message},
+	}
+}
+
+// errFakeTaken returns the validation error GitLab responds with when a
+// unique attribute is already in use.
+func errFakeTaken(fields ...string) error {
+	message := make(map[string]any, len(fields))
+	for _, f := range fields {
+		message[f] = []string{"has already been taken"}
+	}
+	return errFakeBadRequest(message)
+}
+
+// writeFakeJSON writes v as JSON using the given status code.
+func writeFakeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeFakeError writes err as a GitLab error response.
+func writeFakeError(w http.ResponseWriter, err error) {
+	fe, ok := err.(*fakeError)
+	if !ok {
+		fe = &fakeError{
+			status: http.StatusInternalServerError,
+			body:   map[string]any{"message": "500 Internal Server Error"},
+		}
+	}
+	writeFakeJSON(w, fe.status, fe.body)
+}
+
+// decodeFakeBody decodes the JSON request body into v.
+func decodeFakeBody(r *http.Request, v any) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return &fakeError{
+			status: http.StatusBadRequest,
+			body:   map[string]any{"error": "400 Bad request - " + err.Error()},
+		}
+	}
+	return nil
+}
+
+// paginate applies offset-based or keyset-based pagination to items, which
+// must be sorted by ID, and sets the pagination headers GitLab would send.
+// Keyset-based pagination is only available if id is not nil.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T, id func(T) int64) ([]T, error) {
+	q := r.URL.Query()
+
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	if perPage <= 0 {
+		perPage = fakeDefaultPerPage
+	}
+	perPage = min(perPage, fakeMaxPerPage)
+
+	if q.Get("sort") == "desc" {
+		items = slices.Clone(items)
+		slices.Reverse(items)
+	}
+
+	if q.Get("pagination") == "keyset" {
+		if id == nil || (q.Get("order_by") != "" && q.Get("order_by") != "id") {
+			return nil, &fakeError{
+				status: http.StatusMethodNotAllowed,
+				body:   map[string]any{"error": "Keyset pagination is not yet available for this type of request"},
+			}
+		}
+		return paginateKeyset(w, r, items, id, perPage), nil
+	}
+
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page <= 0 {
+		page = 1
+	}
+
+	total := len(items)
+	totalPages := max(1, int(math.Ceil(float64(total)/float64(perPage))))
+
+	h := w.Header()
+	h.Set("X-Total", strconv.Itoa(total))
+	h.Set("X-Total-Pages", strconv.Itoa(totalPages))
+	h.Set("X-Per-Page", strconv.Itoa(perPage))
+	h.Set("X-Page", strconv.Itoa(page))
+	h.Set("X-Next-Page", "")
+	h.Set("X-Prev-Page", "")
+
+	links := []string{
+		fakeLink(r, q, "page", strconv.Itoa(1), "first"),
+		fakeLink(r, q, "page", strconv.Itoa(totalPages), "last"),
+	}
+	if page < totalPages {
+		h.Set("X-Next-Page", strconv.Itoa(page+1))
+		links = append(links, fakeLink(r, q, "page", strconv.Itoa(page+1), "next"))
+	}
+	if page > 1 && page <= totalPages {
+		h.Set("X-Prev-Page", strconv.Itoa(page-1))
+		links = append(links, fakeLink(r, q, "page", strconv.Itoa(page-1), "prev"))
+	}
+	h.Set("Link", strings.Join(links, ", "))
+
+	start := min((page-1)*perPage, total)
+	end := min(start+perPage, total)
+
+	return items[start:end], nil
+}
+
+// paginateKeyset applies keyset-based pagination ordered by ID to items.
+func paginateKeyset[T any](w http.ResponseWriter, r *http.Request, items []T, id func(T) int64, perPage int) []T {
+	q := r.URL.Query()
+	cursor, direction := "id_after", 1
+	if q.Get("sort") == "desc" {
+		cursor, direction = "id_before", -1
+	}
+
+	if v := q.Get(cursor); v != "" {
+		after, _ := strconv.ParseInt(v, 10, 64)
+		i := slices.IndexFunc(items, func(t T) bool { return (id(t)-after)*int64(direction) > 0 })
+		if i < 0 {
+			i = len(items)
+		}
+		items = items[i:]
+	}
+
+	if len(items) <= perPage {
+		return items
+	}
+
+	items = items[:perPage]
+	last := strconv.FormatInt(id(items[len(items)-1]), 10)
+	w.Header().Set("Link", fakeLink(r, q, cursor, last, "next"))
+
+	return items
+}
+
+// fakeLink returns a Link header entry for the current request with the
+// query parameter key set to value.
+func fakeLink(r *http.Request, q url.Values, key, value, rel string) string {
+	q = cloneQuery(q)
+	q.Set(key, value)
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: q.Encode(),
+	}
+
+	return fmt.Sprintf("<%s>; rel=%q", u.String(), rel)
+}
+
+// cloneQuery returns a deep copy of the query values.
+func cloneQuery(q url.Values) url.Values {
+	c := make(url.Values, len(q))
+	for k, v := range q {
+		c[k] = slices.Clone(v)
+	}
+	return c
+}
@@ -0,0 +1,1306 @@
+package testing
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+// fakeHandlerFunc handles a single request of the FakeServer. Returned errors
+// are written as GitLab error responses.
+type fakeHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// routes returns the handler serving all endpoints supported by the
+// FakeServer.
+func (s *FakeServer) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	handle := func(pattern string, h fakeHandlerFunc) {
+		method, route, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+fakeAPIPrefix+route, func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if err := h(w, r); err != nil {
+				writeFakeError(w, err)
+			}
+		})
+	}
+
+	handle("GET /user", s.getCurrentUser)
+	handle("GET /users", s.listUsers)
+	handle("POST /users", s.createUser)
+	handle("GET /users/{id}", s.getUser)
+	handle("DELETE /users/{id}", s.deleteUser)
+
+	handle("GET /groups", s.listGroups)
+	handle("POST /groups", s.createGroup)
+	handle("GET /groups/{id}", s.getGroup)
+	handle("DELETE /groups/{id}", s.deleteGroup)
+	handle("GET /groups/{id}/projects", s.listGroupProjects)
+
+	handle("GET /projects", s.listProjects)
+	handle("POST /projects", s.createProject)
+	handle("GET /projects/{id}", s.getProject)
+	handle("PUT /projects/{id}", s.editProject)
+	handle("DELETE /projects/{id}", s.deleteProject)
+
+	handle("GET /projects/{id}/issues", s.listIssues)
+	handle("POST /projects/{id}/issues", s.createIssue)
+	handle("GET /projects/{id}/issues/{iid}", s.getIssue)
+	handle("PUT /projects/{id}/issues/{iid}", s.updateIssue)
+	handle("DELETE /projects/{id}/issues/{iid}", s.deleteIssue)
+
+	handle("GET /projects/{id}/merge_requests", s.listMergeRequests)
+	handle("POST /projects/{id}/merge_requests", s.createMergeRequest)
+	handle("GET /projects/{id}/merge_requests/{iid}", s.getMergeRequest)
+	handle("PUT /projects/{id}/merge_requests/{iid}", s.updateMergeRequest)
+	handle("PUT /projects/{id}/merge_requests/{iid}/merge", s.acceptMergeRequest)
+
+	handle("GET /projects/{id}/repository/branches", s.listBranches)
+	handle("POST /projects/{id}/repository/branches", s.createBranch)
+	handle("GET /projects/{id}/repository/branches/{branch}", s.getBranch)
+	handle("DELETE /projects/{id}/repository/branches/{branch}", s.deleteBranch)
+
+	handle("GET /projects/{id}/repository/files/{path}", s.getFile)
+	handle("GET /projects/{id}/repository/files/{path}/raw", s.getRawFile)
+	handle("POST /projects/{id}/repository/files/{path}", s.createFile)
+	handle("PUT /projects/{id}/repository/files/{path}", s.updateFile)
+	handle("DELETE /projects/{id}/repository/files/{path}", s.deleteFile)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeJSON(w, http.StatusNotFound, map[string]any{"error": "404 Not Found"})
+	})
+
+	return s.authenticate(mux)
+}
+
+// authenticate rejects requests without a valid token if the FakeServer was
+// configured to require one.
+func (s *FakeServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		for _, got := range []string{
+			r.Header.Get(gitlab.AccessTokenHeaderName),
+			r.Header.Get(gitlab.JobTokenHeaderName),
+			strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+		} {
+			if subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		writeFakeJSON(w, http.StatusUnauthorized, map[string]any{"message": "401 Unauthorized"})
+	})
+}
+
+// Users
+
+func (s *FakeServer) getCurrentUser(w http.ResponseWriter, r *http.Request) error {
+	writeFakeJSON(w, http.StatusOK, s.currentUser)
+	return nil
+}
+
+func (s *FakeServer) listUsers(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+
+	var users []*gitlab.User
+	for _, u := range s.users {
+		if v := q.Get("username"); v != "" && !strings.EqualFold(u.Username, v) {
+			continue
+		}
+		if v := q.Get("search"); v != "" && !fakeContains(v, u.Username, u.Name, u.Email) {
+			continue
+		}
+		users = append(users, u)
+	}
+
+	page, err := paginate(w, r, users, func(u *gitlab.User) int64 { return u.ID })
+	if err != nil {
+		return err
+	}
+
+	writeFakeJSON(w, http.StatusOK, fakeList(page))
+	return nil
+}
+
+func (s *FakeServer) createUser(w http.ResponseWriter, r *http.Request) error {
+	var opt gitlab.CreateUserOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	var missing []string
+	for name, v := range map[string]*string{"email": opt.Email, "name": opt.Name, "username": opt.Username} {
+		if v == nil || *v == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return errFakeMissing(missing...)
+	}
+
+	for _, u := range s.users {
+		if strings.EqualFold(u.Username, *opt.Username) {
+			return &fakeError{
+				status: http.StatusConflict,
+				body:   map[string]any{"message": "Username has already been taken"},
+			}
+		}
+		if strings.EqualFold(u.Email, *opt.Email) {
+			return &fakeError{
+				status: http.StatusConflict,
+				body:   map[string]any{"message": "Email has already been taken"},
+			}
+		}
+	}
+
+	u := &gitlab.User{
+		ID:       s.nextID("users"),
+		Username: *opt.Username,
+		Name:     *opt.Name,
+		Email:    *opt.Email,
+		State:    "active",
+		WebURL:   s.webURL(*opt.Username),
+	}
+	if opt.Admin != nil {
+		u.IsAdmin = *opt.Admin
+	}
+	u.CreatedAt = gitlab.Ptr(time.Now().UTC())
+	s.users = insertByID(s.users, u, func(u *gitlab.User) int64 { return u.ID })
+
+	writeFakeJSON(w, http.StatusCreated, u)
+	return nil
+}
+
+func (s *FakeServer) getUser(w http.ResponseWriter, r *http.Request) error {
+	u := s.userFromPath(r)
+	if u == nil {
+		return errFakeNotFound("User")
+	}
+
+	writeFakeJSON(w, http.StatusOK, u)
+	return nil
+}
+
+func (s *FakeServer) deleteUser(w http.ResponseWriter, r *http.Request) error {
+	u := s.userFromPath(r)
+	if u == nil {
+		return errFakeNotFound("User")
+	}
+	if u == s.currentUser {
+		return &fakeError{
+			status: http.StatusForbidden,
+			body:   map[string]any{"message": "403 Forbidden"},
+		}
+	}
+
+	s.users = slices.DeleteFunc(s.users, func(other *gitlab.User) bool { return other == u })
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *FakeServer) userFromPath(r *http.Request) *gitlab.User {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return s.userByID(id)
+}
+
+// Groups
+
+func (s *FakeServer) listGroups(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+
+	var groups []*gitlab.Group
+	for _, g := range s.groups {
+		if v := q.Get("search"); v != "" && !fakeContains(v, g.Name, g.Path) {
+			continue
+		}
+		if q.Get("top_level_only") == "true" && g.ParentID != 0 {
+			continue
+		}
+		groups = append(groups, g)
+	}
+
+	page, err := paginate(w, r, groups, func(g *gitlab.Group) int64 { return g.ID })
+	if err != nil {
+		return err
+	}
+
+	writeFakeJSON(w, http.StatusOK, fakeList(page))
+	return nil
+}
+
+func (s *FakeServer) createGroup(w http.ResponseWriter, r *http.Request) error {
+	var opt gitlab.CreateGroupOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	switch {
+	case (opt.Name == nil || *opt.Name == "") && (opt.Path == nil || *opt.Path == ""):
+		return errFakeMissing("name", "path")
+	case opt.Name == nil || *opt.Name == "":
+		return errFakeMissing("name")
+	case opt.Path == nil || *opt.Path == "":
+		return errFakeMissing("path")
+	}
+
+	g := &gitlab.Group{
+		Name: *opt.Name,
+		Path: *opt.Path,
+	}
+	if opt.ParentID != nil {
+		if s.groupByID(*opt.ParentID) == nil {
+			return errFakeNotFound("Group")
+		}
+		g.ParentID = *opt.ParentID
+	}
+	if opt.Description != nil {
+		g.Description = *opt.Description
+	}
+	if opt.Visibility != nil {
+		g.Visibility = *opt.Visibility
+	}
+
+	for _, other := range s.groups {
+		if other.ParentID == g.ParentID && strings.EqualFold(other.Path, g.Path) {
+			return errFakeTaken("path")
+		}
+	}
+
+	stored := s.storeGroup(g)
+
+	writeFakeJSON(w, http.StatusCreated, stored)
+	return nil
+}
+
+func (s *FakeServer) getGroup(w http.ResponseWriter, r *http.Request) error {
+	g := s.findGroup(r.PathValue("id"))
+	if g == nil {
+		return errFakeNotFound("Group")
+	}
+
+	writeFakeJSON(w, http.StatusOK, g)
+	return nil
+}
+
+func (s *FakeServer) deleteGroup(w http.ResponseWriter, r *http.Request) error {
+	g := s.findGroup(r.PathValue("id"))
+	if g == nil {
+		return errFakeNotFound("Group")
+	}
+
+	for _, p := range slices.Clone(s.projects) {
+		if p.Namespace.Kind == "group" && p.Namespace.ID == g.ID {
+			s.removeProject(p)
+		}
+	}
+	s.groups = slices.DeleteFunc(s.groups, func(other *gitlab.Group) bool { return other == g })
+
+	writeFakeJSON(w, http.StatusAccepted, map[string]any{"message": "202 Accepted"})
+	return nil
+}
+
+func (s *FakeServer) listGroupProjects(w http.ResponseWriter, r *http.Request) error {
+	g := s.findGroup(r.PathValue("id"))
+	if g == nil {
+		return errFakeNotFound("Group")
+	}
+
+	return s.writeProjects(w, r, func(p *gitlab.Project) bool {
+		return p.Namespace.Kind == "group" && p.Namespace.ID == g.ID
+	})
+}
+
+// Projects
+
+func (s *FakeServer) listProjects(w http.ResponseWriter, r *http.Request) error {
+	return s.writeProjects(w, r, func(*gitlab.Project) bool { return true })
+}
+
+func (s *FakeServer) writeProjects(w http.ResponseWriter, r *http.Request, include func(*gitlab.Project) bool) error {
+	q := r.URL.Query()
+
+	var projects []*gitlab.Project
+	for _, p := range s.projects {
+		if !include(p) {
+			continue
+		}
+		if v := q.Get("search"); v != "" && !fakeContains(v, p.Name, p.Path) {
+			continue
+		}
+		if v := q.Get("visibility"); v != "" && string(p.Visibility) != v {
+			continue
+		}
+		if v := q.Get("archived"); v != "" && strconv.FormatBool(p.Archived) != v {
+			continue
+		}
+		projects = append(projects, p)
+	}
+
+	page, err := paginate(w, r, projects, func(p *gitlab.Project) int64 { return p.ID })
+	if err != nil {
+		return err
+	}
+
+	writeFakeJSON(w, http.StatusOK, fakeList(page))
+	return nil
+}
+
+func (s *FakeServer) createProject(w http.ResponseWriter, r *http.Request) error {
+	var opt gitlab.CreateProjectOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	if (opt.Name == nil || *opt.Name == "") && (opt.Path == nil || *opt.Path == "") {
+		return &fakeError{
+			status: http.StatusBadRequest,
+			body:   map[string]any{"error": "name, path are missing, at least one parameter must be provided"},
+		}
+	}
+
+	p := &gitlab.Project{
+		Name:      fakeValue(opt.Name),
+		Path:      fakeValue(opt.Path),
+		EmptyRepo: true,
+	}
+	if opt.NamespaceID != nil {
+		g := s.groupByID(*opt.NamespaceID)
+		if g == nil {
+			return errFakeNotFound("Namespace")
+		}
+		p.Namespace = s.groupNamespace(g)
+	}
+	p.Description = fakeValue(opt.Description)
+	p.DefaultBranch = fakeValue(opt.DefaultBranch)
+	if opt.Visibility != nil {
+		p.Visibility = *opt.Visibility
+	}
+	if opt.Topics != nil {
+		p.Topics = *opt.Topics
+	}
+
+	namespace := s.currentUser.Username
+	if p.Namespace != nil {
+		namespace = p.Namespace.FullPath
+	}
+	path := p.Path
+	if path == "" {
+		path = fakeSlug(p.Name)
+	}
+	if s.findProject(namespace+"/"+path) != nil {
+		return errFakeTaken("name", "path")
+	}
+
+	stored := s.storeProject(p)
+	if opt.InitializeWithReadme != nil && *opt.InitializeWithReadme {
+		s.initRepository(&stored)
+		readme := s.repositories[stored.ID][stored.DefaultBranch]
+		readme.files["README.md"] = &fakeFile{
+			content:      fmt.Appendf(nil, "# %s\n", stored.Name),
+			lastCommitID: readme.commit.ID,
+		}
+	}
+
+	writeFakeJSON(w, http.StatusCreated, stored)
+	return nil
+}
+
+func (s *FakeServer) getProject(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	writeFakeJSON(w, http.StatusOK, p)
+	return nil
+}
+
+func (s *FakeServer) editProject(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	var opt gitlab.EditProjectOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	if opt.Name != nil {
+		p.Name = *opt.Name
+		p.NameWithNamespace = p.Namespace.Name + " / " + p.Name
+	}
+	if opt.Description != nil {
+		p.Description = *opt.Description
+	}
+	if opt.DefaultBranch != nil {
+		if len(s.repositories[p.ID]) > 0 && s.repositories[p.ID][*opt.DefaultBranch] == nil {
+			return errFakeBadRequest(map[string]any{"base": []string{"Could not change HEAD: branch '" + *opt.DefaultBranch + "' does not exist"}})
+		}
+		p.DefaultBranch = *opt.DefaultBranch
+	}
+	if opt.Visibility != nil {
+		p.Visibility = *opt.Visibility
+	}
+	if opt.Topics != nil {
+		p.Topics = *opt.Topics
+	}
+	p.LastActivityAt = gitlab.Ptr(time.Now().UTC())
+
+	writeFakeJSON(w, http.StatusOK, p)
+	return nil
+}
+
+func (s *FakeServer) deleteProject(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	s.removeProject(p)
+
+	writeFakeJSON(w, http.StatusAccepted, map[string]any{"message": "202 Accepted"})
+	return nil
+}
+
+func (s *FakeServer) removeProject(p *gitlab.Project) {
+	s.projects = slices.DeleteFunc(s.projects, func(other *gitlab.Project) bool { return other == p })
+	delete(s.issues, p.ID)
+	delete(s.mergeRequests, p.ID)
+	delete(s.repositories, p.ID)
+}
+
+// Issues
+
+func (s *FakeServer) listIssues(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	q := r.URL.Query()
+
+	var issues []*gitlab.Issue
+	for _, i := range s.issues[p.ID] {
+		if v := q.Get("state"); v != "" && v != "all" && i.State != v {
+			continue
+		}
+		if !fakeHasLabels(i.Labels, q.Get("labels")) {
+			continue
+		}
+		if v := q.Get("search"); v != "" && !fakeContains(v, i.Title, i.Description) {
+			continue
+		}
+		issues = append(issues, i)
+	}
+
+	page, err := paginate(w, r, issues, func(i *gitlab.Issue) int64 { return i.ID })
+	if err != nil {
+		return err
+	}
+
+	writeFakeJSON(w, http.StatusOK, fakeList(page))
+	return nil
+}
+
+func (s *FakeServer) createIssue(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	var opt gitlab.CreateIssueOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+	if opt.Title == nil || *opt.Title == "" {
+		return errFakeMissing("title")
+	}
+
+	i := &gitlab.Issue{
+		Title:       *opt.Title,
+		Description: fakeValue(opt.Description),
+		Labels:      fakeLabels(opt.Labels),
+	}
+	if opt.Confidential != nil {
+		i.Confidential = *opt.Confidential
+	}
+
+	stored := s.storeIssue(p, i)
+
+	writeFakeJSON(w, http.StatusCreated, stored)
+	return nil
+}
+
+func (s *FakeServer) getIssue(w http.ResponseWriter, r *http.Request) error {
+	i, err := s.issueFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	writeFakeJSON(w, http.StatusOK, i)
+	return nil
+}
+
+func (s *FakeServer) updateIssue(w http.ResponseWriter, r *http.Request) error {
+	i, err := s.issueFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	var opt gitlab.UpdateIssueOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	if opt.Title != nil {
+		i.Title = *opt.Title
+	}
+	if opt.Description != nil {
+		i.Description = *opt.Description
+	}
+	if opt.Confidential != nil {
+		i.Confidential = *opt.Confidential
+	}
+	i.Labels = fakeUpdateLabels(i.Labels, opt.Labels, opt.AddLabels, opt.RemoveLabels)
+
+	now := time.Now().UTC()
+	switch fakeValue(opt.StateEvent) {
+	case "close":
+		i.State, i.ClosedAt = "closed", &now
+	case "reopen":
+		i.State, i.ClosedAt = "opened", nil
+	}
+	i.UpdatedAt = &now
+
+	writeFakeJSON(w, http.StatusOK, i)
+	return nil
+}
+
+func (s *FakeServer) deleteIssue(w http.ResponseWriter, r *http.Request) error {
+	i, err := s.issueFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	s.issues[i.ProjectID] = slices.DeleteFunc(s.issues[i.ProjectID], func(other *gitlab.Issue) bool { return other == i })
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *FakeServer) issueFromPath(r *http.Request) (*gitlab.Issue, error) {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return nil, errFakeNotFound("Project")
+	}
+
+	iid, _ := strconv.ParseInt(r.PathValue("iid"), 10, 64)
+	for _, i := range s.issues[p.ID] {
+		if i.IID == iid {
+			return i, nil
+		}
+	}
+
+	return nil, errFakeNotFound("Issue")
+}
+
+// Merge requests
+
+func (s *FakeServer) listMergeRequests(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	q := r.URL.Query()
+
+	var mergeRequests []*gitlab.MergeRequest
+	for _, mr := range s.mergeRequests[p.ID] {
+		if v := q.Get("state"); v != "" && v != "all" && mr.State != v {
+			continue
+		}
+		if v := q.Get("source_branch"); v != "" && mr.SourceBranch != v {
+			continue
+		}
+		if v := q.Get("target_branch"); v != "" && mr.TargetBranch != v {
+			continue
+		}
+		if !fakeHasLabels(mr.Labels, q.Get("labels")) {
+			continue
+		}
+		if v := q.Get("search"); v != "" && !fakeContains(v, mr.Title, mr.Description) {
+			continue
+		}
+		mergeRequests = append(mergeRequests, mr)
+	}
+
+	page, err := paginate(w, r, mergeRequests, func(mr *gitlab.MergeRequest) int64 { return mr.ID })
+	if err != nil {
+		return err
+	}
+
+	// The list endpoint returns basic merge requests only.
+	basic := make([]*gitlab.BasicMergeRequest, 0, len(page))
+	for _, mr := range page {
+		basic = append(basic, &mr.BasicMergeRequest)
+	}
+
+	writeFakeJSON(w, http.StatusOK, basic)
+	return nil
+}
+
+func (s *FakeServer) createMergeRequest(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	var opt gitlab.CreateMergeRequestOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	var missing []string
+	if opt.SourceBranch == nil || *opt.SourceBranch == "" {
+		missing = append(missing, "source_branch")
+	}
+	if opt.TargetBranch == nil || *opt.TargetBranch == "" {
+		missing = append(missing, "target_branch")
+	}
+	if opt.Title == nil || *opt.Title == "" {
+		missing = append(missing, "title")
+	}
+	if len(missing) > 0 {
+		return errFakeMissing(missing...)
+	}
+
+	var problems []string
+	if s.repositories[p.ID][*opt.SourceBranch] == nil {
+		problems = append(problems, fmt.Sprintf("Source branch %q does not exist", *opt.SourceBranch))
+	}
+	if s.repositories[p.ID][*opt.TargetBranch] == nil {
+		problems = append(problems, fmt.Sprintf("Target branch %q does not exist", *opt.TargetBranch))
+	}
+	if *opt.SourceBranch == *opt.TargetBranch {
+		problems = append(problems, "You can't use same project/branch for source and target")
+	}
+	if len(problems) > 0 {
+		return &fakeError{
+			status: http.StatusUnprocessableEntity,
+			body:   map[string]any{"message": problems},
+		}
+	}
+
+	for _, other := range s.mergeRequests[p.ID] {
+		if other.State == "opened" && other.SourceBranch == *opt.SourceBranch && other.TargetBranch == *opt.TargetBranch {
+			return &fakeError{
+				status: http.StatusConflict,
+				body: map[string]any{"message": []string{
+					fmt.Sprintf("Another open merge request already exists for this source branch: !%d", other.IID),
+				}},
+			}
+		}
+	}
+
+	mr := &gitlab.MergeRequest{}
+	mr.Title = *opt.Title
+	mr.Description = fakeValue(opt.Description)
+	mr.SourceBranch = *opt.SourceBranch
+	mr.TargetBranch = *opt.TargetBranch
+	mr.Labels = fakeLabels(opt.Labels)
+	mr.Draft = strings.HasPrefix(strings.ToLower(mr.Title), "draft:")
+	if opt.RemoveSourceBranch != nil {
+		mr.ForceRemoveSourceBranch = *opt.RemoveSourceBranch
+	}
+	if opt.Squash != nil {
+		mr.Squash = *opt.Squash
+	}
+
+	stored := s.storeMergeRequest(p, mr)
+
+	writeFakeJSON(w, http.StatusCreated, stored)
+	return nil
+}
+
+func (s *FakeServer) getMergeRequest(w http.ResponseWriter, r *http.Request) error {
+	_, mr, err := s.mergeRequestFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	writeFakeJSON(w, http.StatusOK, mr)
+	return nil
+}
+
+func (s *FakeServer) updateMergeRequest(w http.ResponseWriter, r *http.Request) error {
+	p, mr, err := s.mergeRequestFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	var opt gitlab.UpdateMergeRequestOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	if opt.Title != nil {
+		mr.Title = *opt.Title
+		mr.Draft = strings.HasPrefix(strings.ToLower(mr.Title), "draft:")
+	}
+	if opt.Description != nil {
+		mr.Description = *opt.Description
+	}
+	if opt.TargetBranch != nil {
+		if s.repositories[p.ID][*opt.TargetBranch] == nil {
+			return &fakeError{
+				status: http.StatusUnprocessableEntity,
+				body:   map[string]any{"message": []string{fmt.Sprintf("Target branch %q does not exist", *opt.TargetBranch)}},
+			}
+		}
+		mr.TargetBranch = *opt.TargetBranch
+	}
+	mr.Labels = fakeUpdateLabels(mr.Labels, opt.Labels, opt.AddLabels, opt.RemoveLabels)
+
+	now := time.Now().UTC()
+	switch fakeValue(opt.StateEvent) {
+	case "close":
+		if mr.State == "merged" {
+			return errFakeBadRequest("Cannot close a merged merge request")
+		}
+		mr.State, mr.ClosedAt, mr.ClosedBy = "closed", &now, s.basicUser(s.currentUser)
+	case "reopen":
+		if mr.State == "merged" {
+			return errFakeBadRequest("Cannot reopen a merged merge request")
+		}
+		mr.State, mr.ClosedAt, mr.ClosedBy = "opened", nil, nil
+	}
+	mr.UpdatedAt = &now
+
+	writeFakeJSON(w, http.StatusOK, mr)
+	return nil
+}
+
+func (s *FakeServer) acceptMergeRequest(w http.ResponseWriter, r *http.Request) error {
+	p, mr, err := s.mergeRequestFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	var opt gitlab.AcceptMergeRequestOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	if mr.State != "opened" || mr.Draft {
+		return &fakeError{
+			status: http.StatusMethodNotAllowed,
+			body:   map[string]any{"message": "405 Method Not Allowed"},
+		}
+	}
+
+	source := s.repositories[p.ID][mr.SourceBranch]
+	target := s.repositories[p.ID][mr.TargetBranch]
+	if source == nil || target == nil {
+		return &fakeError{
+			status: http.StatusUnprocessableEntity,
+			body:   map[string]any{"message": "Branch cannot be merged"},
+		}
+	}
+	if opt.SHA != nil && *opt.SHA != source.commit.ID {
+		return &fakeError{
+			status: http.StatusConflict,
+			body:   map[string]any{"message": "SHA does not match HEAD of source branch: " + source.commit.ID},
+		}
+	}
+
+	message := fakeValue(opt.MergeCommitMessage)
+	if message == "" {
+		message = fmt.Sprintf("Merge branch '%s' into '%s'\n\n%s\n\nSee merge request !%d", mr.SourceBranch, mr.TargetBranch, mr.Title, mr.IID)
+	}
+
+	commit := s.newCommit(p, message, target.commit)
+	commit.ParentIDs = append(commit.ParentIDs, source.commit.ID)
+	merged := source.clone()
+	merged.commit = commit
+	s.repositories[p.ID][mr.TargetBranch] = merged
+
+	now := time.Now().UTC()
+	mr.State = "merged"
+	mr.MergedAt = &now
+	mr.MergeUser = s.basicUser(s.currentUser)
+	mr.MergeCommitSHA = commit.ID
+	mr.UpdatedAt = &now
+
+	if (opt.ShouldRemoveSourceBranch != nil && *opt.ShouldRemoveSourceBranch) || mr.ForceRemoveSourceBranch {
+		delete(s.repositories[p.ID], mr.SourceBranch)
+	}
+
+	writeFakeJSON(w, http.StatusOK, mr)
+	return nil
+}
+
+func (s *FakeServer) mergeRequestFromPath(r *http.Request) (*gitlab.Project, *gitlab.MergeRequest, error) {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return nil, nil, errFakeNotFound("Project")
+	}
+
+	iid, _ := strconv.ParseInt(r.PathValue("iid"), 10, 64)
+	for _, mr := range s.mergeRequests[p.ID] {
+		if mr.IID == iid {
+			return p, mr, nil
+		}
+	}
+
+	return nil, nil, errFakeNotFound("Merge Request")
+}
+
+// Branches
+
+func (s *FakeServer) listBranches(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	search := r.URL.Query().Get("search")
+
+	var names []string
+	for name := range s.repositories[p.ID] {
+		if search == "" || fakeContains(search, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	// Branches are ordered by name, so keyset-based pagination is not
+	// available for this endpoint.
+	page, err := paginate[string](w, r, names, nil)
+	if err != nil {
+		return err
+	}
+
+	branches := make([]*gitlab.Branch, 0, len(page))
+	for _, name := range page {
+		branches = append(branches, s.branchPayload(p, name))
+	}
+
+	writeFakeJSON(w, http.StatusOK, branches)
+	return nil
+}
+
+func (s *FakeServer) createBranch(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	var opt gitlab.CreateBranchOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	var missing []string
+	if opt.Branch == nil || *opt.Branch == "" {
+		missing = append(missing, "branch")
+	}
+	if opt.Ref == nil || *opt.Ref == "" {
+		missing = append(missing, "ref")
+	}
+	if len(missing) > 0 {
+		return errFakeMissing(missing...)
+	}
+
+	if s.repositories[p.ID][*opt.Branch] != nil {
+		return errFakeBadRequest("Branch already exists")
+	}
+
+	source := s.resolveRef(p.ID, *opt.Ref)
+	if source == nil {
+		return errFakeBadRequest("Invalid reference name: " + *opt.Ref)
+	}
+
+	s.repositories[p.ID][*opt.Branch] = source.clone()
+
+	writeFakeJSON(w, http.StatusCreated, s.branchPayload(p, *opt.Branch))
+	return nil
+}
+
+func (s *FakeServer) getBranch(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	name := r.PathValue("branch")
+	if s.repositories[p.ID][name] == nil {
+		return errFakeNotFound("Branch")
+	}
+
+	writeFakeJSON(w, http.StatusOK, s.branchPayload(p, name))
+	return nil
+}
+
+func (s *FakeServer) deleteBranch(w http.ResponseWriter, r *http.Request) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	name := r.PathValue("branch")
+	if s.repositories[p.ID][name] == nil {
+		return errFakeNotFound("Branch")
+	}
+	if name == p.DefaultBranch {
+		return errFakeBadRequest("Cannot remove HEAD branch")
+	}
+
+	delete(s.repositories[p.ID], name)
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// Repository files
+
+func (s *FakeServer) getFile(w http.ResponseWriter, r *http.Request) error {
+	p, ref, filePath, f, err := s.fileFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	file := s.fileMetadata(w, ref, filePath, f)
+	file.Encoding = "base64"
+	file.Content = base64.StdEncoding.EncodeToString(f.content)
+	file.CommitID = s.resolveRef(p.ID, ref).commit.ID
+
+	writeFakeJSON(w, http.StatusOK, file)
+	return nil
+}
+
+func (s *FakeServer) getRawFile(w http.ResponseWriter, r *http.Request) error {
+	_, ref, filePath, f, err := s.fileFromPath(r)
+	if err != nil {
+		return err
+	}
+
+	s.fileMetadata(w, ref, filePath, f)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(f.content)
+	return nil
+}
+
+func (s *FakeServer) fileFromPath(r *http.Request) (*gitlab.Project, string, string, *fakeFile, error) {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return nil, "", "", nil, errFakeNotFound("Project")
+	}
+
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		if !strings.HasSuffix(r.URL.Path, "/raw") {
+			return nil, "", "", nil, errFakeMissing("ref")
+		}
+		ref = p.DefaultBranch
+	}
+
+	b := s.resolveRef(p.ID, ref)
+	if b == nil {
+		return nil, "", "", nil, errFakeNotFound("Commit")
+	}
+
+	filePath := r.PathValue("path")
+	f := b.files[filePath]
+	if f == nil {
+		return nil, "", "", nil, errFakeNotFound("File")
+	}
+
+	return p, ref, filePath, f, nil
+}
+
+// fileMetadata sets the X-Gitlab-* metadata headers for the file and returns
+// the metadata as a *gitlab.File.
+func (s *FakeServer) fileMetadata(w http.ResponseWriter, ref, filePath string, f *fakeFile) *gitlab.File {
+	file := &gitlab.File{
+		FileName:        path.Base(filePath),
+		FilePath:        filePath,
+		Size:            int64(len(f.content)),
+		ExecuteFilemode: f.executable,
+		Ref:             ref,
+		BlobID:          f.blobID(),
+		CommitID:        f.lastCommitID,
+		SHA256:          f.sha256(),
+		LastCommitID:    f.lastCommitID,
+	}
+
+	h := w.Header()
+	h.Set("X-Gitlab-Blob-Id", file.BlobID)
+	h.Set("X-Gitlab-Commit-Id", file.CommitID)
+	h.Set("X-Gitlab-Content-Sha256", file.SHA256)
+	h.Set("X-Gitlab-Encoding", "base64")
+	h.Set("X-Gitlab-Execute-Filemode", strconv.FormatBool(file.ExecuteFilemode))
+	h.Set("X-Gitlab-File-Name", file.FileName)
+	h.Set("X-Gitlab-File-Path", file.FilePath)
+	h.Set("X-Gitlab-Last-Commit-Id", file.LastCommitID)
+	h.Set("X-Gitlab-Ref", file.Ref)
+	h.Set("X-Gitlab-Size", strconv.FormatInt(file.Size, 10))
+
+	return file
+}
+
+func (s *FakeServer) createFile(w http.ResponseWriter, r *http.Request) error {
+	var opt gitlab.CreateFileOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	return s.commitFile(w, r, fakeFileChange{
+		action:        "create",
+		branch:        opt.Branch,
+		startBranch:   opt.StartBranch,
+		commitMessage: opt.CommitMessage,
+		content:       opt.Content,
+		encoding:      opt.Encoding,
+		executable:    opt.ExecuteFilemode,
+	})
+}
+
+func (s *FakeServer) updateFile(w http.ResponseWriter, r *http.Request) error {
+	var opt gitlab.UpdateFileOptions
+	if err := decodeFakeBody(r, &opt); err != nil {
+		return err
+	}
+
+	return s.commitFile(w, r, fakeFileChange{
+		action:        "update",
+		branch:        opt.Branch,
+		startBranch:   opt.StartBranch,
+		commitMessage: opt.CommitMessage,
+		content:       opt.Content,
+		encoding:      opt.Encoding,
+		executable:    opt.ExecuteFilemode,
+		lastCommitID:  opt.LastCommitID,
+	})
+}
+
+func (s *FakeServer) deleteFile(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	change := fakeFileChange{action: "delete"}
+	if q.Has("branch") {
+		change.branch = gitlab.Ptr(q.Get("branch"))
+	}
+	if q.Has("start_branch") {
+		change.startBranch = gitlab.Ptr(q.Get("start_branch"))
+	}
+	if q.Has("commit_message") {
+		change.commitMessage = gitlab.Ptr(q.Get("commit_message"))
+	}
+	if q.Has("last_commit_id") {
+		change.lastCommitID = gitlab.Ptr(q.Get("last_commit_id"))
+	}
+
+	return s.commitFile(w, r, change)
+}
+
+// fakeFileChange describes a single create, update or delete action of the
+// repository files API.
+type fakeFileChange struct {
+	action        string
+	branch        *string
+	startBranch   *string
+	commitMessage *string
+	content       *string
+	encoding      *string
+	executable    *bool
+	lastCommitID  *string
+}
+
+// commitFile applies the change to the file in the request path and commits
+// it to the target branch.
+func (s *FakeServer) commitFile(w http.ResponseWriter, r *http.Request, change fakeFileChange) error {
+	p := s.findProject(r.PathValue("id"))
+	if p == nil {
+		return errFakeNotFound("Project")
+	}
+
+	var missing []string
+	if change.branch == nil || *change.branch == "" {
+		missing = append(missing, "branch")
+	}
+	if change.commitMessage == nil || *change.commitMessage == "" {
+		missing = append(missing, "commit_message")
+	}
+	if change.action != "delete" && change.content == nil {
+		missing = append(missing, "content")
+	}
+	if len(missing) > 0 {
+		return errFakeMissing(missing...)
+	}
+
+	branch := *change.branch
+	b := s.repositories[p.ID][branch]
+	switch {
+	case b != nil:
+	case change.startBranch != nil && *change.startBranch != "":
+		start := s.repositories[p.ID][*change.startBranch]
+		if start == nil {
+			return errFakeBadRequest(fmt.Sprintf("Invalid reference name: %s", *change.startBranch))
+		}
+		b = start.clone()
+	case len(s.repositories[p.ID]) == 0:
+		// Committing to an empty repository creates the branch.
+		b = &fakeBranch{files: make(map[string]*fakeFile)}
+	default:
+		return errFakeBadRequest("You can only create or edit files when you are on a branch")
+	}
+
+	filePath := r.PathValue("path")
+	existing := b.files[filePath]
+	switch {
+	case change.action == "create" && existing != nil:
+		return errFakeBadRequest("A file with this name already exists")
+	case change.action != "create" && existing == nil:
+		return errFakeBadRequest("A file with this name doesn't exist")
+	case change.lastCommitID != nil && existing != nil && *change.lastCommitID != existing.lastCommitID:
+		return errFakeBadRequest("You are attempting to update a file that has changed since you started editing it.")
+	}
+
+	var content []byte
+	if change.content != nil {
+		content = []byte(*change.content)
+		if fakeValue(change.encoding) == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(*change.content)
+			if err != nil {
+				return errFakeBadRequest("Content is not valid base64")
+			}
+			content = decoded
+		}
+	}
+
+	commit := s.newCommit(p, *change.commitMessage, b.commit)
+	b.commit = commit
+	s.repositories[p.ID][branch] = b
+	p.EmptyRepo = false
+	p.LastActivityAt = commit.CreatedAt
+
+	if change.action == "delete" {
+		delete(b.files, filePath)
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+
+	f := &fakeFile{content: content, lastCommitID: commit.ID}
+	switch {
+	case change.executable != nil:
+		f.executable = *change.executable
+	case existing != nil:
+		f.executable = existing.executable
+	}
+	b.files[filePath] = f
+
+	status := http.StatusOK
+	if change.action == "create" {
+		status = http.StatusCreated
+	}
+
+	writeFakeJSON(w, status, &gitlab.FileInfo{FilePath: filePath, Branch: branch})
+	return nil
+}
+
+// fakeList makes sure empty lists are encoded as [] instead of null.
+func fakeList[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// fakeValue returns the value v points to, or the zero value if v is nil.
+func fakeValue[T any](v *T) T {
+	if v == nil {
+		var z T
+		return z
+	}
+	return *v
+}
+
+// fakeContains reports whether any of the values contains the search term,
+// ignoring case.
+func fakeContains(search string, values ...string) bool {
+	search = strings.ToLower(search)
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeLabels flattens label options, which may contain comma separated
+// labels when they were decoded from a JSON string.
+func fakeLabels(opt *gitlab.LabelOptions) gitlab.Labels {
+	labels := gitlab.Labels{}
+	if opt == nil {
+		return labels
+	}
+	for _, l := range *opt {
+		for label := range strings.SplitSeq(l, ",") {
+			if label = strings.TrimSpace(label); label != "" && !slices.Contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+// fakeUpdateLabels applies the label, add_labels and remove_labels options of
+// an update request to labels.
+func fakeUpdateLabels(labels gitlab.Labels, set, add, remove *gitlab.LabelOptions) gitlab.Labels {
+	if set != nil {
+		labels = fakeLabels(set)
+	}
+	for _, l := range fakeLabels(add) {
+		if !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+	removed := fakeLabels(remove)
+	return slices.DeleteFunc(slices.Clone(labels), func(l string) bool { return slices.Contains(removed, l) })
+}
+
+// fakeHasLabels reports whether labels contains all comma separated labels
+// of the filter.
+func fakeHasLabels(labels gitlab.Labels, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	for l := range strings.SplitSeq(filter, ",") {
+		if !slices.Contains(labels, strings.TrimSpace(l)) {
+			return false
+		}
+	}
+	return true
+}
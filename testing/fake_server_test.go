@@ -0,0 +1,278 @@
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+func TestFakeServer_Projects(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	// WHEN
+	created, resp, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+		Name:                 gitlab.Ptr("My Project"),
+		InitializeWithReadme: gitlab.Ptr(true),
+	})
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "my-project", created.Path)
+	assert.Equal(t, "root/my-project", created.PathWithNamespace)
+	assert.Equal(t, "main", created.DefaultBranch)
+
+	got, _, err := client.Projects.GetProject("root/my-project", nil)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+
+	readme, _, err := client.RepositoryFiles.GetRawFile(created.ID, "README.md", &gitlab.GetRawFileOptions{Ref: gitlab.Ptr("main")})
+	require.NoError(t, err)
+	assert.Equal(t, "# My Project\n", string(readme))
+
+	_, _, err = client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("My Project")})
+	require.Error(t, err)
+	assert.True(t, gitlab.HasStatusCode(err, http.StatusBadRequest))
+	assert.Contains(t, err.Error(), "has already been taken")
+
+	_, err = client.Projects.DeleteProject(created.ID, nil)
+	require.NoError(t, err)
+
+	_, _, err = client.Projects.GetProject(created.ID, nil)
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+}
+
+func TestFakeServer_OffsetPagination(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	for i := range 7 {
+		srv.AddProject(&gitlab.Project{Name: fmt.Sprintf("project-%d", i)})
+	}
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	// WHEN
+	opts := &gitlab.ListProjectsOptions{ListOptions: gitlab.ListOptions{PerPage: 3, Page: 2}}
+	projects, resp, err := client.Projects.ListProjects(opts)
+
+	// THEN
+	require.NoError(t, err)
+	require.Len(t, projects, 3)
+	assert.Equal(t, "project-3", projects[0].Name)
+	assert.Equal(t, int64(7), resp.TotalItems)
+	assert.Equal(t, int64(3), resp.TotalPages)
+	assert.Equal(t, int64(2), resp.CurrentPage)
+	assert.Equal(t, int64(3), resp.NextPage)
+	assert.Equal(t, int64(1), resp.PreviousPage)
+
+	all, err := gitlab.ScanAndCollect(func(p gitlab.PaginationOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+		return client.Projects.ListProjects(&gitlab.ListProjectsOptions{ListOptions: gitlab.ListOptions{PerPage: 3}}, p)
+	})
+	require.NoError(t, err)
+	assert.Len(t, all, 7)
+}
+
+func TestFakeServer_KeysetPagination(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	for i := range 5 {
+		srv.AddProject(&gitlab.Project{Name: fmt.Sprintf("project-%d", i)})
+	}
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	// WHEN
+	opts := &gitlab.ListProjectsOptions{
+		ListOptions: gitlab.ListOptions{
+			Pagination: "keyset",
+			OrderBy:    "id",
+			Sort:       "desc",
+			PerPage:    2,
+		},
+	}
+	projects, err := gitlab.ScanAndCollect(func(p gitlab.PaginationOptionFunc) ([]*gitlab.Project, *gitlab.Response, error) {
+		return client.Projects.ListProjects(opts, p)
+	})
+
+	// THEN
+	require.NoError(t, err)
+	require.Len(t, projects, 5)
+	for i, p := range projects {
+		assert.Equal(t, fmt.Sprintf("project-%d", 4-i), p.Name)
+	}
+
+	opts.ListOptions.OrderBy = "name"
+	_, _, err = client.Projects.ListProjects(opts)
+	assert.True(t, gitlab.HasStatusCode(err, http.StatusMethodNotAllowed))
+}
+
+func TestFakeServer_IssuesAndMergeRequests(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	group := srv.AddGroup(&gitlab.Group{Name: "Platform"})
+	project := srv.AddProject(&gitlab.Project{Name: "api", Namespace: &gitlab.ProjectNamespace{
+		ID: group.ID, Name: group.Name, Path: group.Path, Kind: "group", FullPath: group.FullPath,
+	}})
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	// WHEN
+	issue, _, err := client.Issues.CreateIssue("platform/api", &gitlab.CreateIssueOptions{
+		Title:  gitlab.Ptr("Broken build"),
+		Labels: &gitlab.LabelOptions{"bug", "ci"},
+	})
+	require.NoError(t, err)
+
+	_, _, err = client.Issues.UpdateIssue(project.ID, issue.IID, &gitlab.UpdateIssueOptions{
+		StateEvent:   gitlab.Ptr("close"),
+		RemoveLabels: &gitlab.LabelOptions{"ci"},
+	})
+	require.NoError(t, err)
+
+	// THEN
+	closed, _, err := client.Issues.ListProjectIssues(project.ID, &gitlab.ListProjectIssuesOptions{State: gitlab.Ptr("closed")})
+	require.NoError(t, err)
+	require.Len(t, closed, 1)
+	assert.Equal(t, gitlab.Labels{"bug"}, closed[0].Labels)
+
+	_, _, err = client.Branches.CreateBranch(project.ID, &gitlab.CreateBranchOptions{
+		Branch: gitlab.Ptr("feature/fix"),
+		Ref:    gitlab.Ptr("main"),
+	})
+	require.NoError(t, err)
+
+	_, _, err = client.RepositoryFiles.CreateFile(project.ID, "docs/fix.md", &gitlab.CreateFileOptions{
+		Branch:        gitlab.Ptr("feature/fix"),
+		Content:       gitlab.Ptr("fixed"),
+		CommitMessage: gitlab.Ptr("Fix the build"),
+	})
+	require.NoError(t, err)
+
+	mrOpts := &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr("Fix the build"),
+		SourceBranch: gitlab.Ptr("feature/fix"),
+		TargetBranch: gitlab.Ptr("main"),
+	}
+	mr, _, err := client.MergeRequests.CreateMergeRequest(project.ID, mrOpts)
+	require.NoError(t, err)
+	assert.Equal(t, "opened", mr.State)
+
+	_, _, err = client.MergeRequests.CreateMergeRequest(project.ID, mrOpts)
+	assert.True(t, gitlab.HasStatusCode(err, http.StatusConflict))
+
+	merged, _, err := client.MergeRequests.AcceptMergeRequest(project.ID, mr.IID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "merged", merged.State)
+
+	file, _, err := client.RepositoryFiles.GetFile(project.ID, "docs/fix.md", &gitlab.GetFileOptions{Ref: gitlab.Ptr("main")})
+	require.NoError(t, err)
+	assert.Equal(t, "Zml4ZWQ=", file.Content)
+}
+
+func TestFakeServer_Files(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	project := srv.AddProject(&gitlab.Project{Name: "files"})
+	srv.AddFile(project.ID, "main", "hello.txt", []byte("hello"))
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	// WHEN
+	meta, _, err := client.RepositoryFiles.GetFileMetaData(project.ID, "hello.txt", &gitlab.GetFileMetaDataOptions{Ref: gitlab.Ptr("main")})
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, "b6fc4c620b67d95f953a5c1c1230aaab5db5a1b0", meta.BlobID)
+	assert.Equal(t, int64(5), meta.Size)
+
+	_, _, err = client.RepositoryFiles.UpdateFile(project.ID, "hello.txt", &gitlab.UpdateFileOptions{
+		Branch:        gitlab.Ptr("main"),
+		Content:       gitlab.Ptr("hello world"),
+		CommitMessage: gitlab.Ptr("Update hello.txt"),
+		LastCommitID:  gitlab.Ptr("0000000000000000000000000000000000000000"),
+	})
+	var errResp *gitlab.ErrorResponse
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, "{message: You are attempting to update a file that has changed since you started editing it.}", errResp.Message)
+
+	_, err = client.RepositoryFiles.DeleteFile(project.ID, "hello.txt", &gitlab.DeleteFileOptions{
+		Branch:        gitlab.Ptr("main"),
+		CommitMessage: gitlab.Ptr("Remove hello.txt"),
+	})
+	require.NoError(t, err)
+
+	_, _, err = client.RepositoryFiles.GetFile(project.ID, "hello.txt", &gitlab.GetFileOptions{Ref: gitlab.Ptr("main")})
+	assert.ErrorIs(t, err, gitlab.ErrNotFound)
+
+	_, _, err = client.RepositoryFiles.CreateFile(project.ID, "new.txt", &gitlab.CreateFileOptions{Branch: gitlab.Ptr("main")})
+	require.True(t, errors.As(err, &errResp))
+	assert.Equal(t, "{error: commit_message, content is missing}", errResp.Message)
+}
+
+func TestFakeServer_Authentication(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t, WithFakeServerToken("glpat-secret"))
+
+	// WHEN
+	client, err := gitlab.NewClient("wrong", gitlab.WithBaseURL(srv.URL()))
+	require.NoError(t, err)
+	_, _, err = client.Users.CurrentUser()
+
+	// THEN
+	assert.True(t, gitlab.HasStatusCode(err, http.StatusUnauthorized))
+
+	client, err = srv.NewClient()
+	require.NoError(t, err)
+	user, _, err := client.Users.CurrentUser()
+	require.NoError(t, err)
+	assert.Equal(t, "root", user.Username)
+}
+
+func TestFakeServer_ExplicitIDs(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	explicit := srv.AddProject(&gitlab.Project{ID: 5, Name: "explicit"})
+	user := srv.AddUser(&gitlab.User{ID: 50, Username: "jane"})
+
+	// WHEN
+	created, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{Name: gitlab.Ptr("generated")})
+	require.NoError(t, err)
+	other := srv.AddUser(&gitlab.User{Username: "john"})
+
+	// THEN
+	assert.Equal(t, int64(6), created.ID)
+	assert.Equal(t, int64(51), other.ID)
+
+	got, _, err := client.Projects.GetProject(explicit.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", got.Name)
+
+	gotUser, _, err := client.Users.GetUser(user.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "jane", gotUser.Username)
+}
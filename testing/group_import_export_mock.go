@@ -10,6 +10,7 @@ package testing
 
 import (
 	bytes "bytes"
+	io "io"
 	reflect "reflect"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
@@ -172,3 +173,48 @@ func (c *MockGroupImportExportServiceInterfaceScheduleExportCall) DoAndReturn(f
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// StreamExportDownload mocks base method.
+func (m *MockGroupImportExportServiceInterface) StreamExportDownload(gid any, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{gid}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamExportDownload", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamExportDownload indicates an expected call of StreamExportDownload.
+func (mr *MockGroupImportExportServiceInterfaceMockRecorder) StreamExportDownload(gid any, options ...any) *MockGroupImportExportServiceInterfaceStreamExportDownloadCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{gid}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamExportDownload", reflect.TypeOf((*MockGroupImportExportServiceInterface)(nil).StreamExportDownload), varargs...)
+	return &MockGroupImportExportServiceInterfaceStreamExportDownloadCall{Call: call}
+}
+
+// MockGroupImportExportServiceInterfaceStreamExportDownloadCall wrap *gomock.Call
+type MockGroupImportExportServiceInterfaceStreamExportDownloadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockGroupImportExportServiceInterfaceStreamExportDownloadCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockGroupImportExportServiceInterfaceStreamExportDownloadCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockGroupImportExportServiceInterfaceStreamExportDownloadCall) Do(f func(any, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockGroupImportExportServiceInterfaceStreamExportDownloadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockGroupImportExportServiceInterfaceStreamExportDownloadCall) DoAndReturn(f func(any, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockGroupImportExportServiceInterfaceStreamExportDownloadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
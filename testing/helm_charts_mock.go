@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitlab.com/gitlab-org/api/client-go/v2 (interfaces: HelmChartsServiceInterface)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=helm_charts_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 HelmChartsServiceInterface
+//
+
+package testing
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHelmChartsServiceInterface is a mock of HelmChartsServiceInterface interface.
+type MockHelmChartsServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockHelmChartsServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockHelmChartsServiceInterfaceMockRecorder is the mock recorder for MockHelmChartsServiceInterface.
+type MockHelmChartsServiceInterfaceMockRecorder struct {
+	mock *MockHelmChartsServiceInterface
+}
+
+// NewMockHelmChartsServiceInterface creates a new mock instance.
+func NewMockHelmChartsServiceInterface(ctrl *gomock.Controller) *MockHelmChartsServiceInterface {
+	mock := &MockHelmChartsServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockHelmChartsServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHelmChartsServiceInterface) EXPECT() *MockHelmChartsServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DownloadChart mocks base method.
+func (m *MockHelmChartsServiceInterface) DownloadChart(pid any, channel, fileName string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, channel, fileName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadChart", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadChart indicates an expected call of DownloadChart.
+func (mr *MockHelmChartsServiceInterfaceMockRecorder) DownloadChart(pid, channel, fileName any, options ...any) *MockHelmChartsServiceInterfaceDownloadChartCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, channel, fileName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadChart", reflect.TypeOf((*MockHelmChartsServiceInterface)(nil).DownloadChart), varargs...)
+	return &MockHelmChartsServiceInterfaceDownloadChartCall{Call: call}
+}
+
+// MockHelmChartsServiceInterfaceDownloadChartCall wrap *gomock.Call
+type MockHelmChartsServiceInterfaceDownloadChartCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHelmChartsServiceInterfaceDownloadChartCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockHelmChartsServiceInterfaceDownloadChartCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHelmChartsServiceInterfaceDownloadChartCall) Do(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockHelmChartsServiceInterfaceDownloadChartCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHelmChartsServiceInterfaceDownloadChartCall) DoAndReturn(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockHelmChartsServiceInterfaceDownloadChartCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetIndex mocks base method.
+func (m *MockHelmChartsServiceInterface) GetIndex(pid any, channel string, options ...gitlab.RequestOptionFunc) (*gitlab.HelmIndex, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, channel}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetIndex", varargs...)
+	ret0, _ := ret[0].(*gitlab.HelmIndex)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIndex indicates an expected call of GetIndex.
+func (mr *MockHelmChartsServiceInterfaceMockRecorder) GetIndex(pid, channel any, options ...any) *MockHelmChartsServiceInterfaceGetIndexCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, channel}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndex", reflect.TypeOf((*MockHelmChartsServiceInterface)(nil).GetIndex), varargs...)
+	return &MockHelmChartsServiceInterfaceGetIndexCall{Call: call}
+}
+
+// MockHelmChartsServiceInterfaceGetIndexCall wrap *gomock.Call
+type MockHelmChartsServiceInterfaceGetIndexCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHelmChartsServiceInterfaceGetIndexCall) Return(arg0 *gitlab.HelmIndex, arg1 *gitlab.Response, arg2 error) *MockHelmChartsServiceInterfaceGetIndexCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHelmChartsServiceInterfaceGetIndexCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.HelmIndex, *gitlab.Response, error)) *MockHelmChartsServiceInterfaceGetIndexCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHelmChartsServiceInterfaceGetIndexCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.HelmIndex, *gitlab.Response, error)) *MockHelmChartsServiceInterfaceGetIndexCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UploadChart mocks base method.
+func (m *MockHelmChartsServiceInterface) UploadChart(pid any, channel, fileName string, content io.Reader, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, channel, fileName, content}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadChart", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadChart indicates an expected call of UploadChart.
+func (mr *MockHelmChartsServiceInterfaceMockRecorder) UploadChart(pid, channel, fileName, content any, options ...any) *MockHelmChartsServiceInterfaceUploadChartCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, channel, fileName, content}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadChart", reflect.TypeOf((*MockHelmChartsServiceInterface)(nil).UploadChart), varargs...)
+	return &MockHelmChartsServiceInterfaceUploadChartCall{Call: call}
+}
+
+// MockHelmChartsServiceInterfaceUploadChartCall wrap *gomock.Call
+type MockHelmChartsServiceInterfaceUploadChartCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockHelmChartsServiceInterfaceUploadChartCall) Return(arg0 *gitlab.Response, arg1 error) *MockHelmChartsServiceInterfaceUploadChartCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockHelmChartsServiceInterfaceUploadChartCall) Do(f func(any, string, string, io.Reader, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockHelmChartsServiceInterfaceUploadChartCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockHelmChartsServiceInterfaceUploadChartCall) DoAndReturn(f func(any, string, string, io.Reader, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockHelmChartsServiceInterfaceUploadChartCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -10,6 +10,8 @@ package testing
 
 import (
 	bytes "bytes"
+	io "io"
+	iter "iter"
 	reflect "reflect"
 
 	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
@@ -353,6 +355,49 @@ func (c *MockJobsServiceInterfaceEraseJobCall) DoAndReturn(f func(any, int64, ..
 	return c
 }
 
+// FollowTrace mocks base method.
+func (m *MockJobsServiceInterface) FollowTrace(pid any, jobID int64, opt *gitlab.FollowTraceOptions, options ...gitlab.RequestOptionFunc) iter.Seq2[[]byte, error] {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, jobID, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FollowTrace", varargs...)
+	ret0, _ := ret[0].(iter.Seq2[[]byte, error])
+	return ret0
+}
+
+// FollowTrace indicates an expected call of FollowTrace.
+func (mr *MockJobsServiceInterfaceMockRecorder) FollowTrace(pid, jobID, opt any, options ...any) *MockJobsServiceInterfaceFollowTraceCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, jobID, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowTrace", reflect.TypeOf((*MockJobsServiceInterface)(nil).FollowTrace), varargs...)
+	return &MockJobsServiceInterfaceFollowTraceCall{Call: call}
+}
+
+// MockJobsServiceInterfaceFollowTraceCall wrap *gomock.Call
+type MockJobsServiceInterfaceFollowTraceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceFollowTraceCall) Return(arg0 iter.Seq2[[]byte, error]) *MockJobsServiceInterfaceFollowTraceCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceFollowTraceCall) Do(f func(any, int64, *gitlab.FollowTraceOptions, ...gitlab.RequestOptionFunc) iter.Seq2[[]byte, error]) *MockJobsServiceInterfaceFollowTraceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceFollowTraceCall) DoAndReturn(f func(any, int64, *gitlab.FollowTraceOptions, ...gitlab.RequestOptionFunc) iter.Seq2[[]byte, error]) *MockJobsServiceInterfaceFollowTraceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetJob mocks base method.
 func (m *MockJobsServiceInterface) GetJob(pid any, jobID int64, options ...gitlab.RequestOptionFunc) (*gitlab.Job, *gitlab.Response, error) {
 	m.ctrl.T.Helper()
@@ -802,3 +847,273 @@ func (c *MockJobsServiceInterfaceRetryJobCall) DoAndReturn(f func(any, int64, ..
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// StreamArtifactsFile mocks base method.
+func (m *MockJobsServiceInterface) StreamArtifactsFile(pid any, refName string, opt *gitlab.DownloadArtifactsFileOptions, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, refName, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamArtifactsFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamArtifactsFile indicates an expected call of StreamArtifactsFile.
+func (mr *MockJobsServiceInterfaceMockRecorder) StreamArtifactsFile(pid, refName, opt any, options ...any) *MockJobsServiceInterfaceStreamArtifactsFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, refName, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamArtifactsFile", reflect.TypeOf((*MockJobsServiceInterface)(nil).StreamArtifactsFile), varargs...)
+	return &MockJobsServiceInterfaceStreamArtifactsFileCall{Call: call}
+}
+
+// MockJobsServiceInterfaceStreamArtifactsFileCall wrap *gomock.Call
+type MockJobsServiceInterfaceStreamArtifactsFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceStreamArtifactsFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockJobsServiceInterfaceStreamArtifactsFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceStreamArtifactsFileCall) Do(f func(any, string, *gitlab.DownloadArtifactsFileOptions, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamArtifactsFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceStreamArtifactsFileCall) DoAndReturn(f func(any, string, *gitlab.DownloadArtifactsFileOptions, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamArtifactsFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StreamJobArtifacts mocks base method.
+func (m *MockJobsServiceInterface) StreamJobArtifacts(pid any, jobID int64, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, jobID}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamJobArtifacts", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamJobArtifacts indicates an expected call of StreamJobArtifacts.
+func (mr *MockJobsServiceInterfaceMockRecorder) StreamJobArtifacts(pid, jobID any, options ...any) *MockJobsServiceInterfaceStreamJobArtifactsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, jobID}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamJobArtifacts", reflect.TypeOf((*MockJobsServiceInterface)(nil).StreamJobArtifacts), varargs...)
+	return &MockJobsServiceInterfaceStreamJobArtifactsCall{Call: call}
+}
+
+// MockJobsServiceInterfaceStreamJobArtifactsCall wrap *gomock.Call
+type MockJobsServiceInterfaceStreamJobArtifactsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceStreamJobArtifactsCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockJobsServiceInterfaceStreamJobArtifactsCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceStreamJobArtifactsCall) Do(f func(any, int64, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamJobArtifactsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceStreamJobArtifactsCall) DoAndReturn(f func(any, int64, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamJobArtifactsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StreamSingleArtifactsFile mocks base method.
+func (m *MockJobsServiceInterface) StreamSingleArtifactsFile(pid any, jobID int64, artifactPath string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, jobID, artifactPath}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamSingleArtifactsFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamSingleArtifactsFile indicates an expected call of StreamSingleArtifactsFile.
+func (mr *MockJobsServiceInterfaceMockRecorder) StreamSingleArtifactsFile(pid, jobID, artifactPath any, options ...any) *MockJobsServiceInterfaceStreamSingleArtifactsFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, jobID, artifactPath}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSingleArtifactsFile", reflect.TypeOf((*MockJobsServiceInterface)(nil).StreamSingleArtifactsFile), varargs...)
+	return &MockJobsServiceInterfaceStreamSingleArtifactsFileCall{Call: call}
+}
+
+// MockJobsServiceInterfaceStreamSingleArtifactsFileCall wrap *gomock.Call
+type MockJobsServiceInterfaceStreamSingleArtifactsFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceStreamSingleArtifactsFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockJobsServiceInterfaceStreamSingleArtifactsFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceStreamSingleArtifactsFileCall) Do(f func(any, int64, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamSingleArtifactsFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceStreamSingleArtifactsFileCall) DoAndReturn(f func(any, int64, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamSingleArtifactsFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StreamSingleArtifactsFileByTagOrBranch mocks base method.
+func (m *MockJobsServiceInterface) StreamSingleArtifactsFileByTagOrBranch(pid any, refName, artifactPath string, opt *gitlab.DownloadArtifactsFileOptions, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, refName, artifactPath, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamSingleArtifactsFileByTagOrBranch", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamSingleArtifactsFileByTagOrBranch indicates an expected call of StreamSingleArtifactsFileByTagOrBranch.
+func (mr *MockJobsServiceInterfaceMockRecorder) StreamSingleArtifactsFileByTagOrBranch(pid, refName, artifactPath, opt any, options ...any) *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, refName, artifactPath, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSingleArtifactsFileByTagOrBranch", reflect.TypeOf((*MockJobsServiceInterface)(nil).StreamSingleArtifactsFileByTagOrBranch), varargs...)
+	return &MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall{Call: call}
+}
+
+// MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall wrap *gomock.Call
+type MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall) Do(f func(any, string, string, *gitlab.DownloadArtifactsFileOptions, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall) DoAndReturn(f func(any, string, string, *gitlab.DownloadArtifactsFileOptions, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamSingleArtifactsFileByTagOrBranchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StreamTraceFile mocks base method.
+func (m *MockJobsServiceInterface) StreamTraceFile(pid any, jobID int64, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, jobID}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamTraceFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamTraceFile indicates an expected call of StreamTraceFile.
+func (mr *MockJobsServiceInterfaceMockRecorder) StreamTraceFile(pid, jobID any, options ...any) *MockJobsServiceInterfaceStreamTraceFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, jobID}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamTraceFile", reflect.TypeOf((*MockJobsServiceInterface)(nil).StreamTraceFile), varargs...)
+	return &MockJobsServiceInterfaceStreamTraceFileCall{Call: call}
+}
+
+// MockJobsServiceInterfaceStreamTraceFileCall wrap *gomock.Call
+type MockJobsServiceInterfaceStreamTraceFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceStreamTraceFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockJobsServiceInterfaceStreamTraceFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceStreamTraceFileCall) Do(f func(any, int64, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamTraceFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceStreamTraceFileCall) DoAndReturn(f func(any, int64, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockJobsServiceInterfaceStreamTraceFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WaitForJob mocks base method.
+func (m *MockJobsServiceInterface) WaitForJob(pid any, jobID int64, opt *gitlab.WaitForJobOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Job, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, jobID, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForJob", varargs...)
+	ret0, _ := ret[0].(*gitlab.Job)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WaitForJob indicates an expected call of WaitForJob.
+func (mr *MockJobsServiceInterfaceMockRecorder) WaitForJob(pid, jobID, opt any, options ...any) *MockJobsServiceInterfaceWaitForJobCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, jobID, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForJob", reflect.TypeOf((*MockJobsServiceInterface)(nil).WaitForJob), varargs...)
+	return &MockJobsServiceInterfaceWaitForJobCall{Call: call}
+}
+
+// MockJobsServiceInterfaceWaitForJobCall wrap *gomock.Call
+type MockJobsServiceInterfaceWaitForJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockJobsServiceInterfaceWaitForJobCall) Return(arg0 *gitlab.Job, arg1 *gitlab.Response, arg2 error) *MockJobsServiceInterfaceWaitForJobCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockJobsServiceInterfaceWaitForJobCall) Do(f func(any, int64, *gitlab.WaitForJobOptions, ...gitlab.RequestOptionFunc) (*gitlab.Job, *gitlab.Response, error)) *MockJobsServiceInterfaceWaitForJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockJobsServiceInterfaceWaitForJobCall) DoAndReturn(f func(any, int64, *gitlab.WaitForJobOptions, ...gitlab.RequestOptionFunc) (*gitlab.Job, *gitlab.Response, error)) *MockJobsServiceInterfaceWaitForJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
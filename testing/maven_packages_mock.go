@@ -0,0 +1,355 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitlab.com/gitlab-org/api/client-go/v2 (interfaces: MavenPackagesServiceInterface)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=maven_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 MavenPackagesServiceInterface
+//
+
+package testing
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMavenPackagesServiceInterface is a mock of MavenPackagesServiceInterface interface.
+type MockMavenPackagesServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMavenPackagesServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMavenPackagesServiceInterfaceMockRecorder is the mock recorder for MockMavenPackagesServiceInterface.
+type MockMavenPackagesServiceInterfaceMockRecorder struct {
+	mock *MockMavenPackagesServiceInterface
+}
+
+// NewMockMavenPackagesServiceInterface creates a new mock instance.
+func NewMockMavenPackagesServiceInterface(ctrl *gomock.Controller) *MockMavenPackagesServiceInterface {
+	mock := &MockMavenPackagesServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockMavenPackagesServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMavenPackagesServiceInterface) EXPECT() *MockMavenPackagesServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DownloadGroupPackageFile mocks base method.
+func (m *MockMavenPackagesServiceInterface) DownloadGroupPackageFile(gid any, packagePath, fileName string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{gid, packagePath, fileName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadGroupPackageFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadGroupPackageFile indicates an expected call of DownloadGroupPackageFile.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) DownloadGroupPackageFile(gid, packagePath, fileName any, options ...any) *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{gid, packagePath, fileName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadGroupPackageFile", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).DownloadGroupPackageFile), varargs...)
+	return &MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall) Do(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall) DoAndReturn(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceDownloadGroupPackageFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DownloadInstancePackageFile mocks base method.
+func (m *MockMavenPackagesServiceInterface) DownloadInstancePackageFile(packagePath, fileName string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{packagePath, fileName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadInstancePackageFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadInstancePackageFile indicates an expected call of DownloadInstancePackageFile.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) DownloadInstancePackageFile(packagePath, fileName any, options ...any) *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{packagePath, fileName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadInstancePackageFile", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).DownloadInstancePackageFile), varargs...)
+	return &MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall) Do(f func(string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall) DoAndReturn(f func(string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceDownloadInstancePackageFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DownloadPackageFile mocks base method.
+func (m *MockMavenPackagesServiceInterface) DownloadPackageFile(pid any, packagePath, fileName string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packagePath, fileName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadPackageFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadPackageFile indicates an expected call of DownloadPackageFile.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) DownloadPackageFile(pid, packagePath, fileName any, options ...any) *MockMavenPackagesServiceInterfaceDownloadPackageFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packagePath, fileName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadPackageFile", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).DownloadPackageFile), varargs...)
+	return &MockMavenPackagesServiceInterfaceDownloadPackageFileCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceDownloadPackageFileCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceDownloadPackageFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceDownloadPackageFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockMavenPackagesServiceInterfaceDownloadPackageFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceDownloadPackageFileCall) Do(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceDownloadPackageFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceDownloadPackageFileCall) DoAndReturn(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceDownloadPackageFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetGroupPackageMetadata mocks base method.
+func (m *MockMavenPackagesServiceInterface) GetGroupPackageMetadata(gid any, packagePath string, options ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{gid, packagePath}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGroupPackageMetadata", varargs...)
+	ret0, _ := ret[0].(*gitlab.MavenMetadata)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGroupPackageMetadata indicates an expected call of GetGroupPackageMetadata.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) GetGroupPackageMetadata(gid, packagePath any, options ...any) *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{gid, packagePath}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPackageMetadata", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).GetGroupPackageMetadata), varargs...)
+	return &MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall) Return(arg0 *gitlab.MavenMetadata, arg1 *gitlab.Response, arg2 error) *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInstancePackageMetadata mocks base method.
+func (m *MockMavenPackagesServiceInterface) GetInstancePackageMetadata(packagePath string, options ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{packagePath}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetInstancePackageMetadata", varargs...)
+	ret0, _ := ret[0].(*gitlab.MavenMetadata)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInstancePackageMetadata indicates an expected call of GetInstancePackageMetadata.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) GetInstancePackageMetadata(packagePath any, options ...any) *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{packagePath}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstancePackageMetadata", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).GetInstancePackageMetadata), varargs...)
+	return &MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall) Return(arg0 *gitlab.MavenMetadata, arg1 *gitlab.Response, arg2 error) *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall) Do(f func(string, ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall) DoAndReturn(f func(string, ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPackageMetadata mocks base method.
+func (m *MockMavenPackagesServiceInterface) GetPackageMetadata(pid any, packagePath string, options ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packagePath}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPackageMetadata", varargs...)
+	ret0, _ := ret[0].(*gitlab.MavenMetadata)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPackageMetadata indicates an expected call of GetPackageMetadata.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) GetPackageMetadata(pid, packagePath any, options ...any) *MockMavenPackagesServiceInterfaceGetPackageMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packagePath}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageMetadata", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).GetPackageMetadata), varargs...)
+	return &MockMavenPackagesServiceInterfaceGetPackageMetadataCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceGetPackageMetadataCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceGetPackageMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceGetPackageMetadataCall) Return(arg0 *gitlab.MavenMetadata, arg1 *gitlab.Response, arg2 error) *MockMavenPackagesServiceInterfaceGetPackageMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceGetPackageMetadataCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceGetPackageMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceGetPackageMetadataCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.MavenMetadata, *gitlab.Response, error)) *MockMavenPackagesServiceInterfaceGetPackageMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UploadPackageFile mocks base method.
+func (m *MockMavenPackagesServiceInterface) UploadPackageFile(pid any, packagePath, fileName string, content io.Reader, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packagePath, fileName, content}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadPackageFile", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPackageFile indicates an expected call of UploadPackageFile.
+func (mr *MockMavenPackagesServiceInterfaceMockRecorder) UploadPackageFile(pid, packagePath, fileName, content any, options ...any) *MockMavenPackagesServiceInterfaceUploadPackageFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packagePath, fileName, content}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPackageFile", reflect.TypeOf((*MockMavenPackagesServiceInterface)(nil).UploadPackageFile), varargs...)
+	return &MockMavenPackagesServiceInterfaceUploadPackageFileCall{Call: call}
+}
+
+// MockMavenPackagesServiceInterfaceUploadPackageFileCall wrap *gomock.Call
+type MockMavenPackagesServiceInterfaceUploadPackageFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMavenPackagesServiceInterfaceUploadPackageFileCall) Return(arg0 *gitlab.Response, arg1 error) *MockMavenPackagesServiceInterfaceUploadPackageFileCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMavenPackagesServiceInterfaceUploadPackageFileCall) Do(f func(any, string, string, io.Reader, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockMavenPackagesServiceInterfaceUploadPackageFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMavenPackagesServiceInterfaceUploadPackageFileCall) DoAndReturn(f func(any, string, string, io.Reader, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockMavenPackagesServiceInterfaceUploadPackageFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
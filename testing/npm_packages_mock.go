@@ -0,0 +1,398 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitlab.com/gitlab-org/api/client-go/v2 (interfaces: NPMPackagesServiceInterface)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=npm_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 NPMPackagesServiceInterface
+//
+
+package testing
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNPMPackagesServiceInterface is a mock of NPMPackagesServiceInterface interface.
+type MockNPMPackagesServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNPMPackagesServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockNPMPackagesServiceInterfaceMockRecorder is the mock recorder for MockNPMPackagesServiceInterface.
+type MockNPMPackagesServiceInterfaceMockRecorder struct {
+	mock *MockNPMPackagesServiceInterface
+}
+
+// NewMockNPMPackagesServiceInterface creates a new mock instance.
+func NewMockNPMPackagesServiceInterface(ctrl *gomock.Controller) *MockNPMPackagesServiceInterface {
+	mock := &MockNPMPackagesServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockNPMPackagesServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNPMPackagesServiceInterface) EXPECT() *MockNPMPackagesServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateOrUpdateDistTag mocks base method.
+func (m *MockNPMPackagesServiceInterface) CreateOrUpdateDistTag(pid any, packageName, tag, version string, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packageName, tag, version}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateOrUpdateDistTag", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdateDistTag indicates an expected call of CreateOrUpdateDistTag.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) CreateOrUpdateDistTag(pid, packageName, tag, version any, options ...any) *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packageName, tag, version}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateDistTag", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).CreateOrUpdateDistTag), varargs...)
+	return &MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall) Return(arg0 *gitlab.Response, arg1 error) *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall) Do(f func(any, string, string, string, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall) DoAndReturn(f func(any, string, string, string, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockNPMPackagesServiceInterfaceCreateOrUpdateDistTagCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DeleteDistTag mocks base method.
+func (m *MockNPMPackagesServiceInterface) DeleteDistTag(pid any, packageName, tag string, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packageName, tag}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteDistTag", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDistTag indicates an expected call of DeleteDistTag.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) DeleteDistTag(pid, packageName, tag any, options ...any) *MockNPMPackagesServiceInterfaceDeleteDistTagCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packageName, tag}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDistTag", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).DeleteDistTag), varargs...)
+	return &MockNPMPackagesServiceInterfaceDeleteDistTagCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceDeleteDistTagCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceDeleteDistTagCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceDeleteDistTagCall) Return(arg0 *gitlab.Response, arg1 error) *MockNPMPackagesServiceInterfaceDeleteDistTagCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceDeleteDistTagCall) Do(f func(any, string, string, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockNPMPackagesServiceInterfaceDeleteDistTagCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceDeleteDistTagCall) DoAndReturn(f func(any, string, string, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockNPMPackagesServiceInterfaceDeleteDistTagCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DownloadPackageTarball mocks base method.
+func (m *MockNPMPackagesServiceInterface) DownloadPackageTarball(pid any, packageName, fileName string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packageName, fileName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadPackageTarball", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadPackageTarball indicates an expected call of DownloadPackageTarball.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) DownloadPackageTarball(pid, packageName, fileName any, options ...any) *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packageName, fileName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadPackageTarball", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).DownloadPackageTarball), varargs...)
+	return &MockNPMPackagesServiceInterfaceDownloadPackageTarballCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceDownloadPackageTarballCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceDownloadPackageTarballCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall) Do(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall) DoAndReturn(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceDownloadPackageTarballCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetGroupPackageMetadata mocks base method.
+func (m *MockNPMPackagesServiceInterface) GetGroupPackageMetadata(gid any, packageName string, options ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{gid, packageName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGroupPackageMetadata", varargs...)
+	ret0, _ := ret[0].(*gitlab.NPMPackageMetadata)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGroupPackageMetadata indicates an expected call of GetGroupPackageMetadata.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) GetGroupPackageMetadata(gid, packageName any, options ...any) *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{gid, packageName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPackageMetadata", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).GetGroupPackageMetadata), varargs...)
+	return &MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall) Return(arg0 *gitlab.NPMPackageMetadata, arg1 *gitlab.Response, arg2 error) *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceGetGroupPackageMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetInstancePackageMetadata mocks base method.
+func (m *MockNPMPackagesServiceInterface) GetInstancePackageMetadata(packageName string, options ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{packageName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetInstancePackageMetadata", varargs...)
+	ret0, _ := ret[0].(*gitlab.NPMPackageMetadata)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetInstancePackageMetadata indicates an expected call of GetInstancePackageMetadata.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) GetInstancePackageMetadata(packageName any, options ...any) *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{packageName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstancePackageMetadata", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).GetInstancePackageMetadata), varargs...)
+	return &MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall) Return(arg0 *gitlab.NPMPackageMetadata, arg1 *gitlab.Response, arg2 error) *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall) Do(f func(string, ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall) DoAndReturn(f func(string, ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceGetInstancePackageMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetPackageMetadata mocks base method.
+func (m *MockNPMPackagesServiceInterface) GetPackageMetadata(pid any, packageName string, options ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packageName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetPackageMetadata", varargs...)
+	ret0, _ := ret[0].(*gitlab.NPMPackageMetadata)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPackageMetadata indicates an expected call of GetPackageMetadata.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) GetPackageMetadata(pid, packageName any, options ...any) *MockNPMPackagesServiceInterfaceGetPackageMetadataCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packageName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackageMetadata", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).GetPackageMetadata), varargs...)
+	return &MockNPMPackagesServiceInterfaceGetPackageMetadataCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceGetPackageMetadataCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceGetPackageMetadataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceGetPackageMetadataCall) Return(arg0 *gitlab.NPMPackageMetadata, arg1 *gitlab.Response, arg2 error) *MockNPMPackagesServiceInterfaceGetPackageMetadataCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceGetPackageMetadataCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceGetPackageMetadataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceGetPackageMetadataCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) (*gitlab.NPMPackageMetadata, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceGetPackageMetadataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListDistTags mocks base method.
+func (m *MockNPMPackagesServiceInterface) ListDistTags(pid any, packageName string, options ...gitlab.RequestOptionFunc) (map[string]string, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packageName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDistTags", varargs...)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDistTags indicates an expected call of ListDistTags.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) ListDistTags(pid, packageName any, options ...any) *MockNPMPackagesServiceInterfaceListDistTagsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packageName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDistTags", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).ListDistTags), varargs...)
+	return &MockNPMPackagesServiceInterfaceListDistTagsCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfaceListDistTagsCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfaceListDistTagsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfaceListDistTagsCall) Return(arg0 map[string]string, arg1 *gitlab.Response, arg2 error) *MockNPMPackagesServiceInterfaceListDistTagsCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfaceListDistTagsCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) (map[string]string, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceListDistTagsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfaceListDistTagsCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) (map[string]string, *gitlab.Response, error)) *MockNPMPackagesServiceInterfaceListDistTagsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PublishPackage mocks base method.
+func (m *MockNPMPackagesServiceInterface) PublishPackage(pid any, opt *gitlab.PublishNPMPackageOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PublishPackage", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishPackage indicates an expected call of PublishPackage.
+func (mr *MockNPMPackagesServiceInterfaceMockRecorder) PublishPackage(pid, opt any, options ...any) *MockNPMPackagesServiceInterfacePublishPackageCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPackage", reflect.TypeOf((*MockNPMPackagesServiceInterface)(nil).PublishPackage), varargs...)
+	return &MockNPMPackagesServiceInterfacePublishPackageCall{Call: call}
+}
+
+// MockNPMPackagesServiceInterfacePublishPackageCall wrap *gomock.Call
+type MockNPMPackagesServiceInterfacePublishPackageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockNPMPackagesServiceInterfacePublishPackageCall) Return(arg0 *gitlab.Response, arg1 error) *MockNPMPackagesServiceInterfacePublishPackageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockNPMPackagesServiceInterfacePublishPackageCall) Do(f func(any, *gitlab.PublishNPMPackageOptions, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockNPMPackagesServiceInterfacePublishPackageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockNPMPackagesServiceInterfacePublishPackageCall) DoAndReturn(f func(any, *gitlab.PublishNPMPackageOptions, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockNPMPackagesServiceInterfacePublishPackageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
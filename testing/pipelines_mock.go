@@ -532,3 +532,48 @@ func (c *MockPipelinesServiceInterfaceUpdatePipelineMetadataCall) DoAndReturn(f
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// WaitForPipeline mocks base method.
+func (m *MockPipelinesServiceInterface) WaitForPipeline(pid any, pipeline int64, opt *gitlab.WaitForPipelineOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Pipeline, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, pipeline, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForPipeline", varargs...)
+	ret0, _ := ret[0].(*gitlab.Pipeline)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WaitForPipeline indicates an expected call of WaitForPipeline.
+func (mr *MockPipelinesServiceInterfaceMockRecorder) WaitForPipeline(pid, pipeline, opt any, options ...any) *MockPipelinesServiceInterfaceWaitForPipelineCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, pipeline, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForPipeline", reflect.TypeOf((*MockPipelinesServiceInterface)(nil).WaitForPipeline), varargs...)
+	return &MockPipelinesServiceInterfaceWaitForPipelineCall{Call: call}
+}
+
+// MockPipelinesServiceInterfaceWaitForPipelineCall wrap *gomock.Call
+type MockPipelinesServiceInterfaceWaitForPipelineCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPipelinesServiceInterfaceWaitForPipelineCall) Return(arg0 *gitlab.Pipeline, arg1 *gitlab.Response, arg2 error) *MockPipelinesServiceInterfaceWaitForPipelineCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPipelinesServiceInterfaceWaitForPipelineCall) Do(f func(any, int64, *gitlab.WaitForPipelineOptions, ...gitlab.RequestOptionFunc) (*gitlab.Pipeline, *gitlab.Response, error)) *MockPipelinesServiceInterfaceWaitForPipelineCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPipelinesServiceInterfaceWaitForPipelineCall) DoAndReturn(f func(any, int64, *gitlab.WaitForPipelineOptions, ...gitlab.RequestOptionFunc) (*gitlab.Pipeline, *gitlab.Response, error)) *MockPipelinesServiceInterfaceWaitForPipelineCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -263,3 +263,138 @@ func (c *MockProjectImportExportServiceInterfaceScheduleExportCall) DoAndReturn(
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// StreamExportDownload mocks base method.
+func (m *MockProjectImportExportServiceInterface) StreamExportDownload(pid any, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamExportDownload", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StreamExportDownload indicates an expected call of StreamExportDownload.
+func (mr *MockProjectImportExportServiceInterfaceMockRecorder) StreamExportDownload(pid any, options ...any) *MockProjectImportExportServiceInterfaceStreamExportDownloadCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamExportDownload", reflect.TypeOf((*MockProjectImportExportServiceInterface)(nil).StreamExportDownload), varargs...)
+	return &MockProjectImportExportServiceInterfaceStreamExportDownloadCall{Call: call}
+}
+
+// MockProjectImportExportServiceInterfaceStreamExportDownloadCall wrap *gomock.Call
+type MockProjectImportExportServiceInterfaceStreamExportDownloadCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProjectImportExportServiceInterfaceStreamExportDownloadCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockProjectImportExportServiceInterfaceStreamExportDownloadCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProjectImportExportServiceInterfaceStreamExportDownloadCall) Do(f func(any, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockProjectImportExportServiceInterfaceStreamExportDownloadCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProjectImportExportServiceInterfaceStreamExportDownloadCall) DoAndReturn(f func(any, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockProjectImportExportServiceInterfaceStreamExportDownloadCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WaitForExport mocks base method.
+func (m *MockProjectImportExportServiceInterface) WaitForExport(pid any, opt *gitlab.WaitForExportOptions, options ...gitlab.RequestOptionFunc) (*gitlab.ExportStatus, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForExport", varargs...)
+	ret0, _ := ret[0].(*gitlab.ExportStatus)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WaitForExport indicates an expected call of WaitForExport.
+func (mr *MockProjectImportExportServiceInterfaceMockRecorder) WaitForExport(pid, opt any, options ...any) *MockProjectImportExportServiceInterfaceWaitForExportCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForExport", reflect.TypeOf((*MockProjectImportExportServiceInterface)(nil).WaitForExport), varargs...)
+	return &MockProjectImportExportServiceInterfaceWaitForExportCall{Call: call}
+}
+
+// MockProjectImportExportServiceInterfaceWaitForExportCall wrap *gomock.Call
+type MockProjectImportExportServiceInterfaceWaitForExportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProjectImportExportServiceInterfaceWaitForExportCall) Return(arg0 *gitlab.ExportStatus, arg1 *gitlab.Response, arg2 error) *MockProjectImportExportServiceInterfaceWaitForExportCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProjectImportExportServiceInterfaceWaitForExportCall) Do(f func(any, *gitlab.WaitForExportOptions, ...gitlab.RequestOptionFunc) (*gitlab.ExportStatus, *gitlab.Response, error)) *MockProjectImportExportServiceInterfaceWaitForExportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProjectImportExportServiceInterfaceWaitForExportCall) DoAndReturn(f func(any, *gitlab.WaitForExportOptions, ...gitlab.RequestOptionFunc) (*gitlab.ExportStatus, *gitlab.Response, error)) *MockProjectImportExportServiceInterfaceWaitForExportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// WaitForImport mocks base method.
+func (m *MockProjectImportExportServiceInterface) WaitForImport(pid any, opt *gitlab.WaitForImportOptions, options ...gitlab.RequestOptionFunc) (*gitlab.ImportStatus, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WaitForImport", varargs...)
+	ret0, _ := ret[0].(*gitlab.ImportStatus)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WaitForImport indicates an expected call of WaitForImport.
+func (mr *MockProjectImportExportServiceInterfaceMockRecorder) WaitForImport(pid, opt any, options ...any) *MockProjectImportExportServiceInterfaceWaitForImportCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForImport", reflect.TypeOf((*MockProjectImportExportServiceInterface)(nil).WaitForImport), varargs...)
+	return &MockProjectImportExportServiceInterfaceWaitForImportCall{Call: call}
+}
+
+// MockProjectImportExportServiceInterfaceWaitForImportCall wrap *gomock.Call
+type MockProjectImportExportServiceInterfaceWaitForImportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProjectImportExportServiceInterfaceWaitForImportCall) Return(arg0 *gitlab.ImportStatus, arg1 *gitlab.Response, arg2 error) *MockProjectImportExportServiceInterfaceWaitForImportCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProjectImportExportServiceInterfaceWaitForImportCall) Do(f func(any, *gitlab.WaitForImportOptions, ...gitlab.RequestOptionFunc) (*gitlab.ImportStatus, *gitlab.Response, error)) *MockProjectImportExportServiceInterfaceWaitForImportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProjectImportExportServiceInterfaceWaitForImportCall) DoAndReturn(f func(any, *gitlab.WaitForImportOptions, ...gitlab.RequestOptionFunc) (*gitlab.ImportStatus, *gitlab.Response, error)) *MockProjectImportExportServiceInterfaceWaitForImportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -1878,6 +1878,94 @@ func (c *MockProjectsServiceInterfaceListUserStarredProjectsCall) DoAndReturn(f
 	return c
 }
 
+// PlanProjectState mocks base method.
+func (m *MockProjectsServiceInterface) PlanProjectState(pid any, state *gitlab.ProjectState, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectPlan, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, state}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PlanProjectState", varargs...)
+	ret0, _ := ret[0].(*gitlab.ProjectPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanProjectState indicates an expected call of PlanProjectState.
+func (mr *MockProjectsServiceInterfaceMockRecorder) PlanProjectState(pid, state any, options ...any) *MockProjectsServiceInterfacePlanProjectStateCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, state}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanProjectState", reflect.TypeOf((*MockProjectsServiceInterface)(nil).PlanProjectState), varargs...)
+	return &MockProjectsServiceInterfacePlanProjectStateCall{Call: call}
+}
+
+// MockProjectsServiceInterfacePlanProjectStateCall wrap *gomock.Call
+type MockProjectsServiceInterfacePlanProjectStateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProjectsServiceInterfacePlanProjectStateCall) Return(arg0 *gitlab.ProjectPlan, arg1 error) *MockProjectsServiceInterfacePlanProjectStateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProjectsServiceInterfacePlanProjectStateCall) Do(f func(any, *gitlab.ProjectState, ...gitlab.RequestOptionFunc) (*gitlab.ProjectPlan, error)) *MockProjectsServiceInterfacePlanProjectStateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProjectsServiceInterfacePlanProjectStateCall) DoAndReturn(f func(any, *gitlab.ProjectState, ...gitlab.RequestOptionFunc) (*gitlab.ProjectPlan, error)) *MockProjectsServiceInterfacePlanProjectStateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ReconcileProjectState mocks base method.
+func (m *MockProjectsServiceInterface) ReconcileProjectState(pid any, state *gitlab.ProjectState, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectPlan, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, state}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReconcileProjectState", varargs...)
+	ret0, _ := ret[0].(*gitlab.ProjectPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileProjectState indicates an expected call of ReconcileProjectState.
+func (mr *MockProjectsServiceInterfaceMockRecorder) ReconcileProjectState(pid, state any, options ...any) *MockProjectsServiceInterfaceReconcileProjectStateCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, state}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileProjectState", reflect.TypeOf((*MockProjectsServiceInterface)(nil).ReconcileProjectState), varargs...)
+	return &MockProjectsServiceInterfaceReconcileProjectStateCall{Call: call}
+}
+
+// MockProjectsServiceInterfaceReconcileProjectStateCall wrap *gomock.Call
+type MockProjectsServiceInterfaceReconcileProjectStateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockProjectsServiceInterfaceReconcileProjectStateCall) Return(arg0 *gitlab.ProjectPlan, arg1 error) *MockProjectsServiceInterfaceReconcileProjectStateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockProjectsServiceInterfaceReconcileProjectStateCall) Do(f func(any, *gitlab.ProjectState, ...gitlab.RequestOptionFunc) (*gitlab.ProjectPlan, error)) *MockProjectsServiceInterfaceReconcileProjectStateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockProjectsServiceInterfaceReconcileProjectStateCall) DoAndReturn(f func(any, *gitlab.ProjectState, ...gitlab.RequestOptionFunc) (*gitlab.ProjectPlan, error)) *MockProjectsServiceInterfaceReconcileProjectStateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // RestoreProject mocks base method.
 func (m *MockProjectsServiceInterface) RestoreProject(pid any, options ...gitlab.RequestOptionFunc) (*gitlab.Project, *gitlab.Response, error) {
 	m.ctrl.T.Helper()
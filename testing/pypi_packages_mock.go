@@ -0,0 +1,310 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitlab.com/gitlab-org/api/client-go/v2 (interfaces: PyPIPackagesServiceInterface)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=pypi_packages_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 PyPIPackagesServiceInterface
+//
+
+package testing
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPyPIPackagesServiceInterface is a mock of PyPIPackagesServiceInterface interface.
+type MockPyPIPackagesServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPyPIPackagesServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPyPIPackagesServiceInterfaceMockRecorder is the mock recorder for MockPyPIPackagesServiceInterface.
+type MockPyPIPackagesServiceInterfaceMockRecorder struct {
+	mock *MockPyPIPackagesServiceInterface
+}
+
+// NewMockPyPIPackagesServiceInterface creates a new mock instance.
+func NewMockPyPIPackagesServiceInterface(ctrl *gomock.Controller) *MockPyPIPackagesServiceInterface {
+	mock := &MockPyPIPackagesServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockPyPIPackagesServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPyPIPackagesServiceInterface) EXPECT() *MockPyPIPackagesServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DownloadPackageFile mocks base method.
+func (m *MockPyPIPackagesServiceInterface) DownloadPackageFile(pid any, sha256, fileName string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, sha256, fileName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadPackageFile", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadPackageFile indicates an expected call of DownloadPackageFile.
+func (mr *MockPyPIPackagesServiceInterfaceMockRecorder) DownloadPackageFile(pid, sha256, fileName any, options ...any) *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, sha256, fileName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadPackageFile", reflect.TypeOf((*MockPyPIPackagesServiceInterface)(nil).DownloadPackageFile), varargs...)
+	return &MockPyPIPackagesServiceInterfaceDownloadPackageFileCall{Call: call}
+}
+
+// MockPyPIPackagesServiceInterfaceDownloadPackageFileCall wrap *gomock.Call
+type MockPyPIPackagesServiceInterfaceDownloadPackageFileCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall) Do(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall) DoAndReturn(f func(any, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceDownloadPackageFileCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetGroupSimplePackage mocks base method.
+func (m *MockPyPIPackagesServiceInterface) GetGroupSimplePackage(gid any, packageName string, options ...gitlab.RequestOptionFunc) ([]*gitlab.PyPIPackageFile, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{gid, packageName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetGroupSimplePackage", varargs...)
+	ret0, _ := ret[0].([]*gitlab.PyPIPackageFile)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGroupSimplePackage indicates an expected call of GetGroupSimplePackage.
+func (mr *MockPyPIPackagesServiceInterfaceMockRecorder) GetGroupSimplePackage(gid, packageName any, options ...any) *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{gid, packageName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupSimplePackage", reflect.TypeOf((*MockPyPIPackagesServiceInterface)(nil).GetGroupSimplePackage), varargs...)
+	return &MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall{Call: call}
+}
+
+// MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall wrap *gomock.Call
+type MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall) Return(arg0 []*gitlab.PyPIPackageFile, arg1 *gitlab.Response, arg2 error) *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPIPackageFile, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPIPackageFile, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceGetGroupSimplePackageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetSimplePackage mocks base method.
+func (m *MockPyPIPackagesServiceInterface) GetSimplePackage(pid any, packageName string, options ...gitlab.RequestOptionFunc) ([]*gitlab.PyPIPackageFile, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, packageName}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSimplePackage", varargs...)
+	ret0, _ := ret[0].([]*gitlab.PyPIPackageFile)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSimplePackage indicates an expected call of GetSimplePackage.
+func (mr *MockPyPIPackagesServiceInterfaceMockRecorder) GetSimplePackage(pid, packageName any, options ...any) *MockPyPIPackagesServiceInterfaceGetSimplePackageCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, packageName}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimplePackage", reflect.TypeOf((*MockPyPIPackagesServiceInterface)(nil).GetSimplePackage), varargs...)
+	return &MockPyPIPackagesServiceInterfaceGetSimplePackageCall{Call: call}
+}
+
+// MockPyPIPackagesServiceInterfaceGetSimplePackageCall wrap *gomock.Call
+type MockPyPIPackagesServiceInterfaceGetSimplePackageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPyPIPackagesServiceInterfaceGetSimplePackageCall) Return(arg0 []*gitlab.PyPIPackageFile, arg1 *gitlab.Response, arg2 error) *MockPyPIPackagesServiceInterfaceGetSimplePackageCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPyPIPackagesServiceInterfaceGetSimplePackageCall) Do(f func(any, string, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPIPackageFile, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceGetSimplePackageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPyPIPackagesServiceInterfaceGetSimplePackageCall) DoAndReturn(f func(any, string, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPIPackageFile, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceGetSimplePackageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListGroupSimpleIndex mocks base method.
+func (m *MockPyPIPackagesServiceInterface) ListGroupSimpleIndex(gid any, options ...gitlab.RequestOptionFunc) ([]*gitlab.PyPISimplePackage, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{gid}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListGroupSimpleIndex", varargs...)
+	ret0, _ := ret[0].([]*gitlab.PyPISimplePackage)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListGroupSimpleIndex indicates an expected call of ListGroupSimpleIndex.
+func (mr *MockPyPIPackagesServiceInterfaceMockRecorder) ListGroupSimpleIndex(gid any, options ...any) *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{gid}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupSimpleIndex", reflect.TypeOf((*MockPyPIPackagesServiceInterface)(nil).ListGroupSimpleIndex), varargs...)
+	return &MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall{Call: call}
+}
+
+// MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall wrap *gomock.Call
+type MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall) Return(arg0 []*gitlab.PyPISimplePackage, arg1 *gitlab.Response, arg2 error) *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall) Do(f func(any, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPISimplePackage, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall) DoAndReturn(f func(any, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPISimplePackage, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceListGroupSimpleIndexCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListSimpleIndex mocks base method.
+func (m *MockPyPIPackagesServiceInterface) ListSimpleIndex(pid any, options ...gitlab.RequestOptionFunc) ([]*gitlab.PyPISimplePackage, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSimpleIndex", varargs...)
+	ret0, _ := ret[0].([]*gitlab.PyPISimplePackage)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSimpleIndex indicates an expected call of ListSimpleIndex.
+func (mr *MockPyPIPackagesServiceInterfaceMockRecorder) ListSimpleIndex(pid any, options ...any) *MockPyPIPackagesServiceInterfaceListSimpleIndexCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSimpleIndex", reflect.TypeOf((*MockPyPIPackagesServiceInterface)(nil).ListSimpleIndex), varargs...)
+	return &MockPyPIPackagesServiceInterfaceListSimpleIndexCall{Call: call}
+}
+
+// MockPyPIPackagesServiceInterfaceListSimpleIndexCall wrap *gomock.Call
+type MockPyPIPackagesServiceInterfaceListSimpleIndexCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPyPIPackagesServiceInterfaceListSimpleIndexCall) Return(arg0 []*gitlab.PyPISimplePackage, arg1 *gitlab.Response, arg2 error) *MockPyPIPackagesServiceInterfaceListSimpleIndexCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPyPIPackagesServiceInterfaceListSimpleIndexCall) Do(f func(any, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPISimplePackage, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceListSimpleIndexCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPyPIPackagesServiceInterfaceListSimpleIndexCall) DoAndReturn(f func(any, ...gitlab.RequestOptionFunc) ([]*gitlab.PyPISimplePackage, *gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceListSimpleIndexCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UploadPackage mocks base method.
+func (m *MockPyPIPackagesServiceInterface) UploadPackage(pid any, fileName string, content io.Reader, opt *gitlab.UploadPyPIPackageOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, fileName, content, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadPackage", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadPackage indicates an expected call of UploadPackage.
+func (mr *MockPyPIPackagesServiceInterfaceMockRecorder) UploadPackage(pid, fileName, content, opt any, options ...any) *MockPyPIPackagesServiceInterfaceUploadPackageCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, fileName, content, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPackage", reflect.TypeOf((*MockPyPIPackagesServiceInterface)(nil).UploadPackage), varargs...)
+	return &MockPyPIPackagesServiceInterfaceUploadPackageCall{Call: call}
+}
+
+// MockPyPIPackagesServiceInterfaceUploadPackageCall wrap *gomock.Call
+type MockPyPIPackagesServiceInterfaceUploadPackageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPyPIPackagesServiceInterfaceUploadPackageCall) Return(arg0 *gitlab.Response, arg1 error) *MockPyPIPackagesServiceInterfaceUploadPackageCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPyPIPackagesServiceInterfaceUploadPackageCall) Do(f func(any, string, io.Reader, *gitlab.UploadPyPIPackageOptions, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceUploadPackageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPyPIPackagesServiceInterfaceUploadPackageCall) DoAndReturn(f func(any, string, io.Reader, *gitlab.UploadPyPIPackageOptions, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockPyPIPackagesServiceInterfaceUploadPackageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
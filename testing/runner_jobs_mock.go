@@ -0,0 +1,265 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitlab.com/gitlab-org/api/client-go/v2 (interfaces: RunnerJobsServiceInterface)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=runner_jobs_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 RunnerJobsServiceInterface
+//
+
+package testing
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunnerJobsServiceInterface is a mock of RunnerJobsServiceInterface interface.
+type MockRunnerJobsServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunnerJobsServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRunnerJobsServiceInterfaceMockRecorder is the mock recorder for MockRunnerJobsServiceInterface.
+type MockRunnerJobsServiceInterfaceMockRecorder struct {
+	mock *MockRunnerJobsServiceInterface
+}
+
+// NewMockRunnerJobsServiceInterface creates a new mock instance.
+func NewMockRunnerJobsServiceInterface(ctrl *gomock.Controller) *MockRunnerJobsServiceInterface {
+	mock := &MockRunnerJobsServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockRunnerJobsServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunnerJobsServiceInterface) EXPECT() *MockRunnerJobsServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AppendJobTrace mocks base method.
+func (m *MockRunnerJobsServiceInterface) AppendJobTrace(jobID int64, token string, offset int64, content []byte, options ...gitlab.RequestOptionFunc) (*gitlab.RunnerJobStatus, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{jobID, token, offset, content}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AppendJobTrace", varargs...)
+	ret0, _ := ret[0].(*gitlab.RunnerJobStatus)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AppendJobTrace indicates an expected call of AppendJobTrace.
+func (mr *MockRunnerJobsServiceInterfaceMockRecorder) AppendJobTrace(jobID, token, offset, content any, options ...any) *MockRunnerJobsServiceInterfaceAppendJobTraceCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{jobID, token, offset, content}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendJobTrace", reflect.TypeOf((*MockRunnerJobsServiceInterface)(nil).AppendJobTrace), varargs...)
+	return &MockRunnerJobsServiceInterfaceAppendJobTraceCall{Call: call}
+}
+
+// MockRunnerJobsServiceInterfaceAppendJobTraceCall wrap *gomock.Call
+type MockRunnerJobsServiceInterfaceAppendJobTraceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRunnerJobsServiceInterfaceAppendJobTraceCall) Return(arg0 *gitlab.RunnerJobStatus, arg1 *gitlab.Response, arg2 error) *MockRunnerJobsServiceInterfaceAppendJobTraceCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRunnerJobsServiceInterfaceAppendJobTraceCall) Do(f func(int64, string, int64, []byte, ...gitlab.RequestOptionFunc) (*gitlab.RunnerJobStatus, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceAppendJobTraceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRunnerJobsServiceInterfaceAppendJobTraceCall) DoAndReturn(f func(int64, string, int64, []byte, ...gitlab.RequestOptionFunc) (*gitlab.RunnerJobStatus, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceAppendJobTraceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// DownloadJobArtifacts mocks base method.
+func (m *MockRunnerJobsServiceInterface) DownloadJobArtifacts(jobID int64, token string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{jobID, token}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadJobArtifacts", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadJobArtifacts indicates an expected call of DownloadJobArtifacts.
+func (mr *MockRunnerJobsServiceInterfaceMockRecorder) DownloadJobArtifacts(jobID, token any, options ...any) *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{jobID, token}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadJobArtifacts", reflect.TypeOf((*MockRunnerJobsServiceInterface)(nil).DownloadJobArtifacts), varargs...)
+	return &MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall{Call: call}
+}
+
+// MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall wrap *gomock.Call
+type MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall) Do(f func(int64, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall) DoAndReturn(f func(int64, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceDownloadJobArtifactsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RequestJob mocks base method.
+func (m *MockRunnerJobsServiceInterface) RequestJob(opt *gitlab.RequestRunnerJobOptions, options ...gitlab.RequestOptionFunc) (*gitlab.RunnerJob, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestJob", varargs...)
+	ret0, _ := ret[0].(*gitlab.RunnerJob)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RequestJob indicates an expected call of RequestJob.
+func (mr *MockRunnerJobsServiceInterfaceMockRecorder) RequestJob(opt any, options ...any) *MockRunnerJobsServiceInterfaceRequestJobCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestJob", reflect.TypeOf((*MockRunnerJobsServiceInterface)(nil).RequestJob), varargs...)
+	return &MockRunnerJobsServiceInterfaceRequestJobCall{Call: call}
+}
+
+// MockRunnerJobsServiceInterfaceRequestJobCall wrap *gomock.Call
+type MockRunnerJobsServiceInterfaceRequestJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRunnerJobsServiceInterfaceRequestJobCall) Return(arg0 *gitlab.RunnerJob, arg1 *gitlab.Response, arg2 error) *MockRunnerJobsServiceInterfaceRequestJobCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRunnerJobsServiceInterfaceRequestJobCall) Do(f func(*gitlab.RequestRunnerJobOptions, ...gitlab.RequestOptionFunc) (*gitlab.RunnerJob, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceRequestJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRunnerJobsServiceInterfaceRequestJobCall) DoAndReturn(f func(*gitlab.RequestRunnerJobOptions, ...gitlab.RequestOptionFunc) (*gitlab.RunnerJob, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceRequestJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateJob mocks base method.
+func (m *MockRunnerJobsServiceInterface) UpdateJob(jobID int64, opt *gitlab.UpdateRunnerJobOptions, options ...gitlab.RequestOptionFunc) (*gitlab.RunnerJobStatus, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{jobID, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateJob", varargs...)
+	ret0, _ := ret[0].(*gitlab.RunnerJobStatus)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateJob indicates an expected call of UpdateJob.
+func (mr *MockRunnerJobsServiceInterfaceMockRecorder) UpdateJob(jobID, opt any, options ...any) *MockRunnerJobsServiceInterfaceUpdateJobCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{jobID, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockRunnerJobsServiceInterface)(nil).UpdateJob), varargs...)
+	return &MockRunnerJobsServiceInterfaceUpdateJobCall{Call: call}
+}
+
+// MockRunnerJobsServiceInterfaceUpdateJobCall wrap *gomock.Call
+type MockRunnerJobsServiceInterfaceUpdateJobCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRunnerJobsServiceInterfaceUpdateJobCall) Return(arg0 *gitlab.RunnerJobStatus, arg1 *gitlab.Response, arg2 error) *MockRunnerJobsServiceInterfaceUpdateJobCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRunnerJobsServiceInterfaceUpdateJobCall) Do(f func(int64, *gitlab.UpdateRunnerJobOptions, ...gitlab.RequestOptionFunc) (*gitlab.RunnerJobStatus, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceUpdateJobCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRunnerJobsServiceInterfaceUpdateJobCall) DoAndReturn(f func(int64, *gitlab.UpdateRunnerJobOptions, ...gitlab.RequestOptionFunc) (*gitlab.RunnerJobStatus, *gitlab.Response, error)) *MockRunnerJobsServiceInterfaceUpdateJobCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UploadJobArtifacts mocks base method.
+func (m *MockRunnerJobsServiceInterface) UploadJobArtifacts(jobID int64, token string, content io.Reader, filename string, opt *gitlab.UploadRunnerJobArtifactsOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{jobID, token, content, filename, opt}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UploadJobArtifacts", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadJobArtifacts indicates an expected call of UploadJobArtifacts.
+func (mr *MockRunnerJobsServiceInterfaceMockRecorder) UploadJobArtifacts(jobID, token, content, filename, opt any, options ...any) *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{jobID, token, content, filename, opt}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadJobArtifacts", reflect.TypeOf((*MockRunnerJobsServiceInterface)(nil).UploadJobArtifacts), varargs...)
+	return &MockRunnerJobsServiceInterfaceUploadJobArtifactsCall{Call: call}
+}
+
+// MockRunnerJobsServiceInterfaceUploadJobArtifactsCall wrap *gomock.Call
+type MockRunnerJobsServiceInterfaceUploadJobArtifactsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall) Return(arg0 *gitlab.Response, arg1 error) *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall) Do(f func(int64, string, io.Reader, string, *gitlab.UploadRunnerJobArtifactsOptions, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall) DoAndReturn(f func(int64, string, io.Reader, string, *gitlab.UploadRunnerJobArtifactsOptions, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockRunnerJobsServiceInterfaceUploadJobArtifactsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,310 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gitlab.com/gitlab-org/api/client-go/v2 (interfaces: TerraformModulesServiceInterface)
+//
+// Generated by this command:
+//
+//	mockgen -typed -destination=terraform_modules_mock.go -write_package_comment=false -package=testing gitlab.com/gitlab-org/api/client-go/v2 TerraformModulesServiceInterface
+//
+
+package testing
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTerraformModulesServiceInterface is a mock of TerraformModulesServiceInterface interface.
+type MockTerraformModulesServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTerraformModulesServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTerraformModulesServiceInterfaceMockRecorder is the mock recorder for MockTerraformModulesServiceInterface.
+type MockTerraformModulesServiceInterfaceMockRecorder struct {
+	mock *MockTerraformModulesServiceInterface
+}
+
+// NewMockTerraformModulesServiceInterface creates a new mock instance.
+func NewMockTerraformModulesServiceInterface(ctrl *gomock.Controller) *MockTerraformModulesServiceInterface {
+	mock := &MockTerraformModulesServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTerraformModulesServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTerraformModulesServiceInterface) EXPECT() *MockTerraformModulesServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DownloadModule mocks base method.
+func (m *MockTerraformModulesServiceInterface) DownloadModule(namespace, moduleName, moduleSystem, moduleVersion string, options ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{namespace, moduleName, moduleSystem, moduleVersion}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DownloadModule", varargs...)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadModule indicates an expected call of DownloadModule.
+func (mr *MockTerraformModulesServiceInterfaceMockRecorder) DownloadModule(namespace, moduleName, moduleSystem, moduleVersion any, options ...any) *MockTerraformModulesServiceInterfaceDownloadModuleCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{namespace, moduleName, moduleSystem, moduleVersion}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadModule", reflect.TypeOf((*MockTerraformModulesServiceInterface)(nil).DownloadModule), varargs...)
+	return &MockTerraformModulesServiceInterfaceDownloadModuleCall{Call: call}
+}
+
+// MockTerraformModulesServiceInterfaceDownloadModuleCall wrap *gomock.Call
+type MockTerraformModulesServiceInterfaceDownloadModuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTerraformModulesServiceInterfaceDownloadModuleCall) Return(arg0 io.ReadCloser, arg1 *gitlab.Response, arg2 error) *MockTerraformModulesServiceInterfaceDownloadModuleCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTerraformModulesServiceInterfaceDownloadModuleCall) Do(f func(string, string, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceDownloadModuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTerraformModulesServiceInterfaceDownloadModuleCall) DoAndReturn(f func(string, string, string, string, ...gitlab.RequestOptionFunc) (io.ReadCloser, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceDownloadModuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetLatestModule mocks base method.
+func (m *MockTerraformModulesServiceInterface) GetLatestModule(namespace, moduleName, moduleSystem string, options ...gitlab.RequestOptionFunc) (*gitlab.TerraformModule, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{namespace, moduleName, moduleSystem}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLatestModule", varargs...)
+	ret0, _ := ret[0].(*gitlab.TerraformModule)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLatestModule indicates an expected call of GetLatestModule.
+func (mr *MockTerraformModulesServiceInterfaceMockRecorder) GetLatestModule(namespace, moduleName, moduleSystem any, options ...any) *MockTerraformModulesServiceInterfaceGetLatestModuleCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{namespace, moduleName, moduleSystem}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestModule", reflect.TypeOf((*MockTerraformModulesServiceInterface)(nil).GetLatestModule), varargs...)
+	return &MockTerraformModulesServiceInterfaceGetLatestModuleCall{Call: call}
+}
+
+// MockTerraformModulesServiceInterfaceGetLatestModuleCall wrap *gomock.Call
+type MockTerraformModulesServiceInterfaceGetLatestModuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTerraformModulesServiceInterfaceGetLatestModuleCall) Return(arg0 *gitlab.TerraformModule, arg1 *gitlab.Response, arg2 error) *MockTerraformModulesServiceInterfaceGetLatestModuleCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTerraformModulesServiceInterfaceGetLatestModuleCall) Do(f func(string, string, string, ...gitlab.RequestOptionFunc) (*gitlab.TerraformModule, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceGetLatestModuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTerraformModulesServiceInterfaceGetLatestModuleCall) DoAndReturn(f func(string, string, string, ...gitlab.RequestOptionFunc) (*gitlab.TerraformModule, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceGetLatestModuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetModule mocks base method.
+func (m *MockTerraformModulesServiceInterface) GetModule(namespace, moduleName, moduleSystem, moduleVersion string, options ...gitlab.RequestOptionFunc) (*gitlab.TerraformModule, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{namespace, moduleName, moduleSystem, moduleVersion}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetModule", varargs...)
+	ret0, _ := ret[0].(*gitlab.TerraformModule)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetModule indicates an expected call of GetModule.
+func (mr *MockTerraformModulesServiceInterfaceMockRecorder) GetModule(namespace, moduleName, moduleSystem, moduleVersion any, options ...any) *MockTerraformModulesServiceInterfaceGetModuleCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{namespace, moduleName, moduleSystem, moduleVersion}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModule", reflect.TypeOf((*MockTerraformModulesServiceInterface)(nil).GetModule), varargs...)
+	return &MockTerraformModulesServiceInterfaceGetModuleCall{Call: call}
+}
+
+// MockTerraformModulesServiceInterfaceGetModuleCall wrap *gomock.Call
+type MockTerraformModulesServiceInterfaceGetModuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTerraformModulesServiceInterfaceGetModuleCall) Return(arg0 *gitlab.TerraformModule, arg1 *gitlab.Response, arg2 error) *MockTerraformModulesServiceInterfaceGetModuleCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTerraformModulesServiceInterfaceGetModuleCall) Do(f func(string, string, string, string, ...gitlab.RequestOptionFunc) (*gitlab.TerraformModule, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceGetModuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTerraformModulesServiceInterfaceGetModuleCall) DoAndReturn(f func(string, string, string, string, ...gitlab.RequestOptionFunc) (*gitlab.TerraformModule, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceGetModuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetModuleDownloadURL mocks base method.
+func (m *MockTerraformModulesServiceInterface) GetModuleDownloadURL(namespace, moduleName, moduleSystem, moduleVersion string, options ...gitlab.RequestOptionFunc) (string, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{namespace, moduleName, moduleSystem, moduleVersion}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetModuleDownloadURL", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetModuleDownloadURL indicates an expected call of GetModuleDownloadURL.
+func (mr *MockTerraformModulesServiceInterfaceMockRecorder) GetModuleDownloadURL(namespace, moduleName, moduleSystem, moduleVersion any, options ...any) *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{namespace, moduleName, moduleSystem, moduleVersion}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModuleDownloadURL", reflect.TypeOf((*MockTerraformModulesServiceInterface)(nil).GetModuleDownloadURL), varargs...)
+	return &MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall{Call: call}
+}
+
+// MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall wrap *gomock.Call
+type MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall) Return(arg0 string, arg1 *gitlab.Response, arg2 error) *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall) Do(f func(string, string, string, string, ...gitlab.RequestOptionFunc) (string, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall) DoAndReturn(f func(string, string, string, string, ...gitlab.RequestOptionFunc) (string, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceGetModuleDownloadURLCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ListModuleVersions mocks base method.
+func (m *MockTerraformModulesServiceInterface) ListModuleVersions(namespace, moduleName, moduleSystem string, options ...gitlab.RequestOptionFunc) ([]*gitlab.TerraformModuleVersion, *gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{namespace, moduleName, moduleSystem}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListModuleVersions", varargs...)
+	ret0, _ := ret[0].([]*gitlab.TerraformModuleVersion)
+	ret1, _ := ret[1].(*gitlab.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListModuleVersions indicates an expected call of ListModuleVersions.
+func (mr *MockTerraformModulesServiceInterfaceMockRecorder) ListModuleVersions(namespace, moduleName, moduleSystem any, options ...any) *MockTerraformModulesServiceInterfaceListModuleVersionsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{namespace, moduleName, moduleSystem}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModuleVersions", reflect.TypeOf((*MockTerraformModulesServiceInterface)(nil).ListModuleVersions), varargs...)
+	return &MockTerraformModulesServiceInterfaceListModuleVersionsCall{Call: call}
+}
+
+// MockTerraformModulesServiceInterfaceListModuleVersionsCall wrap *gomock.Call
+type MockTerraformModulesServiceInterfaceListModuleVersionsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTerraformModulesServiceInterfaceListModuleVersionsCall) Return(arg0 []*gitlab.TerraformModuleVersion, arg1 *gitlab.Response, arg2 error) *MockTerraformModulesServiceInterfaceListModuleVersionsCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTerraformModulesServiceInterfaceListModuleVersionsCall) Do(f func(string, string, string, ...gitlab.RequestOptionFunc) ([]*gitlab.TerraformModuleVersion, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceListModuleVersionsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTerraformModulesServiceInterfaceListModuleVersionsCall) DoAndReturn(f func(string, string, string, ...gitlab.RequestOptionFunc) ([]*gitlab.TerraformModuleVersion, *gitlab.Response, error)) *MockTerraformModulesServiceInterfaceListModuleVersionsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PublishModule mocks base method.
+func (m *MockTerraformModulesServiceInterface) PublishModule(pid any, moduleName, moduleSystem, moduleVersion string, content io.Reader, options ...gitlab.RequestOptionFunc) (*gitlab.Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{pid, moduleName, moduleSystem, moduleVersion, content}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PublishModule", varargs...)
+	ret0, _ := ret[0].(*gitlab.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishModule indicates an expected call of PublishModule.
+func (mr *MockTerraformModulesServiceInterfaceMockRecorder) PublishModule(pid, moduleName, moduleSystem, moduleVersion, content any, options ...any) *MockTerraformModulesServiceInterfacePublishModuleCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{pid, moduleName, moduleSystem, moduleVersion, content}, options...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishModule", reflect.TypeOf((*MockTerraformModulesServiceInterface)(nil).PublishModule), varargs...)
+	return &MockTerraformModulesServiceInterfacePublishModuleCall{Call: call}
+}
+
+// MockTerraformModulesServiceInterfacePublishModuleCall wrap *gomock.Call
+type MockTerraformModulesServiceInterfacePublishModuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockTerraformModulesServiceInterfacePublishModuleCall) Return(arg0 *gitlab.Response, arg1 error) *MockTerraformModulesServiceInterfacePublishModuleCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockTerraformModulesServiceInterfacePublishModuleCall) Do(f func(any, string, string, string, io.Reader, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockTerraformModulesServiceInterfacePublishModuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockTerraformModulesServiceInterfacePublishModuleCall) DoAndReturn(f func(any, string, string, string, io.Reader, ...gitlab.RequestOptionFunc) (*gitlab.Response, error)) *MockTerraformModulesServiceInterfacePublishModuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,194 @@
+//go:build ignore
+
+// trim_openapi generates openapi_v2.yaml, the subset of GitLab's OpenAPI v2
+// description used by the contract tests of the testing package.
+//
+// It downloads doc/api/openapi/openapi_v2.yaml of a GitLab release tag, keeps
+// the paths listed in contractPaths and the definitions they reference, and
+// records the tag in the header of the generated file:
+//
+//	go run testing/testdata/trim_openapi.go -tag v18.5.0-ee
+//
+// Use -in to trim a description that was downloaded before.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// contractPaths are the paths covered by the contract tests.
+var contractPaths = []string{
+	"/api/v4/projects",
+	"/api/v4/projects/{id}",
+	"/api/v4/projects/{id}/merge_requests",
+	"/api/v4/projects/{id}/merge_requests/{merge_request_iid}",
+	"/api/v4/projects/{id}/repository/files/{file_path}",
+	"/api/v4/users",
+	"/api/v4/users/{id}",
+	"/api/v4/groups",
+	"/api/v4/projects/{id}/issues",
+	"/api/v4/projects/{id}/issues/{issue_iid}",
+	"/api/v4/projects/{id}/repository/branches",
+}
+
+// topLevelKeys are the top level keys copied to the generated file.
+var topLevelKeys = []string{"swagger", "info", "host", "basePath", "produces", "consumes", "paths", "definitions"}
+
+const header = `# Code generated by testing/testdata/trim_openapi.go. DO NOT EDIT.
+#
+# A trimmed copy of GitLab's OpenAPI v2 description (doc/api/openapi/openapi_v2.yaml)
+# of release %s, describing the endpoints covered by the contract tests of
+# this package. Regenerate it with:
+#
+#   go run testing/testdata/trim_openapi.go -tag %s
+---
+`
+
+func main() {
+	tag := flag.String("tag", "v18.5.0-ee", "GitLab release `tag` to take the description from")
+	in := flag.String("in", "", "read the full description from `file` instead of downloading it")
+	out := flag.String("out", "testing/testdata/openapi_v2.yaml", "write the trimmed description to `file`")
+	flag.Parse()
+
+	data, err := readDescription(*in, *tag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	trimmed, err := trim(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, *tag, *tag)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(trimmed); err != nil {
+		log.Fatal(err)
+	}
+	if err := enc.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readDescription(in, tag string) ([]byte, error) {
+	if in != "" {
+		return os.ReadFile(in)
+	}
+
+	url := "https://gitlab.com/gitlab-org/gitlab/-/raw/" + tag + "/doc/api/openapi/openapi_v2.yaml"
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// trim returns the top level mapping of the description, reduced to the
+// contract paths and the definitions they reference.
+func trim(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing description: %w", err)
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing description: expected a mapping")
+	}
+	root := doc.Content[0]
+
+	trimmed := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range topLevelKeys {
+		if k, v := lookup(root, key); k != nil {
+			k.HeadComment = ""
+			trimmed.Content = append(trimmed.Content, k, v)
+		}
+	}
+
+	_, paths := lookup(trimmed, "paths")
+	if paths == nil {
+		return nil, fmt.Errorf("description has no paths")
+	}
+	var missing []string
+	paths.Content = filter(paths, func(key string) bool {
+		return slices.Contains(contractPaths, key)
+	})
+	for _, p := range contractPaths {
+		if k, _ := lookup(paths, p); k == nil {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("description lacks the paths %s", strings.Join(missing, ", "))
+	}
+
+	if _, definitions := lookup(trimmed, "definitions"); definitions != nil {
+		used := make(map[string]bool)
+		collectRefs(paths, definitions, used)
+		definitions.Content = filter(definitions, func(key string) bool {
+			return used[key]
+		})
+	}
+
+	return trimmed, nil
+}
+
+// lookup returns the key and value nodes of key in mapping m.
+func lookup(m *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i], m.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// filter returns the key and value nodes of mapping m whose key is kept.
+func filter(m *yaml.Node, keep func(string) bool) []*yaml.Node {
+	var content []*yaml.Node
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if keep(m.Content[i].Value) {
+			content = append(content, m.Content[i], m.Content[i+1])
+		}
+	}
+	return content
+}
+
+// collectRefs adds the definitions referenced by n to used, following the
+// references of the definitions themselves.
+func collectRefs(n, definitions *yaml.Node, used map[string]bool) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			ref, ok := strings.CutPrefix(n.Content[i+1].Value, "#/definitions/")
+			if n.Content[i].Value != "$ref" || !ok || used[ref] {
+				continue
+			}
+			used[ref] = true
+			if _, def := lookup(definitions, ref); def != nil {
+				collectRefs(def, definitions, used)
+			}
+		}
+	}
+	for _, c := range n.Content {
+		collectRefs(c, definitions, used)
+	}
+}
@@ -0,0 +1,702 @@
+package testing
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+const (
+	// hookTimeUTC is the format of the times in pipeline and job payloads.
+	// Merge request and system hook payloads use RFC 3339.
+	hookTimeUTC = "2006-01-02 15:04:05 UTC"
+
+	// blankSHA is the SHA GitLab sends for refs that don't exist.
+	blankSHA = "0000000000000000000000000000000000000000"
+)
+
+// NewMergeEvent returns the payload of a merge request webhook for the given
+// action ("open", "update", "merge", ...) on a merge request of the project.
+// The author of the merge request is used as the user of the event.
+//
+// The returned event can be modified before it is sent, e.g. to set its
+// Changes.
+func NewMergeEvent(project *gitlab.Project, mr *gitlab.MergeRequest, action string) *gitlab.MergeEvent {
+	attrs := gitlab.MergeEventObjectAttributes{
+		ID:                          mr.ID,
+		IID:                         mr.IID,
+		TargetBranch:                mr.TargetBranch,
+		SourceBranch:                mr.SourceBranch,
+		SourceProjectID:             cmp.Or(mr.SourceProjectID, project.ID),
+		TargetProjectID:             cmp.Or(mr.TargetProjectID, project.ID),
+		Title:                       mr.Title,
+		Description:                 mr.Description,
+		CreatedAt:                   hookTime(mr.CreatedAt, time.RFC3339),
+		UpdatedAt:                   hookTime(mr.UpdatedAt, time.RFC3339),
+		StateID:                     mergeRequestStateID(mr.State),
+		State:                       mr.State,
+		DetailedMergeStatus:         mr.DetailedMergeStatus,
+		MergeCommitSHA:              mr.MergeCommitSHA,
+		MergeError:                  mr.MergeError,
+		MergeWhenBuildSucceeds:      mr.MergeWhenPipelineSucceeds,
+		Source:                      hookRepository(project),
+		Target:                      hookRepository(project),
+		BlockingDiscussionsResolved: mr.BlockingDiscussionsResolved,
+		WorkInProgress:              mr.Draft,
+		Draft:                       mr.Draft,
+		FirstContribution:           mr.FirstContribution,
+		URL:                         mr.WebURL,
+		Action:                      action,
+		AssigneeIDs:                 []int64{},
+		ReviewerIDs:                 []int64{},
+		Labels:                      hookLabels(project, mr),
+		LastCommit: gitlab.EventMergeRequestLastCommit{
+			ID:  mr.SHA,
+			URL: commitURL(project, mr.SHA),
+		},
+	}
+	if mr.Author != nil {
+		attrs.AuthorID = mr.Author.ID
+	}
+	if mr.Assignee != nil {
+		attrs.AssigneeID = mr.Assignee.ID
+	}
+	for _, u := range mr.Assignees {
+		attrs.AssigneeIDs = append(attrs.AssigneeIDs, u.ID)
+	}
+	for _, u := range mr.Reviewers {
+		attrs.ReviewerIDs = append(attrs.ReviewerIDs, u.ID)
+	}
+	if mr.Milestone != nil {
+		attrs.MilestoneID = mr.Milestone.ID
+	}
+	if mr.MergeUser != nil {
+		attrs.MergeUserID = mr.MergeUser.ID
+	}
+	if mr.HeadPipeline != nil {
+		attrs.HeadPipelineID = &mr.HeadPipeline.ID
+	}
+	if mr.TimeStats != nil {
+		attrs.TimeEstimate = mr.TimeStats.TimeEstimate
+		attrs.TotalTimeSpent = mr.TimeStats.TotalTimeSpent
+		attrs.HumanTimeEstimate = mr.TimeStats.HumanTimeEstimate
+		attrs.HumanTotalTimeSpent = mr.TimeStats.HumanTotalTimeSpent
+	}
+
+	return &gitlab.MergeEvent{
+		ObjectKind:       "merge_request",
+		EventType:        "merge_request",
+		User:             basicEventUser(mr.Author),
+		Project:          mergeEventProject(project),
+		ObjectAttributes: attrs,
+		Repository:       hookRepository(project),
+		Labels:           attrs.Labels,
+		Assignees:        basicEventUsers(mr.Assignees),
+		Reviewers:        basicEventUsers(mr.Reviewers),
+	}
+}
+
+// NewPipelineEvent returns the payload of a pipeline webhook for a pipeline
+// of the project and its jobs.
+func NewPipelineEvent(project *gitlab.Project, pipeline *gitlab.Pipeline, jobs []*gitlab.Job) *gitlab.PipelineEvent {
+	attrs := gitlab.PipelineEventObjectAttributes{
+		ID:             pipeline.ID,
+		IID:            pipeline.IID,
+		Name:           pipeline.Name,
+		Ref:            pipeline.Ref,
+		Tag:            pipeline.Tag,
+		SHA:            pipeline.SHA,
+		BeforeSHA:      cmp.Or(pipeline.BeforeSHA, blankSHA),
+		Source:         string(pipeline.Source),
+		Status:         pipeline.Status,
+		DetailedStatus: pipeline.Status,
+		Stages:         []string{},
+		CreatedAt:      hookTime(pipeline.CreatedAt, hookTimeUTC),
+		FinishedAt:     hookTime(pipeline.FinishedAt, hookTimeUTC),
+		Duration:       pipeline.Duration,
+		QueuedDuration: pipeline.QueuedDuration,
+		URL:            pipeline.WebURL,
+		Variables:      []gitlab.PipelineEventObjectAttributesVariable{},
+	}
+	if pipeline.DetailedStatus != nil {
+		attrs.DetailedStatus = pipeline.DetailedStatus.Text
+	}
+
+	event := &gitlab.PipelineEvent{
+		ObjectKind:       "pipeline",
+		ObjectAttributes: attrs,
+		User:             basicEventUser(pipeline.User),
+		Project:          gitlab.PipelineEventProject(hookProject(project)),
+		Commit: gitlab.PipelineEventCommit{
+			ID:  pipeline.SHA,
+			URL: commitURL(project, pipeline.SHA),
+		},
+		Builds: []gitlab.PipelineEventBuild{},
+	}
+
+	for _, job := range jobs {
+		if !slices.Contains(event.ObjectAttributes.Stages, job.Stage) {
+			event.ObjectAttributes.Stages = append(event.ObjectAttributes.Stages, job.Stage)
+		}
+		if c := job.Commit; c != nil && c.ID == pipeline.SHA {
+			event.Commit.Title = c.Title
+			event.Commit.Message = c.Message
+			event.Commit.Timestamp = c.AuthoredDate
+			event.Commit.Author = gitlab.EventCommitAuthor{Name: c.AuthorName, Email: c.AuthorEmail}
+		}
+
+		event.Builds = append(event.Builds, gitlab.PipelineEventBuild{
+			ID:             job.ID,
+			Stage:          job.Stage,
+			Name:           job.Name,
+			Status:         job.Status,
+			CreatedAt:      hookTime(job.CreatedAt, hookTimeUTC),
+			StartedAt:      hookTime(job.StartedAt, hookTimeUTC),
+			FinishedAt:     hookTime(job.FinishedAt, hookTimeUTC),
+			Duration:       job.Duration,
+			QueuedDuration: job.QueuedDuration,
+			FailureReason:  job.FailureReason,
+			When:           "on_success",
+			AllowFailure:   job.AllowFailure,
+			User:           userEventUser(job.User),
+			Runner: gitlab.PipelineEventBuildRunner{
+				ID:          job.Runner.ID,
+				Description: job.Runner.Description,
+				Active:      job.Runner.Active,
+				IsShared:    job.Runner.IsShared,
+				Tags:        job.TagList,
+			},
+			ArtifactsFile: gitlab.PipelineEventBuildArtifactsFile{
+				Filename: job.ArtifactsFile.Filename,
+				Size:     job.ArtifactsFile.Size,
+			},
+		})
+	}
+
+	return event
+}
+
+// NewPushEvent returns the payload of a push webhook for pushing the commits
+// to a branch of the project. The commits are ordered from oldest to newest,
+// the last one is the new head of the branch. An empty before SHA denotes a
+// newly created branch.
+func NewPushEvent(project *gitlab.Project, user *gitlab.User, branch, before string, commits []*gitlab.Commit) *gitlab.PushEvent {
+	ref := branch
+	if !strings.HasPrefix(ref, "refs/") {
+		ref = "refs/heads/" + branch
+	}
+
+	event := &gitlab.PushEvent{
+		ObjectKind:        "push",
+		EventName:         "push",
+		Before:            cmp.Or(before, blankSHA),
+		After:             blankSHA,
+		Ref:               ref,
+		ProjectID:         project.ID,
+		Project:           hookProject(project),
+		Repository:        hookRepository(project),
+		Commits:           []*gitlab.PushEventCommit{},
+		TotalCommitsCount: int64(len(commits)),
+	}
+	if user != nil {
+		event.UserID = user.ID
+		event.UserName = user.Name
+		event.UserUsername = user.Username
+		event.UserEmail = user.Email
+		event.UserAvatar = user.AvatarURL
+	}
+
+	for _, c := range commits {
+		event.Commits = append(event.Commits, &gitlab.PushEventCommit{
+			ID:        c.ID,
+			Message:   c.Message,
+			Title:     c.Title,
+			Timestamp: c.AuthoredDate,
+			URL:       commitURL(project, c.ID),
+			Author:    gitlab.EventCommitAuthor{Name: c.AuthorName, Email: c.AuthorEmail},
+			Added:     []string{},
+			Modified:  []string{},
+			Removed:   []string{},
+		})
+	}
+	if len(commits) > 0 {
+		event.After = commits[len(commits)-1].ID
+		event.CheckoutSHA = event.After
+	}
+
+	return event
+}
+
+// NewJobEvent returns the payload of a job webhook for a job of the project.
+func NewJobEvent(project *gitlab.Project, job *gitlab.Job) *gitlab.JobEvent {
+	event := &gitlab.JobEvent{
+		ObjectKind:          "build",
+		Ref:                 job.Ref,
+		Tag:                 job.Tag,
+		BeforeSHA:           blankSHA,
+		SHA:                 cmp.Or(job.Pipeline.Sha, blankSHA),
+		BuildID:             job.ID,
+		BuildName:           job.Name,
+		BuildStage:          job.Stage,
+		BuildStatus:         job.Status,
+		BuildCreatedAt:      hookTime(job.CreatedAt, hookTimeUTC),
+		BuildStartedAt:      hookTime(job.StartedAt, hookTimeUTC),
+		BuildFinishedAt:     hookTime(job.FinishedAt, hookTimeUTC),
+		BuildDuration:       job.Duration,
+		BuildQueuedDuration: job.QueuedDuration,
+		BuildAllowFailure:   job.AllowFailure,
+		BuildFailureReason:  job.FailureReason,
+		PipelineID:          job.Pipeline.ID,
+		ProjectID:           project.ID,
+		ProjectName:         project.NameWithNamespace,
+		User:                userEventUser(job.User),
+		Commit: gitlab.JobEventCommit{
+			ID:     job.Pipeline.ID,
+			SHA:    job.Pipeline.Sha,
+			Status: job.Pipeline.Status,
+		},
+		Repository: hookRepository(project),
+		Runner: gitlab.JobEventRunner{
+			ID:          job.Runner.ID,
+			Active:      job.Runner.Active,
+			IsShared:    job.Runner.IsShared,
+			Description: job.Runner.Description,
+			Tags:        job.TagList,
+		},
+	}
+	if c := job.Commit; c != nil {
+		event.SHA = c.ID
+		event.Commit.SHA = c.ID
+		event.Commit.Message = c.Message
+		event.Commit.AuthorName = c.AuthorName
+		event.Commit.AuthorEmail = c.AuthorEmail
+	}
+	return event
+}
+
+// NewProjectSystemEvent returns the payload of a project system hook for the
+// given event ("project_create", "project_destroy", ...).
+func NewProjectSystemEvent(project *gitlab.Project, eventName string) *gitlab.ProjectSystemEvent {
+	event := &gitlab.ProjectSystemEvent{
+		BaseSystemEvent:   baseSystemEvent(eventName, project.CreatedAt, project.UpdatedAt),
+		Name:              project.Name,
+		Path:              project.Path,
+		PathWithNamespace: project.PathWithNamespace,
+		ProjectID:         project.ID,
+		ProjectVisibility: string(project.Visibility),
+	}
+	if project.Owner != nil {
+		event.OwnerName = project.Owner.Name
+		event.OwnerEmail = project.Owner.Email
+	}
+
+	return event
+}
+
+// NewGroupSystemEvent returns the payload of a group system hook for the
+// given event ("group_create", "group_destroy", ...).
+func NewGroupSystemEvent(group *gitlab.Group, eventName string) *gitlab.GroupSystemEvent {
+	return &gitlab.GroupSystemEvent{
+		BaseSystemEvent:   baseSystemEvent(eventName, group.CreatedAt, nil),
+		Name:              group.Name,
+		Path:              group.Path,
+		PathWithNamespace: group.FullPath,
+		GroupID:           group.ID,
+		ProjectVisibility: string(group.Visibility),
+	}
+}
+
+// NewUserSystemEvent returns the payload of a user system hook for the given
+// event ("user_create", "user_destroy", ...).
+func NewUserSystemEvent(user *gitlab.User, eventName string) *gitlab.UserSystemEvent {
+	return &gitlab.UserSystemEvent{
+		BaseSystemEvent: baseSystemEvent(eventName, user.CreatedAt, nil),
+		ID:              user.ID,
+		Name:            user.Name,
+		Username:        user.Username,
+		Email:           user.Email,
+		State:           user.State,
+	}
+}
+
+// WebhookSender delivers web- and system hook payloads to a URL with the same
+// headers GitLab sends, so webhook consumers can be tested without a running
+// GitLab instance.
+//
+// Example:
+//
+//	func TestMyWebhook(t *testing.T) {
+//	    srv := httptest.NewServer(myWebhookHandler)
+//	    defer srv.Close()
+//
+//	    sender := testing.NewWebhookSender(srv.URL, "secret")
+//	    resp, err := sender.Send(ctx, testing.NewMergeEvent(project, mr, "open"))
+//	    require.NoError(t, err)
+//	    defer resp.Body.Close()
+//	    assert.Equal(t, http.StatusOK, resp.StatusCode)
+//	}
+type WebhookSender struct {
+	url         string
+	token       string
+	instance    string
+	webhookUUID string
+	client      *http.Client
+}
+
+// WebhookSenderOption is a functional option to configure a WebhookSender.
+type WebhookSenderOption func(*WebhookSender)
+
+// WithWebhookSenderHTTPClient sets the HTTP client used to deliver the hooks.
+// The default is http.DefaultClient.
+func WithWebhookSenderHTTPClient(client *http.Client) WebhookSenderOption {
+	return func(s *WebhookSender) {
+		s.client = client
+	}
+}
+
+// WithWebhookSenderInstance sets the URL of the GitLab instance sent in the
+// X-Gitlab-Instance header. The default is https://gitlab.example.com.
+func WithWebhookSenderInstance(instance string) WebhookSenderOption {
+	return func(s *WebhookSender) {
+		s.instance = instance
+	}
+}
+
+// WithWebhookSenderUUID sets the UUID of the webhook sent in the
+// X-Gitlab-Webhook-UUID header. By default a random UUID is used for each
+// WebhookSender.
+func WithWebhookSenderUUID(uuid string) WebhookSenderOption {
+	return func(s *WebhookSender) {
+		s.webhookUUID = uuid
+	}
+}
+
+// NewWebhookSender returns a WebhookSender that delivers hooks to the given
+// URL with the secret token. No X-Gitlab-Token header is sent if the token
+// is empty.
+func NewWebhookSender(url, token string, options ...WebhookSenderOption) *WebhookSender {
+	s := &WebhookSender{
+		url:         url,
+		token:       token,
+		instance:    "https://gitlab.example.com",
+		webhookUUID: newUUID(),
+		client:      http.DefaultClient,
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// NewRequest returns the request delivering the event, without sending it.
+// The request can be passed to an http.Handler directly. The X-Gitlab-Event
+// header is derived from the type of the event, see EventTypeOf.
+func (s *WebhookSender) NewRequest(ctx context.Context, event any) (*http.Request, error) {
+	eventType, err := EventTypeOf(event)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.NewHookRequest(ctx, eventType, payload)
+}
+
+// NewHookRequest returns the request delivering a raw payload with the given
+// event type, without sending it. Every request gets a new event UUID and
+// idempotency key.
+func (s *WebhookSender) NewHookRequest(ctx context.Context, eventType gitlab.EventType, payload []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", string(eventType))
+	req.Header.Set("X-Gitlab-Event-UUID", newUUID())
+	req.Header.Set("X-Gitlab-Webhook-UUID", s.webhookUUID)
+	req.Header.Set("X-Gitlab-Instance", s.instance)
+	req.Header.Set("Idempotency-Key", newUUID())
+	if s.token != "" {
+		req.Header.Set("X-Gitlab-Token", s.token)
+	}
+
+	return req, nil
+}
+
+// Send delivers the event. Like http.Client.Do, a response with a non-2xx
+// status code is not an error, and the caller must close the response body.
+func (s *WebhookSender) Send(ctx context.Context, event any) (*http.Response, error) {
+	req, err := s.NewRequest(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req)
+}
+
+// SendHook delivers a raw payload with the given event type, e.g. one of the
+// payloads of a test fixture. See Send for the handling of the response.
+func (s *WebhookSender) SendHook(ctx context.Context, eventType gitlab.EventType, payload []byte) (*http.Response, error) {
+	req, err := s.NewHookRequest(ctx, eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req)
+}
+
+// EventTypeOf returns the value of the X-Gitlab-Event header GitLab sends
+// with the given event. System hook events use gitlab.EventTypeSystemHook.
+// A *gitlab.MergeEvent is always a webhook event; use
+// WebhookSender.SendHook to send it as a system hook.
+func EventTypeOf(event any) (gitlab.EventType, error) {
+	switch e := event.(type) {
+	case *gitlab.UnknownEvent:
+		return e.Type, nil
+	case *gitlab.BuildEvent:
+		return gitlab.EventTypeBuild, nil
+	case *gitlab.CommitCommentEvent, *gitlab.IssueCommentEvent, *gitlab.MergeCommentEvent, *gitlab.SnippetCommentEvent:
+		return gitlab.EventTypeNote, nil
+	case *gitlab.DeploymentEvent:
+		return gitlab.EventTypeDeployment, nil
+	case *gitlab.EmojiEvent:
+		return gitlab.EventTypeEmoji, nil
+	case *gitlab.FeatureFlagEvent:
+		return gitlab.EventTypeFeatureFlag, nil
+	case *gitlab.GroupResourceAccessTokenEvent, *gitlab.ProjectResourceAccessTokenEvent:
+		return gitlab.EventTypeResourceAccessToken, nil
+	case *gitlab.IssueEvent:
+		return gitlab.EventTypeIssue, nil
+	case *gitlab.JobEvent:
+		return gitlab.EventTypeJob, nil
+	case *gitlab.MemberEvent:
+		return gitlab.EventTypeMember, nil
+	case *gitlab.MergeEvent:
+		return gitlab.EventTypeMergeRequest, nil
+	case *gitlab.MilestoneWebhookEvent:
+		return gitlab.EventTypeMilestone, nil
+	case *gitlab.PipelineEvent:
+		return gitlab.EventTypePipeline, nil
+	case *gitlab.ProjectWebhookEvent:
+		return gitlab.EventTypeProject, nil
+	case *gitlab.PushEvent:
+		return gitlab.EventTypePush, nil
+	case *gitlab.ReleaseEvent:
+		return gitlab.EventTypeRelease, nil
+	case *gitlab.SubGroupEvent:
+		return gitlab.EventTypeSubGroup, nil
+	case *gitlab.TagEvent:
+		return gitlab.EventTypeTagPush, nil
+	case *gitlab.VulnerabilityEvent:
+		return gitlab.EventTypeVulnerability, nil
+	case *gitlab.WikiPageEvent:
+		return gitlab.EventTypeWikiPage, nil
+	case
+		*gitlab.GroupSystemEvent,
+		*gitlab.KeySystemEvent,
+		*gitlab.ProjectSystemEvent,
+		*gitlab.PushSystemEvent,
+		*gitlab.RepositoryUpdateSystemEvent,
+		*gitlab.TagPushSystemEvent,
+		*gitlab.UnknownSystemEvent,
+		*gitlab.UserGroupSystemEvent,
+		*gitlab.UserSystemEvent,
+		*gitlab.UserTeamSystemEvent:
+		return gitlab.EventTypeSystemHook, nil
+	default:
+		return "", fmt.Errorf("unsupported event type %T", event)
+	}
+}
+
+func mergeEventProject(p *gitlab.Project) gitlab.MergeEventProject {
+	project := hookProject(p)
+	return gitlab.MergeEventProject{
+		ID:                project.ID,
+		Name:              project.Name,
+		Description:       project.Description,
+		AvatarURL:         project.AvatarURL,
+		GitSSHURL:         project.GitSSHURL,
+		GitHTTPURL:        project.GitHTTPURL,
+		Namespace:         project.Namespace,
+		PathWithNamespace: project.PathWithNamespace,
+		DefaultBranch:     project.DefaultBranch,
+		CIConfigPath:      p.CIConfigPath,
+		Homepage:          project.Homepage,
+		URL:               project.URL,
+		SSHURL:            project.SSHURL,
+		HTTPURL:           project.HTTPURL,
+		WebURL:            project.WebURL,
+		Visibility:        project.Visibility,
+	}
+}
+
+// hookProject returns the project object of the pipeline and push payloads.
+func hookProject(p *gitlab.Project) gitlab.PushEventProject {
+	project := gitlab.PushEventProject{
+		ID:                p.ID,
+		Name:              p.Name,
+		Description:       p.Description,
+		AvatarURL:         p.AvatarURL,
+		GitSSHURL:         p.SSHURLToRepo,
+		GitHTTPURL:        p.HTTPURLToRepo,
+		PathWithNamespace: p.PathWithNamespace,
+		DefaultBranch:     p.DefaultBranch,
+		Homepage:          p.WebURL,
+		URL:               p.SSHURLToRepo,
+		SSHURL:            p.SSHURLToRepo,
+		HTTPURL:           p.HTTPURLToRepo,
+		WebURL:            p.WebURL,
+		Visibility:        p.Visibility,
+	}
+	if p.Namespace != nil {
+		project.Namespace = p.Namespace.Name
+	}
+
+	return project
+}
+
+func hookRepository(p *gitlab.Project) *gitlab.Repository {
+	r := &gitlab.Repository{
+		Name:              p.Name,
+		Description:       p.Description,
+		WebURL:            p.WebURL,
+		AvatarURL:         p.AvatarURL,
+		GitSSHURL:         p.SSHURLToRepo,
+		GitHTTPURL:        p.HTTPURLToRepo,
+		Visibility:        p.Visibility,
+		PathWithNamespace: p.PathWithNamespace,
+		DefaultBranch:     p.DefaultBranch,
+		Homepage:          p.WebURL,
+		URL:               p.SSHURLToRepo,
+		SSHURL:            p.SSHURLToRepo,
+		HTTPURL:           p.HTTPURLToRepo,
+	}
+	if p.Namespace != nil {
+		r.Namespace = p.Namespace.Name
+	}
+
+	return r
+}
+
+func hookLabels(p *gitlab.Project, mr *gitlab.MergeRequest) []*gitlab.EventLabel {
+	labels := []*gitlab.EventLabel{}
+	if len(mr.LabelDetails) > 0 {
+		for _, l := range mr.LabelDetails {
+			labels = append(labels, &gitlab.EventLabel{
+				ID:          l.ID,
+				Title:       l.Name,
+				Color:       l.Color,
+				Description: l.Description,
+				ProjectID:   p.ID,
+				Type:        "ProjectLabel",
+			})
+		}
+		return labels
+	}
+
+	for _, name := range mr.Labels {
+		labels = append(labels, &gitlab.EventLabel{
+			Title:     name,
+			ProjectID: p.ID,
+			Type:      "ProjectLabel",
+		})
+	}
+	return labels
+}
+
+func basicEventUser(u *gitlab.BasicUser) *gitlab.EventUser {
+	if u == nil {
+		return nil
+	}
+	return &gitlab.EventUser{
+		ID:        u.ID,
+		Name:      u.Name,
+		Username:  u.Username,
+		AvatarURL: u.AvatarURL,
+	}
+}
+
+func basicEventUsers(users []*gitlab.BasicUser) []*gitlab.EventUser {
+	eventUsers := []*gitlab.EventUser{}
+	for _, u := range users {
+		eventUsers = append(eventUsers, basicEventUser(u))
+	}
+	return eventUsers
+}
+
+func userEventUser(u *gitlab.User) *gitlab.EventUser {
+	if u == nil {
+		return nil
+	}
+	return &gitlab.EventUser{
+		ID:        u.ID,
+		Name:      u.Name,
+		Username:  u.Username,
+		AvatarURL: u.AvatarURL,
+		Email:     u.Email,
+	}
+}
+
+func baseSystemEvent(eventName string, createdAt, updatedAt *time.Time) gitlab.BaseSystemEvent {
+	if updatedAt == nil {
+		updatedAt = createdAt
+	}
+	return gitlab.BaseSystemEvent{
+		EventName: eventName,
+		CreatedAt: hookTime(createdAt, time.RFC3339),
+		UpdatedAt: hookTime(updatedAt, time.RFC3339),
+	}
+}
+
+func mergeRequestStateID(state string) gitlab.StateID {
+	switch state {
+	case "opened":
+		return gitlab.StateIDOpen
+	case "closed":
+		return gitlab.StateIDClosed
+	case "merged":
+		return gitlab.StateIDMerged
+	case "locked":
+		return gitlab.StateIDLocked
+	default:
+		return gitlab.StateIDNone
+	}
+}
+
+func hookTime(t *time.Time, layout string) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(layout)
+}
+
+func commitURL(p *gitlab.Project, sha string) string {
+	if p.WebURL == "" || sha == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/-/commit/%s", p.WebURL, sha)
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
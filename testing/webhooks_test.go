@@ -0,0 +1,216 @@
+package testing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN
+	srv := NewFakeServer(t)
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	project, _, err := client.Projects.CreateProject(&gitlab.CreateProjectOptions{
+		Name:                 gitlab.Ptr("webhooks"),
+		InitializeWithReadme: gitlab.Ptr(true),
+	})
+	require.NoError(t, err)
+	srv.AddBranch(project.ID, "feature", "main")
+
+	mr, _, err := client.MergeRequests.CreateMergeRequest(project.ID, &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.Ptr("Add feature"),
+		SourceBranch: gitlab.Ptr("feature"),
+		TargetBranch: gitlab.Ptr("main"),
+		Labels:       &gitlab.LabelOptions{"feature"},
+	})
+	require.NoError(t, err)
+
+	var (
+		got      *gitlab.MergeEvent
+		delivery *gitlab.WebhookDelivery
+	)
+	h := gitlab.NewWebhookHandler("secret")
+	h.OnMergeRequest(func(ctx context.Context, e *gitlab.MergeEvent) error {
+		got = e
+		delivery, _ = gitlab.WebhookDeliveryFromContext(ctx)
+		return nil
+	})
+	consumer := httptest.NewServer(h)
+	t.Cleanup(consumer.Close)
+
+	sender := NewWebhookSender(consumer.URL, "secret", WithWebhookSenderUUID("hook-uuid"))
+
+	// WHEN
+	resp, err := sender.Send(context.Background(), NewMergeEvent(project, mr, "open"))
+
+	// THEN
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NotNil(t, got)
+	assert.Equal(t, "merge_request", got.ObjectKind)
+	assert.Equal(t, "open", got.ObjectAttributes.Action)
+	assert.Equal(t, mr.IID, got.ObjectAttributes.IID)
+	assert.Equal(t, project.ID, got.ObjectAttributes.TargetProjectID)
+	assert.Equal(t, gitlab.StateIDOpen, got.ObjectAttributes.StateID)
+	assert.Equal(t, "feature", got.ObjectAttributes.SourceBranch)
+	assert.Equal(t, "root/webhooks", got.Project.PathWithNamespace)
+	assert.Equal(t, srv.CurrentUser().Username, got.User.Username)
+	require.Len(t, got.Labels, 1)
+	assert.Equal(t, "feature", got.Labels[0].Title)
+
+	assert.Equal(t, gitlab.EventTypeMergeRequest, delivery.EventType)
+	assert.Equal(t, "hook-uuid", delivery.WebhookUUID)
+	assert.Equal(t, "https://gitlab.example.com", delivery.Instance)
+	assert.Len(t, delivery.EventUUID, 36)
+	assert.NotEqual(t, delivery.EventUUID, delivery.IdempotencyKey)
+
+	// A sender with the wrong token is rejected.
+	resp, err = NewWebhookSender(consumer.URL, "wrong").Send(context.Background(), NewMergeEvent(project, mr, "update"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestWebhookSender_NewRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 1, 12, 30, 0, 0, time.UTC)
+	user := &gitlab.User{ID: 1, Username: "root", Name: "Administrator", Email: "admin@example.com", CreatedAt: &now}
+	project := &gitlab.Project{
+		ID:                42,
+		Name:              "webhooks",
+		Path:              "webhooks",
+		PathWithNamespace: "root/webhooks",
+		DefaultBranch:     "main",
+		Visibility:        gitlab.PrivateVisibility,
+		WebURL:            "https://gitlab.example.com/root/webhooks",
+		Namespace:         &gitlab.ProjectNamespace{Name: "root"},
+		Owner:             user,
+		CreatedAt:         &now,
+	}
+	commit := &gitlab.Commit{ID: "abc123", Title: "Add feature", Message: "Add feature\n", AuthorName: "Administrator", AuthorEmail: "admin@example.com", AuthoredDate: &now}
+	pipeline := &gitlab.Pipeline{ID: 7, IID: 1, ProjectID: 42, Status: "success", Source: "push", Ref: "main", SHA: "abc123", CreatedAt: &now}
+	job := &gitlab.Job{
+		ID:        9,
+		Name:      "test",
+		Stage:     "test",
+		Status:    "success",
+		Ref:       "main",
+		Commit:    commit,
+		Pipeline:  gitlab.JobPipeline{ID: 7, ProjectID: 42, Ref: "main", Sha: "abc123", Status: "success"},
+		TagList:   []string{"docker"},
+		User:      user,
+		CreatedAt: &now,
+	}
+	group := &gitlab.Group{ID: 3, Name: "Group", Path: "group", FullPath: "group", CreatedAt: &now}
+
+	tests := map[string]struct {
+		event     any
+		eventType gitlab.EventType
+		check     func(t *testing.T, event any)
+	}{
+		"pipeline": {
+			event:     NewPipelineEvent(project, pipeline, []*gitlab.Job{job}),
+			eventType: gitlab.EventTypePipeline,
+			check: func(t *testing.T, event any) {
+				e := event.(*gitlab.PipelineEvent)
+				assert.Equal(t, int64(7), e.ObjectAttributes.ID)
+				assert.Equal(t, "2025-05-01 12:30:00 UTC", e.ObjectAttributes.CreatedAt)
+				assert.Equal(t, []string{"test"}, e.ObjectAttributes.Stages)
+				assert.Equal(t, "Add feature", e.Commit.Title)
+				assert.Equal(t, "https://gitlab.example.com/root/webhooks/-/commit/abc123", e.Commit.URL)
+				require.Len(t, e.Builds, 1)
+				assert.Equal(t, []string{"docker"}, e.Builds[0].Runner.Tags)
+			},
+		},
+		"push": {
+			event:     NewPushEvent(project, user, "main", "", []*gitlab.Commit{commit}),
+			eventType: gitlab.EventTypePush,
+			check: func(t *testing.T, event any) {
+				e := event.(*gitlab.PushEvent)
+				assert.Equal(t, "refs/heads/main", e.Ref)
+				assert.Equal(t, blankSHA, e.Before)
+				assert.Equal(t, "abc123", e.After)
+				assert.Equal(t, "root", e.UserUsername)
+				assert.Equal(t, int64(1), e.TotalCommitsCount)
+				assert.Equal(t, "root", e.Project.Namespace)
+			},
+		},
+		"job": {
+			event:     NewJobEvent(project, job),
+			eventType: gitlab.EventTypeJob,
+			check: func(t *testing.T, event any) {
+				e := event.(*gitlab.JobEvent)
+				assert.Equal(t, "build", e.ObjectKind)
+				assert.Equal(t, int64(9), e.BuildID)
+				assert.Equal(t, int64(7), e.PipelineID)
+				assert.Equal(t, "abc123", e.Commit.SHA)
+				assert.Equal(t, "admin@example.com", e.User.Email)
+			},
+		},
+		"project system hook": {
+			event:     NewProjectSystemEvent(project, "project_create"),
+			eventType: gitlab.EventTypeSystemHook,
+			check: func(t *testing.T, event any) {
+				e := event.(*gitlab.ProjectSystemEvent)
+				assert.Equal(t, "project_create", e.EventName)
+				assert.Equal(t, "2025-05-01T12:30:00Z", e.CreatedAt)
+				assert.Equal(t, "Administrator", e.OwnerName)
+				assert.Equal(t, "private", e.ProjectVisibility)
+			},
+		},
+		"group system hook": {
+			event:     NewGroupSystemEvent(group, "group_create"),
+			eventType: gitlab.EventTypeSystemHook,
+			check: func(t *testing.T, event any) {
+				assert.Equal(t, int64(3), event.(*gitlab.GroupSystemEvent).GroupID)
+			},
+		},
+		"user system hook": {
+			event:     NewUserSystemEvent(user, "user_create"),
+			eventType: gitlab.EventTypeSystemHook,
+			check: func(t *testing.T, event any) {
+				assert.Equal(t, "admin@example.com", event.(*gitlab.UserSystemEvent).Email)
+			},
+		},
+	}
+
+	sender := NewWebhookSender("https://consumer.example.com/hooks", "secret")
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := sender.NewRequest(context.Background(), tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, string(tt.eventType), req.Header.Get("X-Gitlab-Event"))
+			assert.Equal(t, "secret", req.Header.Get("X-Gitlab-Token"))
+
+			payload, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			event, err := gitlab.ParseHook(tt.eventType, payload)
+			require.NoError(t, err)
+			tt.check(t, event)
+		})
+	}
+}
+
+func TestEventTypeOf_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := EventTypeOf(gitlab.MergeEvent{})
+	assert.ErrorContains(t, err, "unsupported event type gitlab.MergeEvent")
+}
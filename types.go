@@ -849,8 +849,10 @@ type UploadType string
 
 // The available upload types.
 const (
-	UploadAvatar UploadType = "avatar"
-	UploadFile   UploadType = "file"
+	UploadAvatar  UploadType = "avatar"
+	UploadChart   UploadType = "chart"
+	UploadContent UploadType = "content"
+	UploadFile    UploadType = "file"
 )
 
 // VariableTypeValue represents a variable type within GitLab.
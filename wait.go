@@ -0,0 +1,299 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	defaultWaitPollInterval    = 3 * time.Second
+	defaultWaitMaxPollInterval = 30 * time.Second
+)
+
+// WaitForPipelineOptions represents the available WaitForPipeline() options.
+type WaitForPipelineOptions struct {
+	// PollInterval is the initial interval between two polls. It is doubled
+	// after every poll that didn't change the status, up to MaxPollInterval.
+	// Defaults to 3 seconds.
+	PollInterval time.Duration
+	// MaxPollInterval is the maximum interval between two polls. Defaults to
+	// 30 seconds.
+	MaxPollInterval time.Duration
+	// OnStatusChange is called with the pipeline every time its status
+	// changes, including once for the initial status.
+	OnStatusChange func(*Pipeline)
+}
+
+// WaitForJobOptions represents the available WaitForJob() options.
+type WaitForJobOptions struct {
+	// PollInterval is the initial interval between two polls. It is doubled
+	// after every poll that didn't change the status, up to MaxPollInterval.
+	// Defaults to 3 seconds.
+	PollInterval time.Duration
+	// MaxPollInterval is the maximum interval between two polls. Defaults to
+	// 30 seconds.
+	MaxPollInterval time.Duration
+	// OnStatusChange is called with the job every time its status changes,
+	// including once for the initial status.
+	OnStatusChange func(*Job)
+}
+
+// PipelineFailedError is returned by WaitForPipeline if the pipeline finished
+// without succeeding.
+type PipelineFailedError struct {
+	Pipeline *Pipeline
+	// FailedJobs contains the failed jobs of the pipeline and its downstream
+	// pipelines that were not allowed to fail.
+	FailedJobs []*Job
+	// FailedBridges contains the failed trigger jobs of the pipeline and its
+	// downstream pipelines that were not allowed to fail.
+	FailedBridges []*Bridge
+}
+
+func (e *PipelineFailedError) Error() string {
+	msg := fmt.Sprintf("pipeline %d finished with status %s", e.Pipeline.ID, e.Pipeline.Status)
+	if len(e.FailedJobs) == 0 {
+		return msg
+	}
+
+	jobs := make([]string, 0, len(e.FailedJobs))
+	for _, j := range e.FailedJobs {
+		jobs = append(jobs, fmt.Sprintf("%s (%d)", j.Name, j.ID))
+	}
+	return fmt.Sprintf("%s, failed jobs: %s", msg, strings.Join(jobs, ", "))
+}
+
+// JobFailedError is returned by WaitForJob if the job finished without
+// succeeding.
+type JobFailedError struct {
+	Job *Job
+}
+
+func (e *JobFailedError) Error() string {
+	msg := fmt.Sprintf("job %s (%d) finished with status %s", e.Job.Name, e.Job.ID, e.Job.Status)
+	if e.Job.FailureReason != "" {
+		msg += fmt.Sprintf(" (%s)", e.Job.FailureReason)
+	}
+	return msg
+}
+
+// PipelineManualError is returned by WaitForPipeline if the pipeline is
+// blocked by a manual job, as it doesn't progress without user action.
+type PipelineManualError struct {
+	Pipeline *Pipeline
+}
+
+func (e *PipelineManualError) Error() string {
+	return fmt.Sprintf("pipeline %d is waiting for a manual action", e.Pipeline.ID)
+}
+
+// JobManualError is returned by WaitForJob if the job is a manual job that
+// was not started yet, as it doesn't progress without user action.
+type JobManualError struct {
+	Job *Job
+}
+
+func (e *JobManualError) Error() string {
+	return fmt.Sprintf("job %s (%d) is waiting for a manual action", e.Job.Name, e.Job.ID)
+}
+
+// WaitForPipeline polls a pipeline until it reaches a terminal status. If the
+// pipeline succeeded, it is returned. Otherwise a *PipelineFailedError is
+// returned, which describes the failed jobs, including the ones of downstream
+// pipelines. A pipeline blocked by a manual job is returned with a
+// *PipelineManualError. Scheduled pipelines and pipelines waiting for a
+// resource progress on their own, so waiting continues for them. Use
+// WithContext to stop waiting early.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/pipelines/#get-a-single-pipeline
+func (s *PipelinesService) WaitForPipeline(pid any, pipeline int64, opt *WaitForPipelineOptions, options ...RequestOptionFunc) (*Pipeline, *Response, error) {
+	if opt == nil {
+		opt = &WaitForPipelineOptions{}
+	}
+
+	var status string
+	p, resp, err := pollUntil(s.client.requestContext(options), opt.PollInterval, opt.MaxPollInterval, func() (*Pipeline, *Response, bool, error) {
+		p, resp, err := s.GetPipeline(pid, pipeline, options...)
+		if err != nil {
+			return nil, resp, false, err
+		}
+
+		changed := p.Status != status
+		if changed && opt.OnStatusChange != nil {
+			opt.OnStatusChange(p)
+		}
+		status = p.Status
+
+		return p, resp, changed, nil
+	}, func(p *Pipeline) bool {
+		return isStoppedBuildState(p.Status)
+	})
+	if err != nil {
+		return p, resp, err
+	}
+
+	switch BuildStateValue(p.Status) {
+	case Success:
+		return p, resp, nil
+	case Manual:
+		return p, resp, &PipelineManualError{Pipeline: p}
+	}
+
+	failed := &PipelineFailedError{Pipeline: p}
+	if err := collectFailedJobs(s.client.Jobs, p.ProjectID, p.ID, failed, options); err != nil {
+		return p, resp, fmt.Errorf("%w (listing failed jobs: %w)", failed, err)
+	}
+
+	return p, resp, failed
+}
+
+// WaitForJob polls a job until it reaches a terminal status. If the job
+// succeeded, it is returned. Otherwise a *JobFailedError is returned. A manual
+// job that was not started is returned with a *JobManualError. Scheduled jobs
+// and jobs waiting for a resource progress on their own, so waiting continues
+// for them. Use WithContext to stop waiting early.
+//
+// GitLab API docs:
+// https://docs.gitlab.com/api/jobs/#get-a-single-job
+func (s *JobsService) WaitForJob(pid any, jobID int64, opt *WaitForJobOptions, options ...RequestOptionFunc) (*Job, *Response, error) {
+	if opt == nil {
+		opt = &WaitForJobOptions{}
+	}
+
+	var status string
+	j, resp, err := pollUntil(s.client.requestContext(options), opt.PollInterval, opt.MaxPollInterval, func() (*Job, *Response, bool, error) {
+		j, resp, err := s.GetJob(pid, jobID, options...)
+		if err != nil {
+			return nil, resp, false, err
+		}
+
+		changed := j.Status != status
+		if changed && opt.OnStatusChange != nil {
+			opt.OnStatusChange(j)
+		}
+		status = j.Status
+
+		return j, resp, changed, nil
+	}, func(j *Job) bool {
+		return isStoppedBuildState(j.Status)
+	})
+	if err != nil {
+		return j, resp, err
+	}
+
+	switch BuildStateValue(j.Status) {
+	case Success:
+		return j, resp, nil
+	case Manual:
+		return j, resp, &JobManualError{Job: j}
+	default:
+		return j, resp, &JobFailedError{Job: j}
+	}
+}
+
+// collectFailedJobs adds the failed jobs and bridges of a pipeline to err,
+// descending into the downstream pipelines of failed bridges.
+func collectFailedJobs(s JobsServiceInterface, pid any, pipeline int64, err *PipelineFailedError, options []RequestOptionFunc) error {
+	jobs, e := ScanAndCollect(func(p PaginationOptionFunc) ([]*Job, *Response, error) {
+		return s.ListPipelineJobs(pid, pipeline, &ListJobsOptions{Scope: &[]BuildStateValue{Failed}}, append(options[:len(options):len(options)], p)...)
+	})
+	if e != nil {
+		return e
+	}
+	for _, j := range jobs {
+		if !j.AllowFailure {
+			err.FailedJobs = append(err.FailedJobs, j)
+		}
+	}
+
+	bridges, e := ScanAndCollect(func(p PaginationOptionFunc) ([]*Bridge, *Response, error) {
+		return s.ListPipelineBridges(pid, pipeline, &ListJobsOptions{Scope: &[]BuildStateValue{Failed}}, append(options[:len(options):len(options)], p)...)
+	})
+	if e != nil {
+		return e
+	}
+	for _, b := range bridges {
+		if b.AllowFailure {
+			continue
+		}
+		err.FailedBridges = append(err.FailedBridges, b)
+
+		if d := b.DownstreamPipeline; d != nil {
+			if e := collectFailedJobs(s, d.ProjectID, d.ID, err, options); e != nil {
+				return e
+			}
+		}
+	}
+
+	return nil
+}
+
+// pollUntil calls poll until done reports true for its result, backing off
+// exponentially between polls as long as poll doesn't report a change.
+func pollUntil[T any](ctx context.Context, interval, maxInterval time.Duration, poll func() (T, *Response, bool, error), done func(T) bool) (T, *Response, error) {
+	if interval <= 0 {
+		interval = defaultWaitPollInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = defaultWaitMaxPollInterval
+	}
+	maxInterval = max(maxInterval, interval)
+
+	wait := interval
+	for {
+		v, resp, changed, err := poll()
+		if err != nil || done(v) {
+			return v, resp, err
+		}
+
+		if changed {
+			wait = interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return v, resp, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		wait = min(2*wait, maxInterval)
+	}
+}
+
+// isStoppedBuildState reports whether waiting for a job or pipeline with the
+// given status should stop. Besides the terminal states, this is the case for
+// manual, as it doesn't change without user action. The created, pending,
+// preparing, running, scheduled and waiting_for_resource states all change
+// on their own.
+func isStoppedBuildState(status string) bool {
+	return isTerminalBuildState(status) || BuildStateValue(status) == Manual
+}
+
+// requestContext returns the context set by the given (and the default)
+// request options using WithContext, or context.Background if there is none.
+// Request options are opaque functions, so they are applied to an empty
+// request to find out the context, without encoding a URL or body.
+func (c *Client) requestContext(options []RequestOptionFunc) context.Context {
+	req := &retryablehttp.Request{Request: &http.Request{
+		Method: http.MethodGet,
+		URL:    &url.URL{},
+		Header: make(http.Header),
+	}}
+
+	for _, fn := range append(c.defaultRequestOptions[:len(c.defaultRequestOptions):len(c.defaultRequestOptions)], options...) {
+		if fn == nil {
+			continue
+		}
+		// Errors are reported by the requests made with the options.
+		_ = fn(req)
+	}
+
+	return req.Context()
+}
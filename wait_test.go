@@ -0,0 +1,212 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForPipeline_Success(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	statuses := []string{"created", "pending", "running", "running", "success"}
+	var polls atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/pipelines/10", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		status := statuses[polls.Add(1)-1]
+		fmt.Fprintf(w, `{"id": 10, "project_id": 1, "status": %q}`, status)
+	})
+
+	var changes []string
+	pipeline, _, err := client.Pipelines.WaitForPipeline(1, 10, &WaitForPipelineOptions{
+		PollInterval: time.Millisecond,
+		OnStatusChange: func(p *Pipeline) {
+			changes = append(changes, p.Status)
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "success", pipeline.Status)
+	assert.Equal(t, []string{"created", "pending", "running", "success"}, changes)
+	assert.Equal(t, int32(5), polls.Load())
+}
+
+func TestWaitForPipeline_FailedWithDownstreamPipeline(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	mux.HandleFunc("/api/v4/projects/1/pipelines/10", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 10, "project_id": 1, "status": "failed"}`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/10/jobs", func(w http.ResponseWriter, r *http.Request) {
+		testParam(t, r, "scope[]", "failed")
+		fmt.Fprint(w, `[
+			{"id": 100, "name": "lint", "status": "failed", "allow_failure": true},
+			{"id": 101, "name": "build", "status": "failed"}
+		]`)
+	})
+	mux.HandleFunc("/api/v4/projects/1/pipelines/10/bridges", func(w http.ResponseWriter, r *http.Request) {
+		testParam(t, r, "scope[]", "failed")
+		fmt.Fprint(w, `[
+			{"id": 102, "name": "deploy", "status": "failed", "downstream_pipeline": {"id": 20, "project_id": 2, "status": "failed"}}
+		]`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/pipelines/20/jobs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": 200, "name": "rollout", "status": "failed", "pipeline": {"id": 20, "project_id": 2}}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/2/pipelines/20/bridges", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	pipeline, _, err := client.Pipelines.WaitForPipeline(1, 10, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, "failed", pipeline.Status)
+
+	var failed *PipelineFailedError
+	require.True(t, errors.As(err, &failed))
+	require.Len(t, failed.FailedJobs, 2)
+	assert.Equal(t, "build", failed.FailedJobs[0].Name)
+	assert.Equal(t, "rollout", failed.FailedJobs[1].Name)
+	assert.Equal(t, int64(2), failed.FailedJobs[1].Pipeline.ProjectID)
+	require.Len(t, failed.FailedBridges, 1)
+	assert.Equal(t, "deploy", failed.FailedBridges[0].Name)
+	assert.Equal(t, "pipeline 10 finished with status failed, failed jobs: build (101), rollout (200)", err.Error())
+}
+
+func TestWaitForPipeline_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux.HandleFunc("/api/v4/projects/1/pipelines/10", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 10, "project_id": 1, "status": "running"}`)
+	})
+
+	_, _, err := client.Pipelines.WaitForPipeline(1, 10, &WaitForPipelineOptions{
+		PollInterval: time.Hour,
+		OnStatusChange: func(*Pipeline) {
+			cancel()
+		},
+	}, WithContext(ctx))
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWaitForJob(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	statuses := []string{"pending", "running", "failed"}
+	var polls atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/jobs/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		status := statuses[polls.Add(1)-1]
+		fmt.Fprintf(w, `{"id": 5, "name": "test", "status": %q, "failure_reason": "script_failure"}`, status)
+	})
+
+	var changes []string
+	job, _, err := client.Jobs.WaitForJob(1, 5, &WaitForJobOptions{
+		PollInterval: time.Millisecond,
+		OnStatusChange: func(j *Job) {
+			changes = append(changes, j.Status)
+		},
+	})
+
+	var failed *JobFailedError
+	require.True(t, errors.As(err, &failed))
+	assert.Equal(t, job, failed.Job)
+	assert.Equal(t, "job test (5) finished with status failed (script_failure)", err.Error())
+	assert.Equal(t, []string{"pending", "running", "failed"}, changes)
+}
+
+func TestWaitForJob_Manual(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	statuses := []string{"scheduled", "waiting_for_resource", "manual"}
+	var polls atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/jobs/5", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[polls.Add(1)-1]
+		fmt.Fprintf(w, `{"id": 5, "name": "deploy", "status": %q}`, status)
+	})
+
+	job, _, err := client.Jobs.WaitForJob(1, 5, &WaitForJobOptions{PollInterval: time.Millisecond})
+
+	var manual *JobManualError
+	require.ErrorAs(t, err, &manual)
+	assert.Equal(t, job, manual.Job)
+	assert.EqualError(t, err, "job deploy (5) is waiting for a manual action")
+	assert.Equal(t, int32(3), polls.Load())
+}
+
+func TestWaitForPipeline_Manual(t *testing.T) {
+	t.Parallel()
+	mux, client := setup(t)
+
+	statuses := []string{"running", "manual"}
+	var polls atomic.Int32
+	mux.HandleFunc("/api/v4/projects/1/pipelines/10", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[polls.Add(1)-1]
+		fmt.Fprintf(w, `{"id": 10, "project_id": 1, "status": %q}`, status)
+	})
+
+	pipeline, _, err := client.Pipelines.WaitForPipeline(1, 10, &WaitForPipelineOptions{PollInterval: time.Millisecond})
+
+	var manual *PipelineManualError
+	require.ErrorAs(t, err, &manual)
+	assert.Equal(t, pipeline, manual.Pipeline)
+	assert.EqualError(t, err, "pipeline 10 is waiting for a manual action")
+}
+
+func TestRequestContext(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "request")
+	defaultCtx := context.WithValue(context.Background(), key{}, "default")
+
+	client, err := NewClient("", WithRequestOptions(WithContext(defaultCtx)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "default", client.requestContext(nil).Value(key{}))
+	assert.Equal(t, "request", client.requestContext([]RequestOptionFunc{WithHeader("X-Test", "1"), WithContext(ctx)}).Value(key{}))
+
+	client, err = NewClient("")
+	require.NoError(t, err)
+	assert.Equal(t, context.Background(), client.requestContext([]RequestOptionFunc{nil, WithSudo("user")}))
+}
+
+func TestPollUntil_Backoff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		polls int
+		times []time.Time
+	)
+	_, _, err := pollUntil(context.Background(), time.Millisecond, 4*time.Millisecond, func() (int, *Response, bool, error) {
+		polls++
+		times = append(times, time.Now())
+		return polls, nil, polls == 1, nil
+	}, func(n int) bool {
+		return n == 5
+	})
+
+	require.NoError(t, err)
+	require.Len(t, times, 5)
+	// The intervals are 1ms, 2ms, 4ms and 4ms, as the status only
+	// changed on the first poll.
+	assert.GreaterOrEqual(t, times[4].Sub(times[3]), 4*time.Millisecond)
+	assert.GreaterOrEqual(t, times[3].Sub(times[2]), 4*time.Millisecond)
+	assert.GreaterOrEqual(t, times[2].Sub(times[1]), 2*time.Millisecond)
+}